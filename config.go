@@ -29,6 +29,20 @@ const (
 	WithGeneric
 )
 
+// EditProtection behavior when a generated file was edited by hand since last generation
+type EditProtection uint
+
+const (
+	// EditOverwrite overwrite hand-edited files
+	EditOverwrite EditProtection = iota
+
+	// EditAbort keep hand-edited files and report a diagnostic naming the file
+	EditAbort
+
+	// EditWriteNew keep hand-edited files and write the generated code next to them as <file>.new
+	EditWriteNew
+)
+
 // Config generator's basic configuration
 type Config struct {
 	db *gorm.DB // db connection
@@ -37,16 +51,23 @@ type Config struct {
 	OutFile      string // query code file name, default: gen.go
 	ModelPkgPath string // generated model code's package name
 	WithUnitTest bool   // generate unit test for query code
-	Incremental  bool   // skip writing unchanged generated files (based on manifest hash)
+	Incremental  bool   // skip writing unchanged generated files (based on manifest hash), skip rendering models and introspecting schema of unchanged tables
 	MergeQuery   bool   // keep previously generated query entries (A+B) when generating subsets
+	Prune        bool   // delete generated files recorded in manifest but not generated anymore, hand-edited files are kept
+
+	EditProtection EditProtection // detect hand-edited generated files (based on manifest hash) before overwriting them
 
 	// generate model global configuration
-	FieldNullable       bool // generate pointer when field is nullable
-	FieldCoverable      bool // generate pointer when field has default value, to fix problem zero value cannot be assign: https://gorm.io/docs/create.html#Default-Values
-	FieldSignable       bool // detect integer field's unsigned type, adjust generated data type
-	FieldWithIndexTag   bool // generate with gorm index tag
-	FieldWithTypeTag    bool // generate with gorm column type tag
-	FieldWithDefaultTag bool
+	FieldNullable        bool // generate pointer when field is nullable
+	FieldCoverable       bool // generate pointer when field has default value, to fix problem zero value cannot be assign: https://gorm.io/docs/create.html#Default-Values
+	FieldSignable        bool // detect integer field's unsigned type, adjust generated data type
+	FieldWithIndexTag    bool // generate with gorm index tag
+	FieldWithTypeTag     bool // generate with gorm column type tag
+	FieldWithDefaultTag  bool
+	FieldWithEnumType    bool // generate named type with typed constants for enum column, and typed query field accepting only them
+	FieldWithDialectType bool // map column type with built-in data type mapping of dialect, like uuid.UUID, datatypes.JSON and pq arrays of postgres
+
+	VersionColumn string // column of optimistic locking version like version or lock_version, models with it get UpdateWithVersion/UpdatesWithVersion
 
 	Mode GenerateMode // generate mode
 
@@ -66,6 +87,11 @@ type Config struct {
 	fieldJSONTagNS func(columnName string) (tagContent string)
 
 	modelOpts []ModelOpt
+
+	// table filters of GenerateAllTable
+	tableIncludes   []tableMatcher
+	tableExcludes   []tableMatcher
+	tableFilterErrs []error
 }
 
 // WithOpts set global  model options
@@ -123,6 +149,30 @@ func (cfg *Config) WithImportPkgPath(paths ...string) {
 	cfg.importPkgPaths = append(cfg.importPkgPaths, paths...)
 }
 
+// WithTableIncludes only generate tables matched by glob patterns in GenerateAllTable, like "user_*"
+func (cfg *Config) WithTableIncludes(patterns ...string) {
+	matchers, errs := globMatchers(patterns)
+	cfg.addTableFilters(true, matchers, errs)
+}
+
+// WithTableIncludesReg only generate tables matched by RegExp in GenerateAllTable
+func (cfg *Config) WithTableIncludesReg(regs ...string) {
+	matchers, errs := regMatchers(regs)
+	cfg.addTableFilters(true, matchers, errs)
+}
+
+// WithTableExcludes skip tables matched by glob patterns in GenerateAllTable, like "flyway_schema_history"
+func (cfg *Config) WithTableExcludes(patterns ...string) {
+	matchers, errs := globMatchers(patterns)
+	cfg.addTableFilters(false, matchers, errs)
+}
+
+// WithTableExcludesReg skip tables matched by RegExp in GenerateAllTable, like `_p\d+$`
+func (cfg *Config) WithTableExcludesReg(regs ...string) {
+	matchers, errs := regMatchers(regs)
+	cfg.addTableFilters(false, matchers, errs)
+}
+
 // WithDataTypesNullType configures the types of fields to use their datatypes nullable counterparts.
 /**
  *
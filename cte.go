@@ -0,0 +1,85 @@
+package gen
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const withClauseName = "WITH"
+
+// withStatementClauses clauses WITH clause is written before, it's kept as their BeforeExpression
+var withStatementClauses = []string{"SELECT", "UPDATE", "DELETE"}
+
+// CTE name of common table expression declared by With/WithRecursive,
+// used as table in Join, or in Table method of generated query struct to select from it
+//
+//	c.With("top", c.Select(c.ID).Order(c.Score.Desc()).Limit(10)).
+//		Join(gen.CTE("top"), c.ID.EqCol(field.NewUint("top", "id"))).Find()
+type CTE string
+
+// TableName implement schema.Tabler
+func (c CTE) TableName() string { return string(c) }
+
+// withClause WITH clause declaring common table expressions, merged under its own name
+// and built before keyword of SELECT/UPDATE/DELETE statement
+type withClause struct {
+	recursive bool
+	tables    []cteTable
+}
+
+type cteTable struct {
+	name  string
+	query interface{} // *gorm.DB or queries combined by set operator
+}
+
+func newWithClause(recursive bool, name string, q SubQuery) withClause {
+	var query interface{} = q.underlyingDO().withoutAlias()
+	if compound, ok := q.underlyingDO().compoundQuery(); ok { // recursive reference is not allowed in derived table
+		query = compound
+	}
+	return withClause{recursive: recursive, tables: []cteTable{{name: name, query: query}}}
+}
+
+// Name clause name
+func (withClause) Name() string { return withClauseName }
+
+// Build build WITH clause
+func (w withClause) Build(builder clause.Builder) {
+	builder.WriteString("WITH ")
+	if w.recursive {
+		builder.WriteString("RECURSIVE ")
+	}
+	for i, t := range w.tables {
+		if i > 0 {
+			builder.WriteByte(',')
+		}
+		builder.WriteQuoted(t.name)
+		builder.WriteString(" AS (")
+		builder.AddVar(builder, t.query)
+		builder.WriteByte(')')
+	}
+}
+
+// MergeClause merge WITH clause, tables declared by With/WithRecursive are appended in order
+func (w withClause) MergeClause(c *clause.Clause) {
+	if exist, ok := c.Expression.(withClause); ok {
+		w.recursive = w.recursive || exist.recursive
+		w.tables = append(exist.tables[:len(exist.tables):len(exist.tables)], w.tables...)
+	}
+	c.Name = "" // keyword is written by Build with RECURSIVE
+	c.Expression = w
+}
+
+// attachWithClause put merged WITH clause of stmt before SELECT/UPDATE/DELETE keyword,
+// other clauses added to them later keep it
+func attachWithClause(stmt *gorm.Statement) {
+	with, ok := stmt.Clauses[withClauseName]
+	if !ok {
+		return
+	}
+	for _, name := range withStatementClauses {
+		c := stmt.Clauses[name]
+		c.BeforeExpression = with.Expression
+		stmt.Clauses[name] = c
+	}
+}
@@ -0,0 +1,232 @@
+package gen
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen/field"
+)
+
+// cursorColumn ordering column of keyset pagination
+type cursorColumn struct {
+	column clause.Column
+	desc   bool
+	field  *schema.Field
+}
+
+// cursorValue content of opaque cursor, ordering values of the row where page starts after (or before if backward)
+type cursorValue struct {
+	Backward bool              `json:"b,omitempty"`
+	Values   []json.RawMessage `json:"v"`
+}
+
+// FindByCursor keyset pagination, query limit rows after the row encoded in cursor ordered by orderCols,
+// first page is queried when cursor is empty. orderCols must be columns of model, like u.Score.Desc(), u.ID,
+// and the last one should be unique (like primary key) to make order stable. Nullable columns (pointer or sql.NullXxx
+// fields without not null tag) cannot be used as NULL is not comparable, and query must not be ordered by Order before.
+//
+// next is the cursor of next page and prev is the cursor of previous page, empty if there is no more page
+func (d *DO) FindByCursor(cursor string, limit int, orderCols ...field.OrderExpr) (results interface{}, next, prev string, err error) {
+	d, done := d.Observe("", "FindByCursor")
+	defer func() { err = done(err) }()
+
+	if d.modelType == nil {
+		return nil, "", "", errors.New("gen: FindByCursor needs model")
+	}
+	if limit <= 0 {
+		return nil, "", "", fmt.Errorf("gen: invalid limit %d, must be > 0", limit)
+	}
+	if _, ok := d.db.Statement.Clauses["ORDER BY"]; ok {
+		return nil, "", "", errors.New("gen: FindByCursor orders query by orderCols, remove Order before it")
+	}
+	cols, err := d.cursorColumns(orderCols)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	var cv cursorValue
+	tx := d.db
+	if cursor != "" {
+		values, err := decodeCursor(cursor, cols, &cv)
+		if err != nil {
+			return nil, "", "", err
+		}
+		tx = tx.Clauses(clause.Where{Exprs: []clause.Expression{keysetCondition(cols, values, cv.Backward)}})
+	}
+	order := clause.OrderBy{Columns: make([]clause.OrderByColumn, len(cols))}
+	for i, col := range cols {
+		order.Columns[i] = clause.OrderByColumn{Column: col.column, Desc: col.desc != cv.Backward}
+	}
+
+	results, err = d.getInstance(tx.Clauses(order).Limit(limit + 1)).Find()
+	if err != nil {
+		return results, "", "", err
+	}
+
+	rows := reflect.ValueOf(results)
+	hasMore := rows.Len() > limit
+	if hasMore {
+		rows = rows.Slice(0, limit)
+	}
+	if cv.Backward {
+		swap := reflect.Swapper(rows.Interface())
+		for i, j := 0, rows.Len()-1; i < j; i, j = i+1, j-1 {
+			swap(i, j)
+		}
+	}
+	if rows.Len() == 0 {
+		return rows.Interface(), "", "", nil
+	}
+
+	ctx := d.db.Statement.Context
+	if hasMore || cv.Backward {
+		if next, err = encodeCursor(ctx, cols, rows.Index(rows.Len()-1), false); err != nil {
+			return rows.Interface(), "", "", err
+		}
+	}
+	if cursor != "" && (hasMore || !cv.Backward) {
+		if prev, err = encodeCursor(ctx, cols, rows.Index(0), true); err != nil {
+			return rows.Interface(), "", "", err
+		}
+	}
+	return rows.Interface(), next, prev, nil
+}
+
+// cursorColumns parse ordering columns and their direction, columns must be fields of model
+func (d *DO) cursorColumns(orderCols []field.OrderExpr) ([]cursorColumn, error) {
+	if len(orderCols) == 0 {
+		return nil, errors.New("gen: FindByCursor needs order columns")
+	}
+	stmt := &gorm.Statement{DB: d.db}
+	if err := stmt.Parse(reflect.New(d.modelType).Interface()); err != nil {
+		return nil, err
+	}
+
+	cols := make([]cursorColumn, len(orderCols))
+	for i, o := range orderCols {
+		col := cursorColumn{}
+		raw := o.RawExpr()
+		if e, ok := raw.(clause.Expr); ok && len(e.Vars) == 1 && (e.SQL == "? DESC" || e.SQL == "? ASC") {
+			raw, col.desc = e.Vars[0], e.SQL == "? DESC"
+		}
+		c, ok := raw.(clause.Column)
+		if !ok {
+			return nil, fmt.Errorf("gen: FindByCursor order column must be column of model, got %v", raw)
+		}
+		col.column = c
+		if c.Name == clause.PrimaryKey {
+			col.field = stmt.Schema.PrioritizedPrimaryField
+			if col.field == nil && len(stmt.Schema.DBNames) > 0 { // same as primary key column quoted by gorm
+				col.field = stmt.Schema.LookUpField(stmt.Schema.DBNames[0])
+			}
+		} else {
+			col.field = stmt.Schema.LookUpField(c.Name)
+		}
+		if col.field == nil {
+			return nil, fmt.Errorf("gen: FindByCursor order column %s is not field of %s", c.Name, stmt.Schema.Name)
+		}
+		if nullable(col.field) {
+			return nil, fmt.Errorf("gen: FindByCursor order column %s is nullable", c.Name)
+		}
+		cols[i] = col
+	}
+	return cols, nil
+}
+
+// nullable report whether field may hold NULL, like *int or sql.NullInt64 without not null tag
+func nullable(f *schema.Field) bool {
+	if f.NotNull || f.PrimaryKey {
+		return false
+	}
+	if f.FieldType.Kind() == reflect.Ptr {
+		return true
+	}
+	if f.FieldType.Kind() != reflect.Struct {
+		return false
+	}
+	valid, ok := f.FieldType.FieldByName("Valid")
+	return ok && valid.Type.Kind() == reflect.Bool && f.FieldType.Implements(reflect.TypeOf((*driver.Valuer)(nil)).Elem())
+}
+
+// keysetCondition rows after values in order of cols, like (a > ?) OR (a = ? AND b < ?) for a ASC, b DESC
+func keysetCondition(cols []cursorColumn, values []interface{}, backward bool) clause.Expression {
+	ors := make([]clause.Expression, len(cols))
+	for i, col := range cols {
+		ands := make([]clause.Expression, 0, i+1)
+		for j := 0; j < i; j++ {
+			ands = append(ands, clause.Eq{Column: cols[j].column, Value: values[j]})
+		}
+		if col.desc != backward {
+			ands = append(ands, clause.Lt{Column: col.column, Value: values[i]})
+		} else {
+			ands = append(ands, clause.Gt{Column: col.column, Value: values[i]})
+		}
+		ors[i] = clause.And(ands...)
+	}
+	return clause.Or(ors...)
+}
+
+func decodeCursor(cursor string, cols []cursorColumn, cv *cursorValue) ([]interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if err = json.Unmarshal(data, cv); err != nil || len(cv.Values) != len(cols) {
+		return nil, ErrInvalidCursor
+	}
+
+	values := make([]interface{}, len(cols))
+	for i, col := range cols {
+		v := reflect.New(col.field.FieldType)
+		if bytes.Equal(cv.Values[i], []byte("null")) {
+			return nil, ErrInvalidCursor
+		}
+		if err = json.Unmarshal(cv.Values[i], v.Interface()); err != nil {
+			return nil, ErrInvalidCursor
+		}
+		values[i] = v.Elem().Interface()
+	}
+	return values, nil
+}
+
+func encodeCursor(ctx context.Context, cols []cursorColumn, row reflect.Value, backward bool) (string, error) {
+	cv := cursorValue{Backward: backward, Values: make([]json.RawMessage, len(cols))}
+	for i, col := range cols {
+		v, _ := col.field.ValueOf(ctx, reflect.Indirect(row))
+		if isNull(v) {
+			return "", fmt.Errorf("gen: FindByCursor order column %s of row is NULL", col.column.Name)
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		cv.Values[i] = data
+	}
+	data, err := json.Marshal(cv)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// isNull report whether value is nil pointer or Valuer of NULL
+func isNull(v interface{}) bool {
+	if rv := reflect.ValueOf(v); !rv.IsValid() || rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return true
+	}
+	if valuer, ok := v.(driver.Valuer); ok {
+		value, err := valuer.Value()
+		return err == nil && value == nil
+	}
+	return false
+}
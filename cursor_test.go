@@ -0,0 +1,139 @@
+package gen
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/utils/tests"
+
+	"gorm.io/gen/field"
+)
+
+type cursorModel struct {
+	ID    uint
+	Score int
+}
+
+func TestDO_FindByCursor(t *testing.T) {
+	rows := []*cursorModel{{ID: 1, Score: 90}, {ID: 2, Score: 80}, {ID: 3, Score: 80}, {ID: 4, Score: 70}}
+	var lastSQL string
+	var lastVars []interface{}
+
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	// fake query returning rows of page in order, simulating the database with rows above
+	_ = db.Callback().Query().Replace("gorm:query", func(db *gorm.DB) {
+		callbacks.BuildQuerySQL(db)
+		lastSQL, lastVars = db.Statement.SQL.String(), db.Statement.Vars
+
+		var result []*cursorModel
+		backward := strings.Contains(lastSQL, "ORDER BY `score`,`id` DESC")
+		for i := range rows {
+			row := rows[i]
+			if backward {
+				row = rows[len(rows)-1-i]
+			}
+			if len(lastVars) > 1 { // the last var is limit
+				score, id := lastVars[0].(int), lastVars[2].(uint)
+				if !backward && (row.Score > score || row.Score == score && row.ID <= id) ||
+					backward && (row.Score < score || row.Score == score && row.ID >= id) {
+					continue
+				}
+			}
+			result = append(result, row)
+		}
+		if limit := *db.Statement.Clauses["LIMIT"].Expression.(clause.Limit).Limit; len(result) > limit {
+			result = result[:limit]
+		}
+		reflect.ValueOf(db.Statement.Dest).Elem().Set(reflect.ValueOf(result))
+	})
+
+	var d DO
+	d.UseDB(db.Session(&gorm.Session{NewDB: true}))
+	d.UseModel(cursorModel{})
+	score, id := field.NewInt("", "score"), field.NewUint("", "id")
+
+	find := func(cursor string) (ids []uint, next, prev string) {
+		results, next, prev, err := d.FindByCursor(cursor, 2, score.Desc(), id)
+		if err != nil {
+			t.Fatalf("FindByCursor: %v", err)
+		}
+		for _, r := range results.([]*cursorModel) {
+			ids = append(ids, r.ID)
+		}
+		return ids, next, prev
+	}
+
+	ids, next, prev := find("")
+	if !reflect.DeepEqual(ids, []uint{1, 2}) || next == "" || prev != "" {
+		t.Fatalf("unexpected first page: %v, next %q, prev %q", ids, next, prev)
+	}
+	if expect := "SELECT * FROM `cursor_models` ORDER BY `score` DESC,`id` LIMIT ?"; lastSQL != expect {
+		t.Errorf("expect %s, got %s", expect, lastSQL)
+	}
+
+	ids, next, prev = find(next)
+	if !reflect.DeepEqual(ids, []uint{3, 4}) || next != "" || prev == "" {
+		t.Fatalf("unexpected second page: %v, next %q, prev %q", ids, next, prev)
+	}
+	if expect := "SELECT * FROM `cursor_models` WHERE (`score` < ? OR (`score` = ? AND `id` > ?)) ORDER BY `score` DESC,`id` LIMIT ?"; lastSQL != expect {
+		t.Errorf("expect %s, got %s", expect, lastSQL)
+	}
+	if !reflect.DeepEqual(lastVars, []interface{}{80, 80, uint(2), 3}) {
+		t.Errorf("unexpected vars: %v", lastVars)
+	}
+
+	ids, next, prev = find(prev)
+	if !reflect.DeepEqual(ids, []uint{1, 2}) || next == "" || prev != "" {
+		t.Fatalf("unexpected previous page: %v, next %q, prev %q", ids, next, prev)
+	}
+	if expect := "SELECT * FROM `cursor_models` WHERE (`score` > ? OR (`score` = ? AND `id` < ?)) ORDER BY `score`,`id` DESC LIMIT ?"; lastSQL != expect {
+		t.Errorf("expect %s, got %s", expect, lastSQL)
+	}
+
+	if _, _, _, err = d.FindByCursor("bad cursor", 2, score.Desc(), id); err != ErrInvalidCursor {
+		t.Errorf("expect ErrInvalidCursor, got %v", err)
+	}
+	if _, _, _, err = d.FindByCursor("", 2, score.Add(1)); err == nil {
+		t.Errorf("expect error for order expression which is not column")
+	}
+	if _, _, _, err = d.FindByCursor("eyJ2IjpbbnVsbCwxXX0", 2, score.Desc(), id); err != ErrInvalidCursor { // {"v":[null,1]}
+		t.Errorf("expect ErrInvalidCursor for NULL value, got %v", err)
+	}
+	if _, _, _, err = d.Order(id).(*DO).FindByCursor("", 2, score.Desc(), id); err == nil {
+		t.Errorf("expect error for query ordered before")
+	}
+}
+
+func TestDO_cursorColumns_Nullable(t *testing.T) {
+	type nullableModel struct {
+		ID        uint
+		Score     *int
+		Rank      sql.NullInt64
+		Level     sql.NullInt64 `gorm:"not null"`
+		CreatedAt time.Time
+	}
+
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	var d DO
+	d.UseDB(db.Session(&gorm.Session{NewDB: true}))
+	d.UseModel(nullableModel{})
+
+	for column, nullable := range map[string]bool{"score": true, "rank": true, "level": false, "created_at": false, "id": false} {
+		_, err := d.cursorColumns([]field.OrderExpr{field.NewField("", column)})
+		if (err != nil) != nullable {
+			t.Errorf("column %s expects nullable %v, got error %v", column, nullable, err)
+		}
+	}
+}
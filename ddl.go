@@ -0,0 +1,81 @@
+package gen
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gorm.io/gen/internal/ddl"
+)
+
+// UseDDL read table schema from DDL files instead of database, so models can be generated without a database connection.
+// dialect is mysql, postgres or sqlite, path is a .sql file or a directory of .sql files such as migrations,
+// files are applied in the order given and files in directory are applied in name order
+func (g *Generator) UseDDL(dialect string, paths ...string) error {
+	schema, err := ddl.NewSchema(dialect)
+	if err != nil {
+		return err
+	}
+
+	files, err := ddlFiles(paths)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("read DDL file fail: %w", err)
+		}
+		if err = schema.Parse(string(content)); err != nil {
+			return fmt.Errorf("parse DDL file %s fail: %w", file, err)
+		}
+	}
+
+	g.schemaSource = schema
+	g.info(fmt.Sprintf("read %d table from %d DDL file", len(schema.Tables()), len(files)))
+	return nil
+}
+
+// GetTables return tables defined in DDL files given by UseDDL, or tables in db
+func (g *Generator) GetTables() ([]string, error) {
+	if g.schemaSource != nil {
+		return g.schemaSource.Tables(), nil
+	}
+	return g.db.Migrator().GetTables()
+}
+
+// ddlFiles expand directories to .sql files in them
+func ddlFiles(paths []string) (files []string, err error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no DDL file is given")
+	}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("read DDL file fail: %w", err)
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("read DDL directory fail: %w", err)
+		}
+		var sqlFiles []string
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.EqualFold(filepath.Ext(entry.Name()), ".sql") {
+				sqlFiles = append(sqlFiles, filepath.Join(path, entry.Name()))
+			}
+		}
+		if len(sqlFiles) == 0 {
+			return nil, fmt.Errorf("no .sql file in DDL directory %s", path)
+		}
+		sort.Strings(sqlFiles)
+		files = append(files, sqlFiles...)
+	}
+	return files, nil
+}
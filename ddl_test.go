@@ -0,0 +1,315 @@
+package gen
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestGenerateModelFromDDL(t *testing.T) {
+	tmp := t.TempDir()
+	migrations := filepath.Join(tmp, "migrations")
+	if err := os.Mkdir(migrations, 0750); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	for name, content := range map[string]string{
+		"0001_users.sql":  "CREATE TABLE users (id bigint unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY, name varchar(64) NOT NULL COMMENT 'user name') COMMENT='users';",
+		"0002_orders.sql": "CREATE TABLE orders (id int PRIMARY KEY, user_id bigint unsigned, amount decimal(10,2));\nALTER TABLE users ADD COLUMN age tinyint;",
+		"README.md":       "not a sql file",
+	} {
+		if err := os.WriteFile(filepath.Join(migrations, name), []byte(content), 0640); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+
+	modelPath := filepath.Join(tmp, "model")
+	g := NewGenerator(Config{OutPath: filepath.Join(tmp, "query"), ModelPkgPath: modelPath, FieldSignable: true})
+	if err := g.UseDDL("mysql", migrations); err != nil {
+		t.Fatalf("use ddl: %v", err)
+	}
+
+	tables, err := g.GetTables()
+	if err != nil || !reflect.DeepEqual(tables, []string{"orders", "users"}) {
+		t.Fatalf("unexpected tables: %v, %v", tables, err)
+	}
+
+	models := g.GenerateAllTable()
+	if len(models) != 2 {
+		t.Fatalf("expected 2 models, got %d", len(models))
+	}
+	if err := g.generateModelFile(); err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	b, err := os.ReadFile(filepath.Join(modelPath, "users.gen.go"))
+	if err != nil {
+		t.Fatalf("read model file: %v", err)
+	}
+	for _, want := range []string{
+		"// User users\ntype User struct {",
+		"ID   uint64 `gorm:\"column:id;primaryKey;autoIncrement:true\" json:\"id\"`",
+		"Name string `gorm:\"column:name;not null;comment:user name\" json:\"name\"` // user name",
+		"Age  int32  `gorm:\"column:age\" json:\"age\"`",
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected %q in model file, got:\n%s", want, b)
+		}
+	}
+}
+
+func TestUseDDL_Error(t *testing.T) {
+	tmp := t.TempDir()
+	g := NewGenerator(Config{OutPath: filepath.Join(tmp, "query")})
+
+	if err := g.UseDDL("mysql"); err == nil {
+		t.Errorf("expected error without DDL file")
+	}
+	if err := g.UseDDL("mysql", tmp); err == nil {
+		t.Errorf("expected error for directory without .sql file")
+	}
+	if err := g.UseDDL("sqlserver", tmp); err == nil {
+		t.Errorf("expected error for unsupported dialect")
+	}
+
+	file := filepath.Join(tmp, "bad.sql")
+	if err := os.WriteFile(file, []byte("CREATE TABLE a (id int"), 0640); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := g.UseDDL("mysql", file); err == nil || !strings.Contains(err.Error(), "bad.sql") {
+		t.Errorf("expected parse error with file name, got %v", err)
+	}
+}
+
+func TestGenerateModelFromDDL_PostgresTypes(t *testing.T) {
+	tmp := t.TempDir()
+	file := filepath.Join(tmp, "schema.sql")
+	content := "CREATE TABLE accounts (id uuid PRIMARY KEY, profile jsonb, tags text[], scores integer[], ip inet, balance numeric(20,4), name text NOT NULL);"
+	if err := os.WriteFile(file, []byte(content), 0640); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	modelPath := filepath.Join(tmp, "model")
+	g := NewGenerator(Config{OutPath: filepath.Join(tmp, "query"), ModelPkgPath: modelPath, FieldWithDialectType: true})
+	if err := g.UseDDL("postgres", file); err != nil {
+		t.Fatalf("use ddl: %v", err)
+	}
+	g.WithDataTypeMap(map[string]func(gorm.ColumnType) string{"inet": func(gorm.ColumnType) string { return "string" }})
+	g.GenerateModel("accounts")
+	if err := g.generateModelFile(); err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	b, err := os.ReadFile(filepath.Join(modelPath, "accounts.gen.go"))
+	if err != nil {
+		t.Fatalf("read model file: %v", err)
+	}
+	for _, want := range []string{
+		`"github.com/google/uuid"`,
+		`"github.com/lib/pq"`,
+		"ID      uuid.UUID      `gorm:\"column:id;primaryKey;autoIncrement:false\" json:\"id\"`",
+		"Profile datatypes.JSON `gorm:\"column:profile\" json:\"profile\"`",
+		"Tags    pq.StringArray `gorm:\"column:tags\" json:\"tags\"`",
+		"Scores  pq.Int32Array  `gorm:\"column:scores\" json:\"scores\"`",
+		"IP      string         `gorm:\"column:ip\" json:\"ip\"`",
+		"Balance string         `gorm:\"column:balance\" json:\"balance\"`",
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected %q in model file, got:\n%s", want, b)
+		}
+	}
+	if strings.Contains(string(b), "pgtype") {
+		t.Errorf("expected unused pgtype not to be imported, got:\n%s", b)
+	}
+
+	// built-in mapping of dialect is opt-in
+	g = NewGenerator(Config{OutPath: filepath.Join(tmp, "query"), ModelPkgPath: modelPath})
+	if err = g.UseDDL("postgres", file); err != nil {
+		t.Fatalf("use ddl: %v", err)
+	}
+	g.GenerateModel("accounts")
+	if err = g.generateModelFile(); err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if b, err = os.ReadFile(filepath.Join(modelPath, "accounts.gen.go")); err != nil {
+		t.Fatalf("read model file: %v", err)
+	}
+	if strings.Contains(string(b), "uuid") || strings.Contains(string(b), "pq.") {
+		t.Errorf("expected no built-in postgres mapping by default, got:\n%s", b)
+	}
+}
+
+func TestGenerateEnumTypeFromDDL(t *testing.T) {
+	tmp := t.TempDir()
+	file := filepath.Join(tmp, "schema.sql")
+	content := `
+CREATE TYPE order_status AS ENUM ('pending', 'paid', 'in-transit');
+CREATE TABLE orders (id bigserial PRIMARY KEY, status order_status NOT NULL, channel text CHECK (channel IN ('web', 'app')), note text);
+CREATE TABLE refunds (id bigserial PRIMARY KEY, order_status order_status);`
+	if err := os.WriteFile(file, []byte(content), 0640); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	modelPath := filepath.Join(tmp, "model")
+	g := NewGenerator(Config{OutPath: filepath.Join(tmp, "query"), ModelPkgPath: modelPath, FieldNullable: true, FieldWithEnumType: true})
+	if err := g.UseDDL("postgres", file); err != nil {
+		t.Fatalf("use ddl: %v", err)
+	}
+	g.ApplyBasic(g.GenerateAllTable()...)
+	if err := g.generateModelFile(); err != nil {
+		t.Fatalf("generate model: %v", err)
+	}
+	if err := g.generateQueryFile(); err != nil {
+		t.Fatalf("generate query: %v", err)
+	}
+
+	read := func(name string) string {
+		b, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatalf("read file: %v", err)
+		}
+		return string(b)
+	}
+	orders, refunds, query := read(filepath.Join(modelPath, "orders.gen.go")), read(filepath.Join(modelPath, "refunds.gen.go")), read(filepath.Join(tmp, "query", "orders.gen.go"))
+	for _, want := range []string{
+		"Status  OrderStatus   `gorm:\"column:status;not null\" json:\"status\"`",
+		"Channel *OrderChannel `gorm:\"column:channel\" json:\"channel\"`",
+		"Note    *string       `gorm:\"column:note\" json:\"note\"`",
+		"// OrderStatus enum values of database type order_status\ntype OrderStatus string",
+		"OrderStatusInTransit OrderStatus = \"in-transit\"",
+		"var OrderStatusValues = []string{\"pending\", \"paid\", \"in-transit\"}",
+		"func (e OrderStatus) Valid() bool {",
+		"func (e *OrderStatus) Scan(value interface{}) error {",
+		"func (e OrderStatus) Value() (driver.Value, error) {",
+		"if e != \"\" && !e.Valid() {",
+		"// OrderChannel enum values of orders.channel\ntype OrderChannel string",
+		"OrderChannelApp OrderChannel = \"app\"",
+	} {
+		if !strings.Contains(orders, want) {
+			t.Errorf("expected %q in model file, got:\n%s", want, orders)
+		}
+	}
+	if !strings.Contains(refunds, "OrderStatus *OrderStatus") || strings.Contains(refunds, "type OrderStatus") {
+		t.Errorf("expected shared enum type declared once, got:\n%s", refunds)
+	}
+	for _, want := range []string{
+		"Status  field.Enum[model.OrderStatus]",
+		"Channel field.Enum[model.OrderChannel]",
+		"_order.Status = field.NewEnum[model.OrderStatus](tableName, \"status\")",
+	} {
+		if !strings.Contains(query, want) {
+			t.Errorf("expected %q in query file, got:\n%s", want, query)
+		}
+	}
+}
+
+func TestGenerateJSONFieldFromDDL(t *testing.T) {
+	tmp := t.TempDir()
+	file := filepath.Join(tmp, "schema.sql")
+	content := "CREATE TABLE users (id bigint PRIMARY KEY, profile json, name varchar(64));"
+	if err := os.WriteFile(file, []byte(content), 0640); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	g := NewGenerator(Config{OutPath: filepath.Join(tmp, "query"), ModelPkgPath: filepath.Join(tmp, "model")})
+	if err := g.UseDDL("mysql", file); err != nil {
+		t.Fatalf("use ddl: %v", err)
+	}
+	g.ApplyBasic(g.GenerateModel("users"))
+	if err := g.generateQueryFile(); err != nil {
+		t.Fatalf("generate query: %v", err)
+	}
+
+	b, err := os.ReadFile(filepath.Join(tmp, "query", "users.gen.go"))
+	if err != nil {
+		t.Fatalf("read query file: %v", err)
+	}
+	for _, want := range []string{
+		"Profile field.JSON",
+		"Name    field.String",
+		"_user.Profile = field.NewJSON(tableName, \"profile\")",
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected %q in query file, got:\n%s", want, b)
+		}
+	}
+}
+
+func TestGenerateSoftDeleteMethodFromDDL(t *testing.T) {
+	tmp := t.TempDir()
+	file := filepath.Join(tmp, "schema.sql")
+	content := "CREATE TABLE users (id bigint PRIMARY KEY, deleted_at datetime);\nCREATE TABLE logs (id bigint PRIMARY KEY, msg text);"
+	if err := os.WriteFile(file, []byte(content), 0640); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	g := NewGenerator(Config{OutPath: filepath.Join(tmp, "query"), ModelPkgPath: filepath.Join(tmp, "model"), Mode: WithQueryInterface})
+	if err := g.UseDDL("mysql", file); err != nil {
+		t.Fatalf("use ddl: %v", err)
+	}
+	g.ApplyBasic(g.GenerateModel("users"), g.GenerateModel("logs"))
+	if err := g.generateQueryFile(); err != nil {
+		t.Fatalf("generate query: %v", err)
+	}
+
+	methods := []string{
+		"WithDeleted() IUserDo",
+		"func (u userDo) OnlyDeleted() IUserDo",
+		"func (u userDo) Restore() (info gen.ResultInfo, err error)",
+		"func (u userDo) ForceDelete(models ...*model.User) (info gen.ResultInfo, err error)",
+	}
+	b, err := os.ReadFile(filepath.Join(tmp, "query", "users.gen.go"))
+	if err != nil {
+		t.Fatalf("read query file: %v", err)
+	}
+	for _, want := range methods {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("expected %q in query file, got:\n%s", want, b)
+		}
+	}
+
+	b, err = os.ReadFile(filepath.Join(tmp, "query", "logs.gen.go"))
+	if err != nil {
+		t.Fatalf("read query file: %v", err)
+	}
+	if strings.Contains(string(b), "OnlyDeleted") {
+		t.Errorf("unexpected soft delete methods in query file of table without soft delete column:\n%s", b)
+	}
+}
+
+func TestGenerateVersionMethodFromDDL(t *testing.T) {
+	tmp := t.TempDir()
+	file := filepath.Join(tmp, "schema.sql")
+	content := "CREATE TABLE orders (id bigint PRIMARY KEY, lock_version int NOT NULL);\nCREATE TABLE logs (id bigint PRIMARY KEY, lock_version varchar(8));"
+	if err := os.WriteFile(file, []byte(content), 0640); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	g := NewGenerator(Config{OutPath: filepath.Join(tmp, "query"), ModelPkgPath: filepath.Join(tmp, "model"), VersionColumn: "lock_version"})
+	if err := g.UseDDL("mysql", file); err != nil {
+		t.Fatalf("use ddl: %v", err)
+	}
+	g.ApplyBasic(g.GenerateModel("orders"), g.GenerateModel("logs"))
+	if err := g.generateQueryFile(); err != nil {
+		t.Fatalf("generate query: %v", err)
+	}
+
+	b, err := os.ReadFile(filepath.Join(tmp, "query", "orders.gen.go"))
+	if err != nil {
+		t.Fatalf("read query file: %v", err)
+	}
+	if want := `return o.DO.UpdateWithVersion("lock_version", value)`; !strings.Contains(string(b), want) {
+		t.Errorf("expected %q in query file, got:\n%s", want, b)
+	}
+
+	b, err = os.ReadFile(filepath.Join(tmp, "query", "logs.gen.go"))
+	if err != nil {
+		t.Fatalf("read query file: %v", err)
+	}
+	if strings.Contains(string(b), "UpdateWithVersion") {
+		t.Errorf("unexpected version methods for non integer version column:\n%s", b)
+	}
+}
@@ -8,11 +8,27 @@ import (
 	"gorm.io/gen/internal/diagnostic"
 )
 
+// Diagnostic structured error reported by generator
+type Diagnostic = diagnostic.Error
+
+// Diagnostics all diagnostics reported at once by ExecuteE or DryRun
+type Diagnostics = diagnostic.List
+
 func WriteDiagnosticJSON(w io.Writer, err error) error {
 	if err == nil {
 		_, writeErr := w.Write([]byte("null\n"))
 		return writeErr
 	}
+	var list Diagnostics
+	if errors.As(err, &list) {
+		b, marshalErr := json.MarshalIndent(list, "", "  ")
+		if marshalErr != nil {
+			return marshalErr
+		}
+		b = append(b, '\n')
+		_, writeErr := w.Write(b)
+		return writeErr
+	}
 	var de *diagnostic.Error
 	if errors.As(err, &de) {
 		b, marshalErr := json.MarshalIndent(de, "", "  ")
@@ -31,4 +47,3 @@ func WriteDiagnosticJSON(w io.Writer, err error) error {
 	_, writeErr := w.Write(b)
 	return writeErr
 }
-
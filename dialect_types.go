@@ -0,0 +1,19 @@
+package gen
+
+import (
+	"gorm.io/gorm"
+
+	"gorm.io/gen/internal/model"
+)
+
+// RegisterDialectDataTypeMap register default data type mapping of dialect, dialect is the name of gorm dialector,
+// like mysql or postgres. It takes precedence over column's scan type and built-in mapping enabled by
+// Config.FieldWithDialectType, and is overridden by WithDataTypeMap.
+// importPaths are packages of mapped types, only imported by model files using them
+//
+//	gen.RegisterDialectDataTypeMap("postgres", map[string]func(gorm.ColumnType) string{
+//		"hstore": func(gorm.ColumnType) string { return "hstore.Hstore" },
+//	}, "github.com/lib/pq/hstore")
+func RegisterDialectDataTypeMap(dialect string, dataTypeMap map[string]func(columnType gorm.ColumnType) (dataType string), importPaths ...string) {
+	model.RegisterDialectDataTypeMap(dialect, dataTypeMap, importPaths...)
+}
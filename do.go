@@ -32,8 +32,13 @@ type DO struct {
 	alias     string // for subquery
 	modelType reflect.Type
 	tableName string
+	queryName string // name of generated query struct
 
 	backfillData interface{}
+
+	setOperation *setOperationExpr // queries combined by Union/UnionAll/Intersect/Except
+
+	withDeleted bool // soft deleted rows are queried by WithDeleted/OnlyDeleted
 }
 
 func (d DO) getInstance(db *gorm.DB) *DO {
@@ -65,11 +70,19 @@ func (d *DO) UseDB(db *gorm.DB, opts ...DOOption) {
 		}
 	}
 	d.DOConfig = config
+	for _, opt := range opts {
+		if opt != nil {
+			if initErr := opt.AfterInitialize(d); initErr != nil {
+				_ = d.db.AddError(initErr) // returned by statements of d
+			}
+		}
+	}
 }
 
 // ReplaceDB replace db connection
 func (d *DO) ReplaceDB(db *gorm.DB) {
 	d.db = db.Session(&gorm.Session{})
+	d.useTenantScope()
 }
 
 // ReplaceConnPool replace db connection pool
@@ -97,6 +110,11 @@ func (d *DO) indirect(value interface{}) reflect.Type {
 	return mt
 }
 
+// UseQueryName specify name of generated query struct, which is reported in QueryEvent
+func (d *DO) UseQueryName(name string) {
+	d.queryName = name
+}
+
 // UseTable specify table name
 func (d *DO) UseTable(tableName string) {
 	d.db = d.db.Table(tableName).Session(new(gorm.Session))
@@ -122,11 +140,29 @@ func (d DO) Returning(value interface{}, columns ...string) Dao {
 }
 
 // Session replace db with new session
-func (d *DO) Session(config *gorm.Session) Dao { return d.getInstance(d.db.Session(config)) }
+func (d *DO) Session(config *gorm.Session) Dao {
+	if config != nil && config.Context != nil {
+		c := *config
+		c.Context = d.keepTenantScope(config.Context)
+		config = &c
+	}
+	return d.getInstance(d.db.Session(config))
+}
 
 // UnderlyingDB return the underlying database connection
 func (d *DO) UnderlyingDB() *gorm.DB { return d.underlyingDB() }
 
+// ExecResult execute raw SQL on connection pool for DIY method returning sql.Result. SQL goes through Exec callbacks
+// in dry run first, so it is refused like Exec, e.g. ErrRawSQLOnTenantTable on tenant table without CrossTenant,
+// and runs on connection pool resolved by them, e.g. by dbresolver.Read/dbresolver.Write clauses
+func (d *DO) ExecResult(query string, values ...interface{}) (sql.Result, error) {
+	tx := d.underlyingDB().Session(&gorm.Session{DryRun: true}).Exec(query, values...)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	return tx.Statement.ConnPool.ExecContext(tx.Statement.Context, query, values...)
+}
+
 // Quote return qutoed data
 func (d *DO) Quote(raw string) string { return d.db.Statement.Quote(raw) }
 
@@ -148,6 +184,14 @@ func (d *DO) underlyingDO() *DO { return d }
 // underlyingDB return self.db
 func (d *DO) underlyingDB() *gorm.DB { return d.db }
 
+// withoutAlias return db used as sub query, alias is ignored or will misuse with sub query alias
+func (d *DO) withoutAlias() *gorm.DB {
+	if d.alias == "" {
+		return d.db
+	}
+	return d.db.Table(d.TableName())
+}
+
 func (d *DO) withError(err error) *DO {
 	if err == nil {
 		return d
@@ -168,7 +212,9 @@ func (d *DO) CondError() error { return nil }
 func (d *DO) Debug() Dao { return d.getInstance(d.db.Debug()) }
 
 // WithContext return a DO with db with context
-func (d *DO) WithContext(ctx context.Context) Dao { return d.getInstance(d.db.WithContext(ctx)) }
+func (d *DO) WithContext(ctx context.Context) Dao {
+	return d.getInstance(d.db.WithContext(d.keepTenantScope(ctx)))
+}
 
 // Clauses specify Clauses
 func (d *DO) Clauses(conds ...clause.Expression) Dao {
@@ -337,7 +383,9 @@ func (d *DO) Scopes(funcs ...func(Dao) Dao) Dao {
 
 // Unscoped ...
 func (d *DO) Unscoped() Dao {
-	return d.getInstance(d.db.Unscoped())
+	do := d.getInstance(d.db.Unscoped())
+	do.withDeleted = false
+	return do
 }
 
 // Join ...
@@ -364,6 +412,23 @@ func (d *DO) join(table schema.Tabler, joinType clause.JoinType, conds []field.E
 	return d.getInstance(d.db.Clauses(from))
 }
 
+// With declare common table expression named name in WITH clause of query, update or delete,
+// which can be referenced by CTE(name) in Join
+func (d *DO) With(name string, q SubQuery) Dao {
+	return d.with(newWithClause(false, name, q))
+}
+
+// WithRecursive declare recursive common table expression named name in WITH RECURSIVE clause of query, update or delete
+func (d *DO) WithRecursive(name string, q SubQuery) Dao {
+	return d.with(newWithClause(true, name, q))
+}
+
+func (d *DO) with(w withClause) Dao {
+	do := d.Clauses(w).(*DO)
+	attachWithClause(do.db.Statement)
+	return do
+}
+
 // Attrs ...
 func (d *DO) Attrs(attrs ...field.AssignExpr) Dao {
 	if len(attrs) == 0 {
@@ -585,34 +650,48 @@ func getFromClause(db *gorm.DB) *clause.From {
 
 // ======================== finisher api ========================
 
+// OnConflict upsert on conflict of columns, action is specified by DoUpdates, DoNothing or UpdateAll of returned builder
+func (d *DO) OnConflict(cols ...field.Expr) Upsert[Dao] {
+	return NewUpsert(d, func(do Dao) Dao { return do }, cols...)
+}
+
 // Create ...
 func (d *DO) Create(value interface{}) error {
-	return d.db.Create(value).Error
+	d, done := d.Observe("", "Create")
+	return done(d.db.Create(value).Error)
 }
 
 // CreateInBatches ...
 func (d *DO) CreateInBatches(value interface{}, batchSize int) error {
-	return d.db.CreateInBatches(value, batchSize).Error
+	d, done := d.Observe("", "CreateInBatches")
+	return done(d.db.CreateInBatches(value, batchSize).Error)
 }
 
 // Save ...
 func (d *DO) Save(value interface{}) error {
-	return d.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(value).Error
+	d, done := d.Observe("", "Save")
+	return done(d.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(value).Error)
 }
 
 // First ...
 func (d *DO) First() (result interface{}, err error) {
-	return d.singleQuery(d.db.First)
+	d, done := d.Observe("", "First")
+	result, err = d.singleQuery(d.db.First)
+	return result, done(err)
 }
 
 // Take ...
 func (d *DO) Take() (result interface{}, err error) {
-	return d.singleQuery(d.db.Take)
+	d, done := d.Observe("", "Take")
+	result, err = d.singleQuery(d.db.Take)
+	return result, done(err)
 }
 
 // Last ...
 func (d *DO) Last() (result interface{}, err error) {
-	return d.singleQuery(d.db.Last)
+	d, done := d.Observe("", "Last")
+	result, err = d.singleQuery(d.db.Last)
+	return result, done(err)
 }
 
 func (d *DO) singleQuery(query func(dest interface{}, conds ...interface{}) *gorm.DB) (result interface{}, err error) {
@@ -635,7 +714,9 @@ func (d *DO) singleScan() (result interface{}, err error) {
 
 // Find ...
 func (d *DO) Find() (results interface{}, err error) {
-	return d.multiQuery(d.db.Find)
+	d, done := d.Observe("", "Find")
+	results, err = d.multiQuery(d.db.Find)
+	return results, done(err)
 }
 
 func (d *DO) multiQuery(query func(dest interface{}, conds ...interface{}) *gorm.DB) (results interface{}, err error) {
@@ -656,21 +737,27 @@ func (d *DO) findToMap() (interface{}, error) {
 
 // FindInBatches ...
 func (d *DO) FindInBatches(dest interface{}, batchSize int, fc func(tx Dao, batch int) error) error {
-	return d.db.FindInBatches(dest, batchSize, func(tx *gorm.DB, batch int) error { return fc(d.getInstance(tx), batch) }).Error
+	d, done := d.Observe("", "FindInBatches")
+	return done(d.db.FindInBatches(dest, batchSize, func(tx *gorm.DB, batch int) error { return fc(d.getInstance(tx), batch) }).Error)
 }
 
 // FirstOrInit ...
 func (d *DO) FirstOrInit() (result interface{}, err error) {
-	return d.singleQuery(d.db.FirstOrInit)
+	d, done := d.Observe("", "FirstOrInit")
+	result, err = d.singleQuery(d.db.FirstOrInit)
+	return result, done(err)
 }
 
 // FirstOrCreate ...
 func (d *DO) FirstOrCreate() (result interface{}, err error) {
-	return d.singleQuery(d.db.FirstOrCreate)
+	d, done := d.Observe("", "FirstOrCreate")
+	result, err = d.singleQuery(d.db.FirstOrCreate)
+	return result, done(err)
 }
 
 // Update ...
 func (d *DO) Update(column field.Expr, value interface{}) (info ResultInfo, err error) {
+	d, done := d.Observe("", "Update")
 	tx := d.prepareTx()
 	columnStr := column.BuildColumn(d.db.Statement, field.WithoutQuote).String()
 
@@ -683,7 +770,7 @@ func (d *DO) Update(column field.Expr, value interface{}) (info ResultInfo, err
 	default:
 		result = tx.Update(columnStr, value)
 	}
-	return ResultInfo{RowsAffected: result.RowsAffected, Error: result.Error}, result.Error
+	return ResultInfo{RowsAffected: result.RowsAffected, Error: result.Error}, done(result.Error)
 }
 
 // UpdateSimple ...
@@ -691,13 +778,15 @@ func (d *DO) UpdateSimple(columns ...field.AssignExpr) (info ResultInfo, err err
 	if len(columns) == 0 {
 		return
 	}
+	d, done := d.Observe("", "UpdateSimple")
 	tx := d.prepareTx()
 	result := tx.Clauses(d.assignSet(columns)).Omit("*").Updates(map[string]interface{}{})
-	return ResultInfo{RowsAffected: result.RowsAffected, Error: result.Error}, result.Error
+	return ResultInfo{RowsAffected: result.RowsAffected, Error: result.Error}, done(result.Error)
 }
 
 // Updates ...
 func (d *DO) Updates(value interface{}) (info ResultInfo, err error) {
+	d, done := d.Observe("", "Updates")
 	var rawTyp, valTyp reflect.Type
 
 	rawTyp = reflect.TypeOf(value)
@@ -718,11 +807,12 @@ func (d *DO) Updates(value interface{}) (info ResultInfo, err error) {
 	}
 
 	result := tx.Updates(value)
-	return ResultInfo{RowsAffected: result.RowsAffected, Error: result.Error}, result.Error
+	return ResultInfo{RowsAffected: result.RowsAffected, Error: result.Error}, done(result.Error)
 }
 
 // UpdateColumn ...
 func (d *DO) UpdateColumn(column field.Expr, value interface{}) (info ResultInfo, err error) {
+	d, done := d.Observe("", "UpdateColumn")
 	tx := d.prepareTx()
 	columnStr := column.BuildColumn(d.db.Statement, field.WithoutQuote).String()
 
@@ -735,7 +825,7 @@ func (d *DO) UpdateColumn(column field.Expr, value interface{}) (info ResultInfo
 	default:
 		result = d.db.UpdateColumn(columnStr, value)
 	}
-	return ResultInfo{RowsAffected: result.RowsAffected, Error: result.Error}, result.Error
+	return ResultInfo{RowsAffected: result.RowsAffected, Error: result.Error}, done(result.Error)
 }
 
 // UpdateColumnSimple ...
@@ -743,16 +833,18 @@ func (d *DO) UpdateColumnSimple(columns ...field.AssignExpr) (info ResultInfo, e
 	if len(columns) == 0 {
 		return
 	}
+	d, done := d.Observe("", "UpdateColumnSimple")
 	tx := d.prepareTx()
 	result := tx.Clauses(d.assignSetWithoutAutoUpdate(columns)).Omit("*").UpdateColumns(map[string]interface{}{})
-	return ResultInfo{RowsAffected: result.RowsAffected, Error: result.Error}, result.Error
+	return ResultInfo{RowsAffected: result.RowsAffected, Error: result.Error}, done(result.Error)
 }
 
 // UpdateColumns ...
 func (d *DO) UpdateColumns(value interface{}) (info ResultInfo, err error) {
+	d, done := d.Observe("", "UpdateColumns")
 	tx := d.prepareTx()
 	result := tx.UpdateColumns(value)
-	return ResultInfo{RowsAffected: result.RowsAffected, Error: result.Error}, result.Error
+	return ResultInfo{RowsAffected: result.RowsAffected, Error: result.Error}, done(result.Error)
 }
 
 // prepareTx returns a transaction with backfillData model if available
@@ -792,8 +884,9 @@ func (d *DO) assignSetWithoutAutoUpdate(exprs []field.AssignExpr) (set clause.Se
 
 // Delete ...
 func (d *DO) Delete(models ...interface{}) (info ResultInfo, err error) {
+	d, done := d.Observe("", "Delete")
 	var result *gorm.DB
-	tx := d.prepareTx()
+	tx := d.scopedTx(d.prepareTx())
 	if d.backfillData != nil && len(models) == 0 {
 		result = tx.Delete(d.backfillData)
 	} else if len(models) == 0 || reflect.ValueOf(models[0]).Len() == 0 {
@@ -806,12 +899,14 @@ func (d *DO) Delete(models ...interface{}) (info ResultInfo, err error) {
 		}
 		result = tx.Delete(targets.Interface())
 	}
-	return ResultInfo{RowsAffected: result.RowsAffected, Error: result.Error}, result.Error
+	return ResultInfo{RowsAffected: result.RowsAffected, Error: result.Error}, done(result.Error)
 }
 
 // Count ...
 func (d *DO) Count() (count int64, err error) {
-	return count, d.db.Session(&gorm.Session{}).Count(&count).Error
+	d, done := d.Observe("", "Count")
+	err = d.db.Session(&gorm.Session{}).Count(&count).Error
+	return count, done(err)
 }
 
 // Row ...
@@ -826,12 +921,14 @@ func (d *DO) Rows() (*sql.Rows, error) {
 
 // Scan ...
 func (d *DO) Scan(dest interface{}) error {
-	return d.db.Scan(dest).Error
+	d, done := d.Observe("", "Scan")
+	return done(d.db.Scan(dest).Error)
 }
 
 // Pluck ...
 func (d *DO) Pluck(column field.Expr, dest interface{}) error {
-	return d.db.Pluck(column.ColumnName().String(), dest).Error
+	d, done := d.Observe("", "Pluck")
+	return done(d.db.Pluck(column.ColumnName().String(), dest).Error)
 }
 
 // ScanRows ...
@@ -999,8 +1096,7 @@ func Table(subQueries ...SubQuery) Dao {
 		tablePlaceholder[i] = "(?)"
 
 		do := query.underlyingDO()
-		// ignore alias, or will misuse with sub query alias
-		tableExprs[i] = do.db.Table(do.TableName())
+		tableExprs[i] = do.withoutAlias()
 		if do.alias != "" {
 			tablePlaceholder[i] += " AS " + do.Quote(do.alias)
 		}
@@ -1012,6 +1108,91 @@ func Table(subQueries ...SubQuery) Dao {
 	}
 }
 
+// Union combine results of queries with UNION, the return value has to be used as root node
+// and can be ordered, limited, counted, scanned or used as sub query by Table
+//
+//	Union(u.Select(u.ID).Where(u.Age.Gt(18)), u.Select(u.ID).Where(u.Score.Gte(100))).Order(u.ID).Limit(10)
+//
+// the above usage is equivalent to SQL statement:
+//
+//	SELECT * FROM ((SELECT `id` FROM `users_info` WHERE `age` > ?) UNION (SELECT `id` FROM `users_info` WHERE `score` >= ?)) AS `users_info` ORDER BY `id` LIMIT 10
+func Union(query SubQuery, queries ...SubQuery) Dao {
+	return setOperation("UNION", query, queries)
+}
+
+// UnionAll combine results of queries with UNION ALL, duplicate rows are kept
+func UnionAll(query SubQuery, queries ...SubQuery) Dao {
+	return setOperation("UNION ALL", query, queries)
+}
+
+// Intersect combine results of queries with INTERSECT
+func Intersect(query SubQuery, queries ...SubQuery) Dao {
+	return setOperation("INTERSECT", query, queries)
+}
+
+// Except combine results of queries with EXCEPT
+func Except(query SubQuery, queries ...SubQuery) Dao {
+	return setOperation("EXCEPT", query, queries)
+}
+
+// setOperation select from derived table of queries combined by operator,
+// derived table is named after alias or table of first query, so that its fields can be used in Order/Where
+func setOperation(operator string, query SubQuery, queries []SubQuery) Dao {
+	first := query.underlyingDO()
+	expr := setOperationExpr{operator: operator}
+	for _, q := range append([]SubQuery{query}, queries...) {
+		expr.queries = append(expr.queries, q.underlyingDO().withoutAlias())
+	}
+
+	table, name := "(?)", first.alias
+	if name == "" {
+		name = first.TableName()
+	}
+	if name != "" {
+		table += " AS " + first.Quote(name)
+	}
+	return &DO{
+		DOConfig:     first.DOConfig,
+		db:           first.db.Session(&gorm.Session{NewDB: true}).Table(table, expr),
+		modelType:    first.modelType,
+		setOperation: &expr,
+	}
+}
+
+// compoundQuery return queries combined by set operator if nothing is chained after Union/UnionAll/Intersect/Except,
+// so that they can be used without derived table, like in recursive common table expression
+func (d *DO) compoundQuery() (clause.Expression, bool) {
+	if d.setOperation == nil || len(d.db.Statement.Clauses) > 0 || len(d.db.Statement.Selects) > 0 {
+		return nil, false
+	}
+	return *d.setOperation, true
+}
+
+// setOperationExpr queries combined by set operator, sqlite doesn't allow parentheses around compound select member
+type setOperationExpr struct {
+	operator string
+	queries  []*gorm.DB
+}
+
+func (e setOperationExpr) Build(builder clause.Builder) {
+	parentheses := true
+	if stmt, ok := builder.(*gorm.Statement); ok && stmt.Dialector.Name() == "sqlite" {
+		parentheses = false
+	}
+	for i, q := range e.queries {
+		if i > 0 {
+			builder.WriteString(" " + e.operator + " ")
+		}
+		if parentheses {
+			builder.WriteByte('(')
+		}
+		builder.AddVar(builder, q)
+		if parentheses {
+			builder.WriteByte(')')
+		}
+	}
+}
+
 // Exists EXISTS expression
 // SELECT * FROM table WHERE EXISTS (SELECT NAME FROM users WHERE id = 1)
 func Exists(subQuery SubQuery) Condition {
@@ -7,6 +7,8 @@ import (
 	"gorm.io/gorm/clause"
 	"gorm.io/gorm/utils/tests"
 	"gorm.io/plugin/dbresolver"
+
+	"gorm.io/gen/field"
 )
 
 func TestDOClausesWithClauseChecker(t *testing.T) {
@@ -39,3 +41,102 @@ func TestDOClausesWithClauseChecker(t *testing.T) {
 		t.Fatalf("unexpected error: %v", dao3.db.Error)
 	}
 }
+
+func TestDOWithClause(t *testing.T) {
+	base, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+
+	var d DO
+	d.UseDB(base.Session(&gorm.Session{DryRun: true, NewDB: true}), WithClauseChecker(func(clause.Expression) error { return ErrClauseNotHandled }))
+	d.UseTable("users")
+	dao := d.With("adult", d.Where(field.NewInt("", "age").Gt(18))).(*DO)
+	if dao.db.Error != nil {
+		t.Fatalf("unexpected error: %v", dao.db.Error)
+	}
+
+	var count int64
+	stmt := dao.db.Count(&count).Statement
+	if sql := stmt.SQL.String(); sql != "WITH `adult` AS (SELECT * FROM `users` WHERE `age` > ?) SELECT count(*) FROM `users`" {
+		t.Fatalf("unexpected sql: %s", sql)
+	}
+
+	// WITH clause does not take place of SELECT clause
+	dao = d.With("adult", d.Where(field.NewInt("", "age").Gt(18))).Select(field.NewInt("", "id")).(*DO)
+	stmt = dao.db.Find(&[]map[string]interface{}{}).Statement
+	if sql := stmt.SQL.String(); sql != "WITH `adult` AS (SELECT * FROM `users` WHERE `age` > ?) SELECT `id` FROM `users`" {
+		t.Fatalf("unexpected sql: %s", sql)
+	}
+
+	// WITH clause is kept by update and delete
+	type User struct {
+		ID   uint
+		Name string
+	}
+	dao = d.With("adult", d.Where(field.NewInt("", "age").Gt(18))).(*DO)
+	stmt = dao.db.Session(&gorm.Session{}).Model(&User{}).Where("id IN (SELECT id FROM adult)").Update("name", "adult").Statement
+	if sql := stmt.SQL.String(); sql != "WITH `adult` AS (SELECT * FROM `users` WHERE `age` > ?) UPDATE `users` SET `name`=? WHERE id IN (SELECT id FROM adult)" {
+		t.Fatalf("unexpected sql: %s", sql)
+	}
+	stmt = dao.db.Session(&gorm.Session{}).Where("id IN (SELECT id FROM adult)").Delete(&User{}).Statement
+	if sql := stmt.SQL.String(); sql != "WITH `adult` AS (SELECT * FROM `users` WHERE `age` > ?) DELETE FROM `users` WHERE id IN (SELECT id FROM adult)" {
+		t.Fatalf("unexpected sql: %s", sql)
+	}
+}
+
+func TestDOOnConflict(t *testing.T) {
+	base, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+
+	type User struct {
+		ID    uint
+		Email string
+		Count int
+	}
+	email, count := field.NewString("users", "email"), field.NewInt("users", "count")
+
+	var checked []clause.Expression
+	var d DO
+	d.UseDB(base.Session(&gorm.Session{DryRun: true, NewDB: true}), WithClauseChecker(func(c clause.Expression) error {
+		checked = append(checked, c)
+		return ErrClauseNotHandled
+	}))
+	d.UseModel(User{})
+
+	for _, testcase := range []struct {
+		dao    Dao
+		expect string
+	}{
+		{
+			dao:    d.OnConflict(email).DoUpdates(count.Add(1), email.Value("a@b.c")),
+			expect: "INSERT INTO `users` (`email`,`count`,`id`) VALUES (?,?,?) ON CONFLICT (`email`) DO UPDATE SET `count`=`users`.`count`+?,`email`=? RETURNING `id`",
+		},
+		{
+			dao:    d.OnConflict(email).DoNothing(),
+			expect: "INSERT INTO `users` (`email`,`count`,`id`) VALUES (?,?,?) ON CONFLICT (`email`) DO NOTHING RETURNING `id`",
+		},
+		{
+			dao:    d.OnConflict().UpdateAll(),
+			expect: "INSERT INTO `users` (`email`,`count`,`id`) VALUES (?,?,?) ON CONFLICT (`id`) DO UPDATE SET `email`=`excluded`.`email`,`count`=`excluded`.`count` RETURNING `id`",
+		},
+	} {
+		do := testcase.dao.(*DO)
+		if do.db.Error != nil {
+			t.Fatalf("unexpected error: %v", do.db.Error)
+		}
+		if sql := do.db.Create(&User{ID: 1, Email: "a@b.c"}).Statement.SQL.String(); sql != testcase.expect {
+			t.Errorf("SQL expects %s, got %s", testcase.expect, sql)
+		}
+	}
+	if len(checked) != 3 {
+		t.Errorf("expect ON CONFLICT clauses to be passed to clause checker, got %v", checked)
+	}
+
+	// raw expression assignment is still banned
+	if err := CheckClause(clause.OnConflict{DoUpdates: clause.Set{{Column: clause.Column{Name: "count"}, Value: gorm.Expr("count + 1")}}}); err == nil {
+		t.Errorf("expect raw expression assignment to be banned")
+	}
+}
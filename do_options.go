@@ -18,6 +18,16 @@ var ErrClauseNotHandled = errors.New("clause not handled")
 
 type DOConfig struct {
 	ClauseChecker ClauseChecker
+
+	// TenantColumn column of tenant tables which rows are scoped to tenant of context
+	TenantColumn string
+	// TenantExtractor extract tenant from context of statement
+	TenantExtractor TenantExtractor
+
+	// Observers observe terminal operations
+	Observers []QueryObserver
+	// StartObservers observe start of terminal operations
+	StartObservers []QueryStartObserver
 }
 
 // Apply update config to new config
@@ -2,6 +2,8 @@ package gen
 
 import (
 	"context"
+	"database/sql"
+	"database/sql/driver"
 	"reflect"
 	"strconv"
 	"strings"
@@ -14,6 +16,7 @@ import (
 	"gorm.io/gorm/logger"
 	"gorm.io/gorm/utils/tests"
 	"gorm.io/hints"
+	"gorm.io/plugin/dbresolver"
 
 	"gorm.io/gen/field"
 )
@@ -61,7 +64,7 @@ func build(stmt *gorm.Statement, opts ...stmtOpt) *gorm.Statement {
 		stmt = opt(stmt)
 	}
 
-	if _, ok := stmt.Clauses["SELECT"]; !ok && len(stmt.Selects) > 0 {
+	if c := stmt.Clauses["SELECT"]; c.Expression == nil && len(stmt.Selects) > 0 { // SELECT clause may only keep WITH clause
 		stmt.AddClause(clause.Select{Distinct: stmt.Distinct, Expression: clause.Expr{SQL: strings.Join(stmt.Selects, ",")}})
 	}
 
@@ -395,6 +398,68 @@ func TestDO_methods(t *testing.T) {
 			ExpectedVars: []interface{}{18, 100.0},
 			Result:       "SELECT * FROM (SELECT * FROM `users_info` WHERE `age` > ?) AS `a`, (SELECT * FROM `users_info` WHERE `score` >= ?) AS `b`",
 		},
+		{
+			Expr:         Table(u.Select(u.ID, u.Name, field.Func.RowNumber().Over(field.PartitionBy(u.Address).OrderBy(u.Score.Desc())).As("rn")).Where(u.Age.Gt(18)).As("t")).Select(),
+			Opts:         []stmtOpt{withFROM},
+			ExpectedVars: []interface{}{18},
+			Result:       "SELECT * FROM (SELECT `id`,`name`,ROW_NUMBER() OVER (PARTITION BY `address` ORDER BY `score` DESC) AS `rn` FROM `users_info` WHERE `age` > ?) AS `t`",
+		},
+
+		// ======================== common table expression ========================
+		{
+			Expr:         u.With("adult", u.Select(u.ID).Where(u.Age.Gt(18))).Select(u.ID, u.Name),
+			Opts:         []stmtOpt{withFROM},
+			ExpectedVars: []interface{}{18},
+			Result:       "WITH `adult` AS (SELECT `id` FROM `users_info` WHERE `age` > ?) SELECT `id`,`name` FROM `users_info`",
+		},
+		{
+			Expr:         student.With("t", teacher.Select(teacher.ID).Where(teacher.Name.Like("a%"))).Join(CTE("t"), student.Instructor.EqCol(field.NewInt64("t", "id"))).Select(),
+			ExpectedVars: []interface{}{"a%"},
+			Result:       "WITH `t` AS (SELECT `teacher`.`id` FROM `teacher` WHERE `teacher`.`name` LIKE ?) SELECT * FROM `student` INNER JOIN `t` ON `student`.`instructor` = `t`.`id`",
+		},
+		{
+			Expr:         u.WithRecursive("a", u.Select(u.ID).Where(u.Age.Gt(18))).(*DO).With("b", u.Select(u.ID).Where(u.Score.Gte(100))).Select(),
+			Opts:         []stmtOpt{withFROM},
+			ExpectedVars: []interface{}{18, 100.0},
+			Result:       "WITH RECURSIVE `a` AS (SELECT `id` FROM `users_info` WHERE `age` > ?),`b` AS (SELECT `id` FROM `users_info` WHERE `score` >= ?) SELECT * FROM `users_info`",
+		},
+		{
+			Expr:         Table(u.With("adult", u.Select(u.ID).Where(u.Age.Gt(18))).Select(u.ID)).Select(),
+			Opts:         []stmtOpt{withFROM},
+			ExpectedVars: []interface{}{18},
+			Result:       "SELECT * FROM (WITH `adult` AS (SELECT `id` FROM `users_info` WHERE `age` > ?) SELECT `id` FROM `users_info`)",
+		},
+
+		// ======================== set operation ========================
+		{
+			Expr:         Union(u.Select(u.ID).Where(u.Age.Gt(18)), u.Select(u.ID).Where(u.Score.Gte(100))).Select().Order(u.ID.Desc()),
+			Opts:         []stmtOpt{withFROM},
+			ExpectedVars: []interface{}{18, 100.0},
+			Result:       "SELECT * FROM ((SELECT `id` FROM `users_info` WHERE `age` > ?) UNION (SELECT `id` FROM `users_info` WHERE `score` >= ?)) AS `users_info` ORDER BY `id` DESC",
+		},
+		{
+			Expr:         UnionAll(u.Select(u.ID).Where(u.Age.Gt(18)).As("a"), u.Select(u.ID), u.Select(u.ID)).Select(),
+			Opts:         []stmtOpt{withFROM},
+			ExpectedVars: []interface{}{18},
+			Result:       "SELECT * FROM ((SELECT `id` FROM `users_info` WHERE `age` > ?) UNION ALL (SELECT `id` FROM `users_info`) UNION ALL (SELECT `id` FROM `users_info`)) AS `a`",
+		},
+		{
+			Expr:         Table(Intersect(u.Select(u.ID).Where(u.Age.Gt(18)), u.Select(u.ID).Where(u.Score.Gte(100)))).Select(),
+			Opts:         []stmtOpt{withFROM},
+			ExpectedVars: []interface{}{18, 100.0},
+			Result:       "SELECT * FROM (SELECT * FROM ((SELECT `id` FROM `users_info` WHERE `age` > ?) INTERSECT (SELECT `id` FROM `users_info` WHERE `score` >= ?)) AS `users_info`)",
+		},
+		{
+			Expr:         u.Where(u.ID.In(1, 2)).Where(u.Columns(u.ID).In(Except(u.Select(u.ID), u.Select(u.ID).Where(u.Famous.Is(true))).Select(u.ID))),
+			ExpectedVars: []interface{}{uint(1), uint(2), true},
+			Result:       "WHERE `id` IN (?,?) AND `id` IN (SELECT `id` FROM ((SELECT `id` FROM `users_info`) EXCEPT (SELECT `id` FROM `users_info` WHERE `famous` = ?)) AS `users_info`)",
+		},
+		{
+			Expr:         u.WithRecursive("tree", UnionAll(u.Select(u.ID).Where(u.ID.Eq(1)), u.Select(u.ID).Join(CTE("tree"), u.ID.EqCol(field.NewUint("tree", "id"))))).Select(),
+			Opts:         []stmtOpt{withFROM},
+			ExpectedVars: []interface{}{uint(1)},
+			Result:       "WITH RECURSIVE `tree` AS ((SELECT `id` FROM `users_info` WHERE `id` = ?) UNION ALL (SELECT `id` FROM `users_info` INNER JOIN `tree` ON `id` = `tree`.`id`)) SELECT * FROM `users_info`",
+		},
 
 		// ======================== join subquery ========================
 		{
@@ -573,3 +638,97 @@ func TestPostgresSelectVarBind(t *testing.T) {
 		t.Fatalf("expect %s, got %s", expect, l.lastSQL)
 	}
 }
+
+type sqliteDialector struct{ tests.DummyDialector }
+
+func (sqliteDialector) Name() string { return "sqlite" }
+
+func TestSetOperationCount(t *testing.T) {
+	for _, testcase := range []struct {
+		dialector gorm.Dialector
+		expect    string
+	}{
+		{
+			dialector: tests.DummyDialector{},
+			expect:    "SELECT count(*) FROM ((SELECT `id` FROM `user` WHERE `age` > 18) UNION (SELECT `id` FROM `user` WHERE `score` >= 100)) AS `user`",
+		},
+		{
+			dialector: sqliteDialector{},
+			expect:    "SELECT count(*) FROM (SELECT `id` FROM `user` WHERE `age` > 18 UNION SELECT `id` FROM `user` WHERE `score` >= 100) AS `user`",
+		},
+	} {
+		base, err := gorm.Open(testcase.dialector, &gorm.Config{})
+		if err != nil {
+			t.Fatalf("open db: %v", err)
+		}
+		l := &captureLogger{}
+		var d DO
+		d.UseDB(base.Session(&gorm.Session{DryRun: true, NewDB: true, Logger: l}))
+		d.UseTable("user")
+
+		id := field.NewInt("", "id")
+		_, _ = Union(d.Select(id).Where(field.NewInt("", "age").Gt(18)), d.Select(id).Where(field.NewInt("", "score").Gte(100))).Count()
+		if l.lastSQL != testcase.expect {
+			t.Errorf("%s: expect %s, got %s", testcase.dialector.Name(), testcase.expect, l.lastSQL)
+		}
+	}
+}
+
+// execPool connection pool recording SQL executed on it
+type execPool struct {
+	gorm.ConnPool
+	execs []string
+}
+
+func (p *execPool) ExecContext(_ context.Context, query string, _ ...interface{}) (sql.Result, error) {
+	p.execs = append(p.execs, query)
+	return driver.RowsAffected(1), nil
+}
+
+// poolDialector dummy dialector connecting to pool
+type poolDialector struct {
+	tests.DummyDialector
+	pool gorm.ConnPool
+}
+
+func (d poolDialector) Initialize(db *gorm.DB) error {
+	if err := d.DummyDialector.Initialize(db); err != nil {
+		return err
+	}
+	db.ConnPool = d.pool
+	return nil
+}
+
+func TestDO_ExecResultResolver(t *testing.T) {
+	source, replica := &execPool{}, &execPool{}
+	db, err := gorm.Open(poolDialector{pool: source}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	if err := db.Use(dbresolver.Register(dbresolver.Config{Replicas: []gorm.Dialector{poolDialector{pool: replica}}})); err != nil {
+		t.Fatalf("register dbresolver: %v", err)
+	}
+	var d DO
+	d.UseDB(db)
+
+	testCases := []struct {
+		do     *DO
+		sql    string
+		expect *execPool
+	}{
+		{do: &d, sql: "UPDATE users SET name = 'a'", expect: source},
+		{do: &d, sql: "SELECT pg_advisory_lock(1)", expect: replica},
+		{do: d.Clauses(dbresolver.Write).(*DO), sql: "SELECT pg_advisory_lock(1)", expect: source},
+		{do: d.Clauses(dbresolver.Read).(*DO), sql: "UPDATE users SET name = 'a'", expect: replica},
+	}
+	for _, tt := range testCases {
+		source.execs, replica.execs = nil, nil
+		if _, err := tt.do.ExecResult(tt.sql); err != nil {
+			t.Fatalf("ExecResult fail: %v", err)
+		}
+		if len(tt.expect.execs) != 1 || len(source.execs)+len(replica.execs) != 1 {
+			t.Errorf("SQL %s expects to run on %s, got source %v, replica %v",
+				tt.sql, map[*execPool]string{source: "source", replica: "replica"}[tt.expect], source.execs, replica.execs)
+		}
+	}
+}
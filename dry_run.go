@@ -0,0 +1,109 @@
+package gen
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"sort"
+	"sync"
+
+	"gorm.io/gen/internal/diff"
+)
+
+// FileChangeType kind of change made to a generated file
+type FileChangeType string
+
+const (
+	// FileCreate file does not exist yet
+	FileCreate FileChangeType = "create"
+	// FileModify file exists with different content
+	FileModify FileChangeType = "modify"
+	// FileDelete file would be removed
+	FileDelete FileChangeType = "delete"
+)
+
+// FileChange a change Execute would make to a generated file
+type FileChange struct {
+	Path string
+	Type FileChangeType
+	Diff string // unified diff between file on disk and generated content
+}
+
+// DryRun render all model, query and gen.go files like Execute, but return the changes instead of writing them
+func (g *Generator) DryRun() ([]FileChange, error) {
+	g.dryRun = &dryRunRecorder{}
+	defer func() { g.dryRun = nil }()
+
+	if err := g.generate(); err != nil {
+		return nil, err
+	}
+	return g.dryRun.result(), nil
+}
+
+// dryRunRecorder collect file changes during dry run
+type dryRunRecorder struct {
+	mu      sync.Mutex
+	changes []FileChange
+}
+
+func (r *dryRunRecorder) write(fileName string, content []byte) error {
+	old, err := os.ReadFile(fileName)
+	switch {
+	case err == nil:
+		if bytes.Equal(old, content) {
+			return nil
+		}
+		r.add(FileChange{Path: fileName, Type: FileModify, Diff: diff.Unified(fileName, fileName, old, content)})
+	case errors.Is(err, os.ErrNotExist):
+		r.add(FileChange{Path: fileName, Type: FileCreate, Diff: diff.Unified(os.DevNull, fileName, nil, content)})
+	default:
+		return err
+	}
+	return nil
+}
+
+func (r *dryRunRecorder) remove(fileName string) error {
+	old, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+	r.add(FileChange{Path: fileName, Type: FileDelete, Diff: diff.Unified(fileName, os.DevNull, old, nil)})
+	return nil
+}
+
+func (r *dryRunRecorder) add(c FileChange) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.changes = append(r.changes, c)
+}
+
+func (r *dryRunRecorder) result() []FileChange {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sort.Slice(r.changes, func(i, j int) bool { return r.changes[i].Path < r.changes[j].Path })
+	return r.changes
+}
+
+// writeFile write generated content to file, only record the change in dry run mode
+func (g *Generator) writeFile(fileName string, content []byte) error {
+	if g.dryRun != nil {
+		return g.dryRun.write(fileName, content)
+	}
+	return os.WriteFile(fileName, content, 0640)
+}
+
+// removeFile remove generated file, only record the change in dry run mode
+func (g *Generator) removeFile(fileName string) error {
+	if g.dryRun != nil {
+		return g.dryRun.remove(fileName)
+	}
+	return os.Remove(fileName)
+}
+
+// mkdirAll create output directory, nothing is created in dry run mode
+func (g *Generator) mkdirAll(path string) error {
+	if g.dryRun != nil {
+		return nil
+	}
+	return os.MkdirAll(path, os.ModePerm)
+}
@@ -0,0 +1,93 @@
+package gen
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDryRunRecorder_OutputDoesNotWrite(t *testing.T) {
+	tmp := t.TempDir()
+	g := NewGenerator(Config{OutPath: tmp})
+	g.dryRun = &dryRunRecorder{}
+
+	created := filepath.Join(tmp, "a.gen.go")
+	if err := g.output(created, []byte("package p\n\nfunc A() {}\n")); err != nil {
+		t.Fatalf("output: %v", err)
+	}
+	if _, err := os.Stat(created); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected file not to be written in dry run, stat err: %v", err)
+	}
+
+	modified := filepath.Join(tmp, "b.gen.go")
+	if err := os.WriteFile(modified, []byte("package p\n\nfunc B() {}\n"), 0640); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	if err := g.output(modified, []byte("package p\n\nfunc C() {}\n")); err != nil {
+		t.Fatalf("output: %v", err)
+	}
+
+	unchanged := filepath.Join(tmp, "c.gen.go")
+	if err := os.WriteFile(unchanged, []byte("package p\n\nfunc D() {}\n"), 0640); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	if err := g.output(unchanged, []byte("package p\n\nfunc D() {}\n")); err != nil {
+		t.Fatalf("output: %v", err)
+	}
+
+	changes := g.dryRun.result()
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, got %+v", changes)
+	}
+	if changes[0].Path != created || changes[0].Type != FileCreate {
+		t.Fatalf("unexpected first change: %+v", changes[0])
+	}
+	if changes[1].Path != modified || changes[1].Type != FileModify {
+		t.Fatalf("unexpected second change: %+v", changes[1])
+	}
+	if !strings.Contains(changes[1].Diff, "-func B() {}") || !strings.Contains(changes[1].Diff, "+func C() {}") {
+		t.Fatalf("unexpected diff:\n%s", changes[1].Diff)
+	}
+
+	b, err := os.ReadFile(modified)
+	if err != nil {
+		t.Fatalf("read file: %v", err)
+	}
+	if !strings.Contains(string(b), "func B") {
+		t.Fatalf("expected file to keep content in dry run, got:\n%s", string(b))
+	}
+}
+
+func TestDryRun_NothingToGenerate(t *testing.T) {
+	g := NewGenerator(Config{OutPath: filepath.Join(t.TempDir(), "query")})
+
+	changes, err := g.DryRun()
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("expected no change, got %+v", changes)
+	}
+	if g.dryRun != nil {
+		t.Fatalf("expected dry run mode to be reset")
+	}
+}
+
+func TestDryRun_ManifestIsNotChange(t *testing.T) {
+	tmp := t.TempDir()
+	g := NewGenerator(Config{OutPath: tmp})
+	g.dryRun = &dryRunRecorder{}
+
+	path := filepath.Join(tmp, manifestFileName)
+	if err := g.saveManifest(path, &genManifest{Version: 1, Files: map[string]string{"a.gen.go": "hash"}}); err != nil {
+		t.Fatalf("save manifest: %v", err)
+	}
+	if changes := g.dryRun.result(); len(changes) != 0 {
+		t.Fatalf("expected manifest not to be reported as change, got %+v", changes)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected manifest not to be written in dry run, stat err: %v", err)
+	}
+}
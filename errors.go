@@ -5,4 +5,20 @@ import "errors"
 var (
 	// ErrEmptyCondition empty condition
 	ErrEmptyCondition = errors.New("empty condition")
+
+	// ErrInvalidCursor cursor of FindByCursor is malformed or doesn't match order columns
+	ErrInvalidCursor = errors.New("invalid cursor")
+
+	// ErrStaleObject row is not updated by UpdateWithVersion/UpdatesWithVersion because its version has been changed
+	ErrStaleObject = errors.New("stale object")
+
+	// ErrMissingTenant statement on tenant table is refused because its context carries no tenant
+	ErrMissingTenant = errors.New("missing tenant")
+
+	// ErrRawSQLOnTenantTable raw SQL on tenant table can not be scoped to tenant, use CrossTenant explicitly
+	ErrRawSQLOnTenantTable = errors.New("raw SQL on tenant table")
+
+	// ErrUpsertOnTenantTable conflict update (Save, OnConflict with updates) on tenant table may overwrite row of another tenant,
+	// use CrossTenant explicitly
+	ErrUpsertOnTenantTable = errors.New("upsert on tenant table")
 )
@@ -0,0 +1,7 @@
+package field
+
+// Enum enum type field, values are constants of generated enum type T,
+// so comparing with value of another type is caught at compile time
+type Enum[T ~string] struct {
+	genericsField[T]
+}
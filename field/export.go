@@ -144,6 +144,20 @@ func NewBytes(table, column string, opts ...Option) Bytes {
 	return newChars[[]byte](expr{col: toColumn(table, column, opts...)})
 }
 
+// ======================== enum =======================
+
+// NewEnum create new field for enum type T
+func NewEnum[T ~string](table, column string, opts ...Option) Enum[T] {
+	return Enum[T]{genericsField: newGenerics[T](expr{col: toColumn(table, column, opts...)})}
+}
+
+// ======================== json =======================
+
+// NewJSON create new field for json
+func NewJSON(table, column string, opts ...Option) JSON {
+	return JSON{NewString(table, column, opts...)}
+}
+
 // ======================== bool =======================
 
 // NewBool ...
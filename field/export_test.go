@@ -3,10 +3,15 @@ package field_test
 import (
 	"database/sql/driver"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/utils/tests"
+
 	"gorm.io/gen/field"
 )
 
@@ -14,6 +19,13 @@ var _ field.ScanValuer = new(password)
 
 type password string
 
+type status string
+
+const (
+	statusActive  status = "active"
+	statusBlocked status = "blocked"
+)
+
 func (p *password) Scan(src interface{}) error {
 	*p = password(fmt.Sprintf("this is password {%q}", src))
 	return nil
@@ -470,6 +482,92 @@ func TestExpr_Build(t *testing.T) {
 			ExpectedVars: []interface{}{"%W %M %Y"},
 			Result:       "DATE_FORMAT(`updateAt`,?)",
 		},
+		// ======================== enum ========================
+		{
+			Expr:         field.NewEnum[status]("", "status").Eq(statusActive),
+			ExpectedVars: []interface{}{statusActive},
+			Result:       "`status` = ?",
+		},
+		{
+			Expr:         field.NewEnum[status]("", "status").In(statusActive, statusBlocked),
+			ExpectedVars: []interface{}{statusActive, statusBlocked},
+			Result:       "`status` IN (?,?)",
+		},
+		{
+			Expr:         field.NewEnum[status]("", "status").NotIn(statusActive, statusBlocked),
+			ExpectedVars: []interface{}{statusActive, statusBlocked},
+			Result:       "`status` NOT IN (?,?)",
+		},
+		// ======================== json ========================
+		{
+			Expr:         field.NewJSON("user", "profile").Path("address.city").Eq("Paris"),
+			ExpectedVars: []interface{}{"$.address.city", "Paris"},
+			Result:       "JSON_UNQUOTE(JSON_EXTRACT(`user`.`profile`, ?)) = ?",
+		},
+		{
+			Expr:         field.NewJSON("", "profile").Path("tags[0]").Like("go%"),
+			ExpectedVars: []interface{}{"$.tags[0]", "go%"},
+			Result:       "JSON_UNQUOTE(JSON_EXTRACT(`profile`, ?)) LIKE ?",
+		},
+		{
+			Expr:         field.NewJSON("", "profile").HasKey("$.address.zip code"),
+			ExpectedVars: []interface{}{`$.address."zip code"`},
+			Result:       "JSON_CONTAINS_PATH(`profile`, 'one', ?)",
+		},
+		{
+			Expr:         field.NewJSON("", "profile").Contains("", map[string]interface{}{"vip": true}),
+			ExpectedVars: []interface{}{`{"vip":true}`, "$"},
+			Result:       "JSON_CONTAINS(`profile`, ?, ?)",
+		},
+		{
+			Expr:         field.NewJSON("", "profile").ArrayContains("tags", "go"),
+			ExpectedVars: []interface{}{`"go"`, "$.tags"},
+			Result:       "JSON_CONTAINS(`profile`, ?, ?)",
+		},
+		{
+			Expr:         field.NewJSON("", "profile").Eq(`{}`),
+			ExpectedVars: []interface{}{`{}`},
+			Result:       "`profile` = ?",
+		},
+		{
+			Expr:   field.NewJSON("user", "profile").IsNull(),
+			Result: "`user`.`profile` IS NULL",
+		},
+		// ======================== window ========================
+		{
+			Expr:   field.Func.RowNumber().Over(field.OrderBy(field.NewInt("", "score").Desc())).As("rn"),
+			Result: "ROW_NUMBER() OVER (ORDER BY `score` DESC) AS `rn`",
+		},
+		{
+			Expr:   field.Func.Rank().Over(field.PartitionBy(field.NewString("user", "dept")).OrderBy(field.NewInt("user", "score").Desc(), field.NewUint("user", "id"))),
+			Result: "RANK() OVER (PARTITION BY `user`.`dept` ORDER BY `user`.`score` DESC,`user`.`id`)",
+		},
+		{
+			Expr:   field.Func.DenseRank().Over(field.PartitionBy(field.NewString("", "dept"), field.NewString("", "team"))),
+			Result: "DENSE_RANK() OVER (PARTITION BY `dept`,`team`)",
+		},
+		{
+			Expr:         field.NewInt("", "score").Lag(1).Over(field.OrderBy(field.NewTime("", "created_at"))),
+			ExpectedVars: []interface{}{1},
+			Result:       "LAG(`score`, ?) OVER (ORDER BY `created_at`)",
+		},
+		{
+			Expr:         field.NewInt("", "score").Lead(2, 0).Over(field.OrderBy(field.NewTime("", "created_at"))),
+			ExpectedVars: []interface{}{2, 0},
+			Result:       "LEAD(`score`, ?, ?) OVER (ORDER BY `created_at`)",
+		},
+		{
+			Expr:   field.NewString("", "name").FirstValue().Over(field.PartitionBy(field.NewString("", "dept")).OrderBy(field.NewInt("", "score").Desc())),
+			Result: "FIRST_VALUE(`name`) OVER (PARTITION BY `dept` ORDER BY `score` DESC)",
+		},
+		{
+			Expr:   field.NewFloat64("", "amount").Sum().Over(field.PartitionBy(field.NewUint("", "user_id"))).As("total"),
+			Result: "SUM(`amount`) OVER (PARTITION BY `user_id`) AS `total`",
+		},
+		{
+			Expr:   field.NewUint("", "id").Count().Over(field.Window{}),
+			Result: "COUNT(`id`) OVER ()",
+		},
 		// ======================== bool ========================
 		{
 			Expr:   field.NewBool("", "male").Not(),
@@ -497,6 +595,98 @@ func TestExpr_Build(t *testing.T) {
 	}
 }
 
+type dialector struct {
+	tests.DummyDialector
+	name string
+}
+
+func (d dialector) Name() string { return d.name }
+
+func TestJSON_Build(t *testing.T) {
+	profile := field.NewJSON("", "profile")
+	testcases := []struct {
+		Dialect      string
+		Expr         field.Expr
+		ExpectedVars []interface{}
+		Result       string
+	}{
+		{
+			Dialect:      "mysql",
+			Expr:         profile.Set("address.city", "Paris"),
+			ExpectedVars: []interface{}{"$.address.city", `"Paris"`},
+			Result:       "`profile` = JSON_SET(`profile`, ?, CAST(? AS JSON))",
+		},
+		{
+			Dialect:      "postgres",
+			Expr:         profile.Path("address.city").Eq("Paris"),
+			ExpectedVars: []interface{}{"address", "city", "Paris"},
+			Result:       "json_extract_path_text(`profile`::json, ?,?) = ?",
+		},
+		{
+			Dialect:      "postgres",
+			Expr:         profile.HasKey("tags[1]"),
+			ExpectedVars: []interface{}{"tags", "1"},
+			Result:       "json_extract_path(`profile`::json, ?,?) IS NOT NULL",
+		},
+		{
+			Dialect:      "postgres",
+			Expr:         profile.Contains("", map[string]interface{}{"vip": true}),
+			ExpectedVars: []interface{}{`{"vip":true}`},
+			Result:       "`profile`::jsonb @> ?::jsonb",
+		},
+		{
+			Dialect:      "postgres",
+			Expr:         profile.ArrayContains("tags", "go"),
+			ExpectedVars: []interface{}{"tags", `["go"]`},
+			Result:       "jsonb_extract_path(`profile`::jsonb, ?) @> ?::jsonb",
+		},
+		{
+			Dialect:      "postgres",
+			Expr:         profile.Set("address.city", "Paris"),
+			ExpectedVars: []interface{}{"address", "city", `"Paris"`},
+			Result:       "`profile` = jsonb_set(`profile`::jsonb, ARRAY[?,?]::text[], ?::jsonb)",
+		},
+		{
+			Dialect:      "sqlite",
+			Expr:         profile.Path("address.city").Eq("Paris"),
+			ExpectedVars: []interface{}{"$.address.city", "Paris"},
+			Result:       "json_extract(`profile`, ?) = ?",
+		},
+		{
+			Dialect:      "sqlite",
+			Expr:         profile.ArrayContains("tags", "go"),
+			ExpectedVars: []interface{}{"$.tags", `"go"`},
+			Result:       "EXISTS (SELECT 1 FROM json_each(`profile`, ?) WHERE json_each.value = json_extract(?, '$'))",
+		},
+		{
+			Dialect:      "sqlite",
+			Expr:         profile.Set("tags[0]", "go"),
+			ExpectedVars: []interface{}{"$.tags[0]", `"go"`},
+			Result:       "`profile` = json_set(`profile`, ?, json(?))",
+		},
+	}
+
+	for _, testcase := range testcases {
+		db, _ := gorm.Open(dialector{name: testcase.Dialect}, nil)
+		stmt := &gorm.Statement{DB: db, Clauses: map[string]clause.Clause{}}
+		testcase.Expr.Build(stmt)
+
+		if sql := strings.TrimSpace(stmt.SQL.String()); sql != testcase.Result {
+			t.Errorf("%s: SQL expects %v got %v", testcase.Dialect, testcase.Result, sql)
+		}
+		if !reflect.DeepEqual(stmt.Vars, testcase.ExpectedVars) {
+			t.Errorf("%s: Vars expects %+v got %+v", testcase.Dialect, testcase.ExpectedVars, stmt.Vars)
+		}
+	}
+
+	db, _ := gorm.Open(dialector{name: "sqlite"}, nil)
+	stmt := &gorm.Statement{DB: db, Clauses: map[string]clause.Clause{}}
+	profile.Contains("", "go").Build(stmt)
+	if stmt.Error == nil {
+		t.Errorf("expects error for json contains in sqlite")
+	}
+}
+
 func TestExpr_BuildColumn(t *testing.T) {
 	stmt := field.GetStatement()
 	id := field.NewUint("user", "id")
@@ -0,0 +1,227 @@
+package field
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// JSON json type field, query and update values inside json document with path like `address.city` or `tags[0]`,
+// sql is built for dialect of db: mysql, postgres and sqlite, other dialects use mysql syntax.
+// JSON embeds String, so whole document can still be compared like a string column, e.g. Eq, Like, IsNull
+type JSON struct{ String }
+
+// Value set value of json column, value can be json string, []byte, datatypes.JSON or any Valuer
+func (field JSON) Value(value interface{}) AssignExpr {
+	return field.value(value)
+}
+
+// Path value at path of json document as text, like `JSON_UNQUOTE(JSON_EXTRACT(col, '$.address.city'))` in mysql
+// or `json_extract_path_text(col::json, 'address', 'city')` in postgres
+func (field JSON) Path(path string) String {
+	e := jsonExpr{op: jsonExtract, col: field.RawExpr(), path: parseJSONPath(path)}
+	return newChars[string](field.setE(clause.Expr{SQL: "?", Vars: []interface{}{e}}))
+}
+
+// HasKey judge path exists in json document
+func (field JSON) HasKey(path string) Expr {
+	return field.setE(jsonExpr{op: jsonHasKey, col: field.RawExpr(), path: parseJSONPath(path)})
+}
+
+// Contains judge value at path (whole document if path is empty) contains json document of value,
+// like `JSON_CONTAINS(col, '{"a":1}')` in mysql and `col::jsonb @> '{"a":1}'` in postgres, not supported by sqlite
+func (field JSON) Contains(path string, value interface{}) Expr {
+	return field.setE(jsonExpr{op: jsonContains, col: field.RawExpr(), path: parseJSONPath(path), value: value})
+}
+
+// ArrayContains judge array at path (whole document if path is empty) has element of value
+func (field JSON) ArrayContains(path string, value interface{}) Expr {
+	return field.setE(jsonExpr{op: jsonArrayContains, col: field.RawExpr(), path: parseJSONPath(path), value: value})
+}
+
+// Set update value at path of json document, value is encoded to json, whole document is replaced if path is empty
+func (field JSON) Set(path string, value interface{}) AssignExpr {
+	return field.value(jsonExpr{op: jsonSet, col: field.RawExpr(), path: parseJSONPath(path), value: value})
+}
+
+type jsonOp int
+
+const (
+	jsonExtract jsonOp = iota
+	jsonHasKey
+	jsonContains
+	jsonArrayContains
+	jsonSet
+)
+
+// jsonKey object key or array index of json path
+type jsonKey struct {
+	name  string
+	index int // array index, -1 for object key
+}
+
+// parseJSONPath parse path like `address.city`, `tags[0]` or `$.tags[0].name`
+func parseJSONPath(path string) (keys []jsonKey) {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	for _, part := range strings.Split(path, ".") {
+		name := part
+		if i := strings.IndexByte(part, '['); i >= 0 {
+			name, part = part[:i], part[i:]
+		} else {
+			part = ""
+		}
+		if name != "" {
+			keys = append(keys, jsonKey{name: name, index: -1})
+		}
+		for strings.HasPrefix(part, "[") {
+			end := strings.IndexByte(part, ']')
+			if end < 0 {
+				break
+			}
+			if index, err := strconv.Atoi(part[1:end]); err == nil {
+				keys = append(keys, jsonKey{name: part[1:end], index: index})
+			} else {
+				keys = append(keys, jsonKey{name: strings.Trim(part[1:end], `"'`), index: -1})
+			}
+			part = part[end+1:]
+		}
+	}
+	return keys
+}
+
+// jsonExpr json expression built by dialect
+type jsonExpr struct {
+	op    jsonOp
+	col   expression
+	path  []jsonKey
+	value interface{}
+}
+
+func (e jsonExpr) Build(builder clause.Builder) {
+	dialect := ""
+	if stmt, ok := builder.(*gorm.Statement); ok {
+		dialect = stmt.Dialector.Name()
+	}
+
+	var value string
+	if e.op == jsonContains || e.op == jsonArrayContains || e.op == jsonSet {
+		v := e.value
+		if e.op == jsonArrayContains && dialect == "postgres" {
+			v = []interface{}{v}
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			_ = builder.AddError(fmt.Errorf("encode json value fail: %w", err))
+		}
+		value = string(data)
+	}
+
+	var result clause.Expr
+	switch dialect {
+	case "postgres":
+		result = e.postgres(value)
+	case "sqlite":
+		result = e.sqlite(value)
+	default:
+		result = e.mysql(value)
+	}
+	if result.SQL == "" {
+		_ = builder.AddError(fmt.Errorf("json expression is not supported by %s", dialect))
+		return
+	}
+	result.Build(builder)
+}
+
+func (e jsonExpr) mysql(value string) clause.Expr {
+	path := e.jsonPath()
+	switch e.op {
+	case jsonHasKey:
+		return clause.Expr{SQL: "JSON_CONTAINS_PATH(?, 'one', ?)", Vars: []interface{}{e.col, path}}
+	case jsonContains, jsonArrayContains:
+		return clause.Expr{SQL: "JSON_CONTAINS(?, ?, ?)", Vars: []interface{}{e.col, value, path}}
+	case jsonSet:
+		if len(e.path) == 0 {
+			return clause.Expr{SQL: "CAST(? AS JSON)", Vars: []interface{}{value}}
+		}
+		return clause.Expr{SQL: "JSON_SET(?, ?, CAST(? AS JSON))", Vars: []interface{}{e.col, path, value}}
+	default:
+		return clause.Expr{SQL: "JSON_UNQUOTE(JSON_EXTRACT(?, ?))", Vars: []interface{}{e.col, path}}
+	}
+}
+
+func (e jsonExpr) postgres(value string) clause.Expr {
+	keys := make([]interface{}, len(e.path))
+	for i, key := range e.path {
+		keys[i] = key.name
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(keys)), ",")
+
+	switch e.op {
+	case jsonHasKey:
+		return clause.Expr{SQL: "json_extract_path(?::json, " + placeholders + ") IS NOT NULL", Vars: append([]interface{}{e.col}, keys...)}
+	case jsonContains, jsonArrayContains:
+		if len(keys) == 0 {
+			return clause.Expr{SQL: "?::jsonb @> ?::jsonb", Vars: []interface{}{e.col, value}}
+		}
+		return clause.Expr{SQL: "jsonb_extract_path(?::jsonb, " + placeholders + ") @> ?::jsonb", Vars: append(append([]interface{}{e.col}, keys...), value)}
+	case jsonSet:
+		if len(keys) == 0 {
+			return clause.Expr{SQL: "?::jsonb", Vars: []interface{}{value}}
+		}
+		return clause.Expr{SQL: "jsonb_set(?::jsonb, ARRAY[" + placeholders + "]::text[], ?::jsonb)", Vars: append(append([]interface{}{e.col}, keys...), value)}
+	default:
+		if len(keys) == 0 {
+			return clause.Expr{SQL: "?::text", Vars: []interface{}{e.col}}
+		}
+		return clause.Expr{SQL: "json_extract_path_text(?::json, " + placeholders + ")", Vars: append([]interface{}{e.col}, keys...)}
+	}
+}
+
+func (e jsonExpr) sqlite(value string) clause.Expr {
+	path := e.jsonPath()
+	switch e.op {
+	case jsonHasKey:
+		return clause.Expr{SQL: "json_type(?, ?) IS NOT NULL", Vars: []interface{}{e.col, path}}
+	case jsonArrayContains:
+		return clause.Expr{SQL: "EXISTS (SELECT 1 FROM json_each(?, ?) WHERE json_each.value = json_extract(?, '$'))", Vars: []interface{}{e.col, path, value}}
+	case jsonSet:
+		if len(e.path) == 0 {
+			return clause.Expr{SQL: "json(?)", Vars: []interface{}{value}}
+		}
+		return clause.Expr{SQL: "json_set(?, ?, json(?))", Vars: []interface{}{e.col, path, value}}
+	case jsonExtract:
+		return clause.Expr{SQL: "json_extract(?, ?)", Vars: []interface{}{e.col, path}}
+	default: // json containment is not supported by sqlite
+		return clause.Expr{}
+	}
+}
+
+// jsonPath build path like `$.address.city` or `$.tags[0]` for mysql and sqlite
+func (e jsonExpr) jsonPath() string {
+	var sb strings.Builder
+	sb.WriteString("$")
+	for _, key := range e.path {
+		switch {
+		case key.index >= 0:
+			sb.WriteString("[" + key.name + "]")
+		case isJSONPathIdent(key.name):
+			sb.WriteString("." + key.name)
+		default:
+			sb.WriteString("." + strconv.Quote(key.name))
+		}
+	}
+	return sb.String()
+}
+
+func isJSONPathIdent(name string) bool {
+	for i, r := range name {
+		if !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || i > 0 && r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return name != ""
+}
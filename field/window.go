@@ -0,0 +1,109 @@
+package field
+
+import (
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// Window window definition of OVER clause, like OVER (PARTITION BY `dept` ORDER BY `salary` DESC)
+type Window struct {
+	partitionBy []Expr
+	orderBy     []Expr
+}
+
+// PartitionBy create window partitioned by columns
+func PartitionBy(columns ...Expr) Window {
+	return Window{}.PartitionBy(columns...)
+}
+
+// OrderBy create window ordered by columns, use Desc()/Asc() of column to specify direction
+func OrderBy(columns ...Expr) Window {
+	return Window{}.OrderBy(columns...)
+}
+
+// PartitionBy partition window by columns
+func (w Window) PartitionBy(columns ...Expr) Window {
+	w.partitionBy = append(w.partitionBy[:len(w.partitionBy):len(w.partitionBy)], columns...)
+	return w
+}
+
+// OrderBy order rows of window partition by columns
+func (w Window) OrderBy(columns ...Expr) Window {
+	w.orderBy = append(w.orderBy[:len(w.orderBy):len(w.orderBy)], columns...)
+	return w
+}
+
+// over build window function expression e OVER (...)
+func (w Window) over(e expression) clause.Expr {
+	var items []string
+	vars := []interface{}{e}
+	if len(w.partitionBy) > 0 {
+		items = append(items, "PARTITION BY "+strings.TrimSuffix(strings.Repeat("?,", len(w.partitionBy)), ","))
+		for _, col := range w.partitionBy {
+			vars = append(vars, col.RawExpr())
+		}
+	}
+	if len(w.orderBy) > 0 {
+		items = append(items, "ORDER BY "+strings.TrimSuffix(strings.Repeat("?,", len(w.orderBy)), ","))
+		for _, col := range w.orderBy {
+			vars = append(vars, col.RawExpr())
+		}
+	}
+	return clause.Expr{SQL: "? OVER (" + strings.Join(items, " ") + ")", Vars: vars}
+}
+
+// WindowFunc window function, which must be called with Over to be used in query
+type WindowFunc struct{ e expr }
+
+// Over apply window function to window
+func (f WindowFunc) Over(window Window) Expr {
+	return f.e.setE(window.over(f.e.RawExpr()))
+}
+
+// RowNumber ROW_NUMBER() window function
+func (f *function) RowNumber() WindowFunc {
+	return WindowFunc{expr{e: clause.Expr{SQL: "ROW_NUMBER()"}}}
+}
+
+// Rank RANK() window function
+func (f *function) Rank() WindowFunc {
+	return WindowFunc{expr{e: clause.Expr{SQL: "RANK()"}}}
+}
+
+// DenseRank DENSE_RANK() window function
+func (f *function) DenseRank() WindowFunc {
+	return WindowFunc{expr{e: clause.Expr{SQL: "DENSE_RANK()"}}}
+}
+
+// Lag LAG(col, offset[, default]) window function, value of column in the row offset rows before current row
+func (e expr) Lag(offset int, defaultValue ...interface{}) WindowFunc {
+	return WindowFunc{e.setE(e.offsetFunc("LAG", offset, defaultValue))}
+}
+
+// Lead LEAD(col, offset[, default]) window function, value of column in the row offset rows after current row
+func (e expr) Lead(offset int, defaultValue ...interface{}) WindowFunc {
+	return WindowFunc{e.setE(e.offsetFunc("LEAD", offset, defaultValue))}
+}
+
+// FirstValue FIRST_VALUE(col) window function
+func (e expr) FirstValue() WindowFunc {
+	return WindowFunc{e.setE(clause.Expr{SQL: "FIRST_VALUE(?)", Vars: []interface{}{e.RawExpr()}})}
+}
+
+// LastValue LAST_VALUE(col) window function
+func (e expr) LastValue() WindowFunc {
+	return WindowFunc{e.setE(clause.Expr{SQL: "LAST_VALUE(?)", Vars: []interface{}{e.RawExpr()}})}
+}
+
+// Over use aggregate expression as window function, like SUM(`amount`) OVER (PARTITION BY `user_id`)
+func (e expr) Over(window Window) Expr {
+	return e.setE(window.over(e.RawExpr()))
+}
+
+func (e expr) offsetFunc(name string, offset int, defaultValue []interface{}) clause.Expr {
+	if len(defaultValue) > 0 {
+		return clause.Expr{SQL: name + "(?, ?, ?)", Vars: []interface{}{e.RawExpr(), offset, defaultValue[0]}}
+	}
+	return clause.Expr{SQL: name + "(?, ?)", Vars: []interface{}{e.RawExpr(), offset}}
+}
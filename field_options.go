@@ -311,6 +311,16 @@ var (
 	WithMethod = func(methods ...interface{}) model.AddMethodOpt {
 		return func() []interface{} { return methods }
 	}
+
+	// WithFileName specify generated model file name (without .gen.go), take precedence over file name strategy
+	WithFileName = func(fileName string) model.FileNameOpt {
+		return func() string { return fileName }
+	}
+
+	// WithModelSubPkg generate model into sub package of model package, e.g. "audit" for model/audit
+	WithModelSubPkg = func(subPkg string) model.SubPkgOpt {
+		return func() string { return subPkg }
+	}
 )
 
 var (
@@ -6,12 +6,14 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"go/scanner"
 	"io"
 	"log"
 	"os"
+	"path"
 	"path/filepath"
 	"runtime"
-	"strconv"
+	"sort"
 	"strings"
 	"sync"
 	"text/template"
@@ -22,6 +24,7 @@ import (
 	"gorm.io/gorm/schema"
 
 	"gorm.io/gen/helper"
+	"gorm.io/gen/internal/diagnostic"
 	"gorm.io/gen/internal/generate"
 	"gorm.io/gen/internal/model"
 	"gorm.io/gen/internal/parser"
@@ -100,6 +103,14 @@ type Generator struct {
 	models map[string]*generate.QueryStructMeta //gen model data
 
 	logger Logger
+	dryRun *dryRunRecorder // record file changes instead of writing, set by DryRun
+
+	schemaCache     *schemaCache // reuse table schema of unchanged tables in incremental mode
+	schemaCacheInit bool
+
+	schemaSource model.SchemaSource // table schema read from DDL files, set by UseDDL
+
+	syncErrs diagnostic.List // failures of reading tables and applying interfaces, reported by Execute
 }
 
 // SetLogger  set gen logger
@@ -128,8 +139,8 @@ func (g *Generator) GenerateModel(tableName string, opts ...ModelOpt) *generate.
 func (g *Generator) GenerateModelAs(tableName string, modelName string, opts ...ModelOpt) *generate.QueryStructMeta {
 	meta, err := generate.GetQueryStructMeta(g.db, g.genModelConfig(tableName, modelName, opts))
 	if err != nil {
-		g.db.Logger.Error(context.Background(), "generate struct from table fail: %s", err)
-		panic("generate struct fail")
+		g.addSyncError(fmt.Errorf("generate struct from table fail: %w", err), diagnostic.CodeModelGenerate, tableName)
+		return nil
 	}
 	if meta == nil {
 		g.info(fmt.Sprintf("ignore table <%s>", tableName))
@@ -143,13 +154,19 @@ func (g *Generator) GenerateModelAs(tableName string, modelName string, opts ...
 
 // GenerateAllTable generate all tables in db
 func (g *Generator) GenerateAllTable(opts ...ModelOpt) (tableModels []interface{}) {
-	tableList, err := g.db.Migrator().GetTables()
+	tableList, err := g.GetTables()
 	if err != nil {
-		panic(fmt.Errorf("get all tables fail: %w", err))
+		g.addSyncError(fmt.Errorf("get all tables fail: %w", err), diagnostic.CodeModelGenerate, "")
+		return nil
 	}
 
 	g.info(fmt.Sprintf("find %d table from db: %s", len(tableList), tableList))
 
+	if filtered := g.filterTables(tableList); len(filtered) != len(tableList) {
+		g.info(fmt.Sprintf("skip %d table by include/exclude filters, generate %d table: %s", len(tableList)-len(filtered), len(filtered), filtered))
+		tableList = filtered
+	}
+
 	tableModels = make([]interface{}, len(tableList))
 	for i, tableName := range tableList {
 		tableModels[i] = g.GenerateModel(tableName, opts...)
@@ -161,7 +178,8 @@ func (g *Generator) GenerateAllTable(opts ...ModelOpt) (tableModels []interface{
 func (g *Generator) GenerateModelFrom(obj helper.Object) *generate.QueryStructMeta {
 	s, err := generate.GetQueryStructMetaFromObject(obj, g.genModelObjConfig())
 	if err != nil {
-		panic(fmt.Errorf("generate struct from object fail: %w", err))
+		g.addSyncError(fmt.Errorf("generate struct from object fail: %w", err), diagnostic.CodeModelGenerate, obj.TableName())
+		return nil
 	}
 	g.models[s.ModelStructName] = s
 
@@ -182,6 +200,8 @@ func (g *Generator) genModelConfig(tableName string, modelName string, modelOpts
 		ModelName:      modelName,
 		ImportPkgPaths: g.importPkgPaths,
 		ModelOpts:      modelOpts,
+		SchemaCache:    g.getSchemaCache(),
+		SchemaSource:   g.schemaSource,
 		NameStrategy: model.NameStrategy{
 			SchemaNameOpts: g.dbNameOpts,
 			TableNameNS:    g.tableNameNS,
@@ -191,12 +211,14 @@ func (g *Generator) genModelConfig(tableName string, modelName string, modelOpts
 		FieldConfig: model.FieldConfig{
 			DataTypeMap: g.dataTypeMap,
 
-			FieldSignable:       g.FieldSignable,
-			FieldNullable:       g.FieldNullable,
-			FieldCoverable:      g.FieldCoverable,
-			FieldWithIndexTag:   g.FieldWithIndexTag,
-			FieldWithTypeTag:    g.FieldWithTypeTag,
-			FieldWithDefaultTag: g.FieldWithDefaultTag,
+			FieldSignable:        g.FieldSignable,
+			FieldNullable:        g.FieldNullable,
+			FieldCoverable:       g.FieldCoverable,
+			FieldWithIndexTag:    g.FieldWithIndexTag,
+			FieldWithTypeTag:     g.FieldWithTypeTag,
+			FieldWithDefaultTag:  g.FieldWithDefaultTag,
+			FieldWithEnumType:    g.FieldWithEnumType,
+			FieldWithDialectType: g.FieldWithDialectType,
 
 			FieldJSONTagNS: g.fieldJSONTagNS,
 		},
@@ -232,8 +254,8 @@ func (g *Generator) ApplyBasic(models ...interface{}) {
 func (g *Generator) ApplyInterface(fc interface{}, models ...interface{}) {
 	structs, err := generate.ConvertStructs(g.db, models...)
 	if err != nil {
-		g.db.Logger.Error(context.Background(), "check struct fail: %v", err)
-		panic("check struct fail")
+		g.addSyncError(fmt.Errorf("check struct fail: %w", err), diagnostic.CodeQueryGenerate, "")
+		return
 	}
 	g.apply(fc, structs)
 }
@@ -241,15 +263,15 @@ func (g *Generator) ApplyInterface(fc interface{}, models ...interface{}) {
 func (g *Generator) apply(fc interface{}, structs []*generate.QueryStructMeta) {
 	interfacePaths, err := parser.GetInterfacePath(fc)
 	if err != nil {
-		g.db.Logger.Error(context.Background(), "get interface name or file fail: %s", err)
-		panic("check interface fail")
+		g.addSyncError(fmt.Errorf("get interface name or file fail: %w", err), diagnostic.CodeQueryGenerate, "")
+		return
 	}
 
 	readInterface := new(parser.InterfaceSet)
 	err = readInterface.ParseFile(interfacePaths, generate.GetStructNames(structs))
 	if err != nil {
-		g.db.Logger.Error(context.Background(), "parser interface file fail: %s", err)
-		panic("parser interface file fail")
+		g.addSyncError(fmt.Errorf("parser interface file fail: %w", err), diagnostic.CodeQueryGenerate, "")
+		return
 	}
 
 	for _, interfaceStructMeta := range structs {
@@ -257,17 +279,18 @@ func (g *Generator) apply(fc interface{}, structs []*generate.QueryStructMeta) {
 			interfaceStructMeta.ReviseFieldNameFor(model.GormKeywords)
 		}
 		interfaceStructMeta.ReviseFieldNameFor(model.DOKeywords)
+		interfaceStructMeta.VersionColumn = g.VersionColumn
 
 		genInfo, err := g.pushQueryStructMeta(interfaceStructMeta)
 		if err != nil {
-			g.db.Logger.Error(context.Background(), "gen struct fail: %v", err)
-			panic("gen struct fail")
+			g.addSyncError(fmt.Errorf("gen struct fail: %w", err), diagnostic.CodeQueryGenerate, interfaceStructMeta.TableName)
+			continue
 		}
 
 		functions, err := generate.BuildDIYMethod(readInterface, interfaceStructMeta, genInfo.Interfaces)
 		if err != nil {
-			g.db.Logger.Error(context.Background(), "check interface fail: %v", err)
-			panic("check interface fail")
+			g.addSyncError(err, diagnostic.CodeQueryGenerate, interfaceStructMeta.TableName)
+			continue
 		}
 		genInfo.appendMethods(functions)
 	}
@@ -277,19 +300,73 @@ func (g *Generator) apply(fc interface{}, structs []*generate.QueryStructMeta) {
 func (g *Generator) Execute() {
 	g.info("Start generating code.")
 
+	if err := g.tableFilterError(); err != nil {
+		g.logDiagnostics(err)
+		panic("invalid table filter")
+	}
+
+	if err := g.syncErrs.Err(); err != nil {
+		g.logDiagnostics(err)
+		panic("generate struct fail")
+	}
+
 	if err := g.generateModelFile(); err != nil {
-		g.db.Logger.Error(context.Background(), "generate model struct fail: %s", err)
+		g.logDiagnostics(err)
 		panic("generate model struct fail")
 	}
 
-	if err := g.generateQueryFile(); err != nil {
-		g.db.Logger.Error(context.Background(), "generate query code fail: %s", err)
+	if err := g.generateQueryFile(); err != nil && g.logDiagnostics(err) {
 		panic("generate query code fail")
 	}
 
 	g.info("Generate code done.")
 }
 
+// ExecuteE generate code to output path like Execute, but return every failure as diagnostic instead of panic
+//
+// the returned error is a Diagnostics, each item keeps the file and table it belongs to
+func (g *Generator) ExecuteE() error {
+	g.info("Start generating code.")
+
+	if err := g.generate(); err != nil {
+		return err
+	}
+
+	g.info("Generate code done.")
+	return nil
+}
+
+// generate model and query code, collect diagnostics of both steps
+func (g *Generator) generate() error {
+	if err := g.tableFilterError(); err != nil {
+		return err
+	}
+	if err := g.syncErrs.Err(); err != nil {
+		return err
+	}
+
+	var errs diagnostic.List
+	errs = errs.Append(g.generateModelFile(), diagnostic.CodeModelGenerate)
+	errs = errs.Append(g.generateQueryFile(), diagnostic.CodeQueryGenerate)
+	return errs.Err()
+}
+
+// logDiagnostics log all diagnostics in err, return whether there is any failure besides unit test generation and pruning
+func (g *Generator) logDiagnostics(err error) (fatal bool) {
+	var errs diagnostic.List
+	errs = errs.Append(err, "")
+	for _, e := range errs {
+		g.db.Logger.Error(context.Background(), "%s", e)
+		if e.Diag.Snippet != "" {
+			g.logger.Println(e.Diag.Snippet)
+		}
+		if e.Diag.Code != diagnostic.CodeUnitTestGenerate && e.Diag.Code != diagnostic.CodePrune {
+			fatal = true
+		}
+	}
+	return fatal
+}
+
 // info logger
 func (g *Generator) info(logInfos ...string) {
 	for _, l := range logInfos {
@@ -304,60 +381,78 @@ func (g *Generator) generateQueryFile() (err error) {
 		return nil
 	}
 
-	if err = os.MkdirAll(g.OutPath, os.ModePerm); err != nil {
-		return fmt.Errorf("make dir outpath(%s) fail: %s", g.OutPath, err)
+	if err = g.mkdirAll(g.OutPath); err != nil {
+		return genError(fmt.Errorf("make dir outpath(%s) fail: %s", g.OutPath, err), diagnostic.CodeQueryGenerate, g.OutPath, "")
 	}
 
-	manifestEnabled := g.Incremental || g.MergeQuery
+	manifestEnabled := g.Incremental || g.MergeQuery || g.Prune || g.EditProtection != EditOverwrite
 	var manifest *genManifest
 	var manifestPath string
 	var manifestMu sync.Mutex
 	if manifestEnabled {
 		manifest, manifestPath, err = loadManifest(g.OutPath)
 		if err != nil {
-			return err
+			return genError(err, diagnostic.CodeQueryGenerate, filepath.Join(g.OutPath, manifestFileName), "")
 		}
 		prevMode := manifest.Mode
 		if g.MergeQuery && prevMode != 0 && prevMode != uint(g.Mode) {
-			return fmt.Errorf("cannot merge query tables with different mode: previous=%d current=%d", prevMode, g.Mode)
+			return genError(fmt.Errorf("cannot merge query tables with different mode: previous=%d current=%d", prevMode, g.Mode), diagnostic.CodeQueryGenerate, manifestPath, "")
 		}
 		manifest.Mode = uint(g.Mode)
 	}
 
-	errChan := make(chan error)
+	var errs genErrors
 	pool := pools.NewPool(concurrent)
 	// generate query code for all struct
 	for _, info := range g.Data {
 		pool.Wait()
 		go func(info *genInfo) {
 			defer pool.Done()
-			err := g.generateSingleQueryFile(info, manifest, &manifestMu)
-			if err != nil {
-				errChan <- err
-			}
+			errs.add(g.generateSingleQueryFile(info, manifest, &manifestMu), diagnostic.CodeQueryGenerate)
 
 			if g.WithUnitTest {
-				err = g.generateQueryUnitTestFile(info, manifest, &manifestMu)
-				if err != nil { // do not panic
-					g.db.Logger.Error(context.Background(), "generate unit test fail: %s", err)
-				}
+				errs.add(g.generateQueryUnitTestFile(info, manifest, &manifestMu), diagnostic.CodeUnitTestGenerate)
 			}
 		}(info)
 	}
-	select {
-	case err = <-errChan:
-		return err
-	case <-pool.AsyncWaitAll():
-	}
+	pool.WaitAll()
 
 	genForRoot := *g
-	if g.MergeQuery {
+	if manifestEnabled {
 		mergedTables, dataForGenGo := g.buildMergedQueryData(manifest)
 		manifest.Tables = mergedTables
 		genForRoot.Data = dataForGenGo
 	}
 
 	// generate query file
+	errs.add(genError(g.generateRootQueryFile(&genForRoot, manifest, &manifestMu), diagnostic.CodeQueryGenerate, g.OutFile, ""), diagnostic.CodeQueryGenerate)
+
+	// generate query unit test file
+	if g.WithUnitTest {
+		fileName := strings.TrimSuffix(g.OutFile, ".go") + "_test.go"
+		errs.add(genError(g.generateRootQueryUnitTestFile(&genForRoot, fileName, manifest, &manifestMu), diagnostic.CodeUnitTestGenerate, fileName, ""), diagnostic.CodeUnitTestGenerate)
+	}
+
+	if g.Prune {
+		// query files of merged tables are still referenced by gen.go
+		protected := make(map[string]bool)
+		if g.MergeQuery {
+			for _, t := range manifest.Tables {
+				protected[t.FileName+".gen.go"] = true
+				protected[t.FileName+".gen_test.go"] = true
+			}
+		}
+		errs.add(g.pruneManifestFiles(g.OutPath, manifest, protected), diagnostic.CodePrune)
+	}
+
+	if manifestEnabled {
+		errs.add(genError(g.saveManifest(manifestPath, manifest), diagnostic.CodeQueryGenerate, manifestPath, ""), diagnostic.CodeQueryGenerate)
+	}
+	return errs.list.Err()
+}
+
+// generateRootQueryFile generate gen.go which holds all query structs
+func (g *Generator) generateRootQueryFile(genForRoot *Generator, m *genManifest, mu *sync.Mutex) (err error) {
 	var buf bytes.Buffer
 	err = render(tmpl.Header, &buf, map[string]interface{}{
 		"Package":        g.queryPkgName,
@@ -368,18 +463,18 @@ func (g *Generator) generateQueryFile() (err error) {
 	}
 
 	if g.judgeMode(WithDefaultQuery) {
-		err = render(tmpl.DefaultQuery, &buf, &genForRoot)
+		err = render(tmpl.DefaultQuery, &buf, genForRoot)
 		if err != nil {
 			return err
 		}
 	}
-	err = render(tmpl.QueryMethod, &buf, &genForRoot)
+	err = render(tmpl.QueryMethod, &buf, genForRoot)
 	if err != nil {
 		return err
 	}
 
-	if manifestEnabled {
-		err = g.outputWithManifest(g.OutFile, buf.Bytes(), manifest, filepath.Base(g.OutFile), &manifestMu)
+	if m != nil {
+		err = g.outputWithManifest(g.OutFile, buf.Bytes(), m, filepath.Base(g.OutFile), mu)
 	} else {
 		err = g.output(g.OutFile, buf.Bytes())
 	}
@@ -387,46 +482,37 @@ func (g *Generator) generateQueryFile() (err error) {
 		return err
 	}
 	g.info("generate query file: " + g.OutFile)
+	return nil
+}
 
-	// generate query unit test file
-	if g.WithUnitTest {
-		buf.Reset()
-
-		err = render(tmpl.Header, &buf, map[string]interface{}{
-			"Package":        g.queryPkgName,
-			"ImportPkgPaths": unitTestImportList.Add(g.importPkgPaths...).Paths(),
-		})
-		if err != nil {
-			g.db.Logger.Error(context.Background(), "generate query unit test fail: %s", err)
-			return nil
-		}
-		err = render(tmpl.DIYMethodTestBasic, &buf, nil)
-		if err != nil {
-			return err
-		}
-		err = render(tmpl.QueryMethodTest, &buf, &genForRoot)
-		if err != nil {
-			g.db.Logger.Error(context.Background(), "generate query unit test fail: %s", err)
-			return nil
-		}
-		fileName := strings.TrimSuffix(g.OutFile, ".go") + "_test.go"
-		if manifestEnabled {
-			err = g.outputWithManifest(fileName, buf.Bytes(), manifest, filepath.Base(fileName), &manifestMu)
-		} else {
-			err = g.output(fileName, buf.Bytes())
-		}
-		if err != nil {
-			g.db.Logger.Error(context.Background(), "generate query unit test fail: %s", err)
-			return nil
-		}
-		g.info("generate unit test file: " + fileName)
+// generateRootQueryUnitTestFile generate unit test file for gen.go
+func (g *Generator) generateRootQueryUnitTestFile(genForRoot *Generator, fileName string, m *genManifest, mu *sync.Mutex) (err error) {
+	var buf bytes.Buffer
+	err = render(tmpl.Header, &buf, map[string]interface{}{
+		"Package":        g.queryPkgName,
+		"ImportPkgPaths": unitTestImportList.Add(g.importPkgPaths...).Paths(),
+	})
+	if err != nil {
+		return err
+	}
+	err = render(tmpl.DIYMethodTestBasic, &buf, nil)
+	if err != nil {
+		return err
+	}
+	err = render(tmpl.QueryMethodTest, &buf, genForRoot)
+	if err != nil {
+		return err
 	}
 
-	if manifestEnabled {
-		if err := saveManifest(manifestPath, manifest); err != nil {
-			return err
-		}
+	if m != nil {
+		err = g.outputWithManifest(fileName, buf.Bytes(), m, filepath.Base(fileName), mu)
+	} else {
+		err = g.output(fileName, buf.Bytes())
+	}
+	if err != nil {
+		return err
 	}
+	g.info("generate unit test file: " + fileName)
 	return nil
 }
 
@@ -436,6 +522,7 @@ func (g *Generator) buildMergedQueryData(manifest *genManifest) (map[string]genM
 		currentTables[d.ModelStructName] = genManifestTable{
 			ModelStructName: d.ModelStructName,
 			QueryStructName: d.QueryStructName,
+			TableName:       d.TableName,
 			FileName:        d.FileName,
 		}
 	}
@@ -482,11 +569,14 @@ func (g *Generator) buildMergedQueryData(manifest *genManifest) (map[string]genM
 
 // generateSingleQueryFile generate query code and save to file
 func (g *Generator) generateSingleQueryFile(data *genInfo, m *genManifest, mu *sync.Mutex) (err error) {
+	fileName := fmt.Sprintf("%s%s%s.gen.go", g.OutPath, string(os.PathSeparator), data.FileName)
+	defer func() { err = genError(err, diagnostic.CodeQueryGenerate, fileName, data.TableName) }()
+
 	var buf bytes.Buffer
 
 	structPkgPath := data.StructInfo.PkgPath
 	if structPkgPath == "" {
-		structPkgPath = g.modelPkgPath
+		structPkgPath = path.Join(g.modelPkgPath, data.ModelSubPkg)
 	}
 	err = render(tmpl.Header, &buf, map[string]interface{}{
 		"Package":        g.queryPkgName,
@@ -517,6 +607,7 @@ func (g *Generator) generateSingleQueryFile(data *genInfo, m *genManifest, mu *s
 	} else {
 		structTmpl += tmpl.DefineMethodStruct
 	}
+	crudTmpl += tmpl.VersionMethod
 	err = render(structTmpl, &buf, data.QueryStructMeta)
 	if err != nil {
 		return err
@@ -543,8 +634,7 @@ func (g *Generator) generateSingleQueryFile(data *genInfo, m *genManifest, mu *s
 		return err
 	}
 
-	defer g.info(fmt.Sprintf("generate query file: %s%s%s.gen.go", g.OutPath, string(os.PathSeparator), data.FileName))
-	fileName := fmt.Sprintf("%s%s%s.gen.go", g.OutPath, string(os.PathSeparator), data.FileName)
+	defer g.info(fmt.Sprintf("generate query file: %s", fileName))
 	if m == nil {
 		return g.output(fileName, buf.Bytes())
 	}
@@ -553,11 +643,14 @@ func (g *Generator) generateSingleQueryFile(data *genInfo, m *genManifest, mu *s
 
 // generateQueryUnitTestFile generate unit test file for query
 func (g *Generator) generateQueryUnitTestFile(data *genInfo, m *genManifest, mu *sync.Mutex) (err error) {
+	fileName := fmt.Sprintf("%s%s%s.gen_test.go", g.OutPath, string(os.PathSeparator), data.FileName)
+	defer func() { err = genError(err, diagnostic.CodeUnitTestGenerate, fileName, data.TableName) }()
+
 	var buf bytes.Buffer
 
 	structPkgPath := data.StructInfo.PkgPath
 	if structPkgPath == "" {
-		structPkgPath = g.modelPkgPath
+		structPkgPath = path.Join(g.modelPkgPath, data.ModelSubPkg)
 	}
 	err = render(tmpl.Header, &buf, map[string]interface{}{
 		"Package":        g.queryPkgName,
@@ -587,8 +680,7 @@ func (g *Generator) generateQueryUnitTestFile(data *genInfo, m *genManifest, mu
 		}
 	}
 
-	defer g.info(fmt.Sprintf("generate unit test file: %s%s%s.gen_test.go", g.OutPath, string(os.PathSeparator), data.FileName))
-	fileName := fmt.Sprintf("%s%s%s.gen_test.go", g.OutPath, string(os.PathSeparator), data.FileName)
+	defer g.info(fmt.Sprintf("generate unit test file: %s", fileName))
 	if m == nil {
 		return g.output(fileName, buf.Bytes())
 	}
@@ -603,26 +695,29 @@ func (g *Generator) generateModelFile() error {
 
 	modelOutPath, err := g.getModelOutputPath()
 	if err != nil {
-		return err
+		return genError(err, diagnostic.CodeModelGenerate, g.ModelPkgPath, "")
 	}
 
-	if err = os.MkdirAll(modelOutPath, os.ModePerm); err != nil {
-		return fmt.Errorf("create model pkg path(%s) fail: %s", modelOutPath, err)
+	if err = g.mkdirAll(modelOutPath); err != nil {
+		return genError(fmt.Errorf("create model pkg path(%s) fail: %s", modelOutPath, err), diagnostic.CodeModelGenerate, modelOutPath, "")
 	}
 
-	manifestEnabled := g.Incremental
+	manifestEnabled := g.Incremental || g.Prune || g.EditProtection != EditOverwrite
 	var manifest *genManifest
 	var manifestPath string
 	var manifestMu sync.Mutex
 	if manifestEnabled {
 		manifest, manifestPath, err = loadManifest(modelOutPath)
 		if err != nil {
-			return err
+			return genError(err, diagnostic.CodeModelGenerate, filepath.Join(modelOutPath, manifestFileName), "")
 		}
 		manifest.Mode = uint(g.Mode)
 	}
 
-	errChan := make(chan error)
+	dedupSharedEnums(g.models)
+
+	var errs genErrors
+	fingerprints := make(map[string]string, len(g.models)) // model fingerprints of successfully generated files
 	pool := pools.NewPool(concurrent)
 	for _, data := range g.models {
 		if data == nil || !data.Generated {
@@ -632,47 +727,139 @@ func (g *Generator) generateModelFile() error {
 		go func(data *generate.QueryStructMeta) {
 			defer pool.Done()
 
-			var buf bytes.Buffer
-			err := render(tmpl.Model, &buf, data)
-			if err != nil {
-				errChan <- err
+			modelFile := modelOutPath + filepath.FromSlash(modelFileKey(data))
+			if data.ModelSubPkg != "" {
+				if err := g.mkdirAll(filepath.Dir(modelFile)); err != nil {
+					errs.add(genError(fmt.Errorf("create model sub pkg path(%s) fail: %s", filepath.Dir(modelFile), err), diagnostic.CodeModelGenerate, modelFile, data.TableName), diagnostic.CodeModelGenerate)
+					return
+				}
+			}
+			fingerprint := modelFingerprint(data)
+			if g.Incremental && g.modelUnchanged(data, fingerprint, modelFile, manifest, &manifestMu) {
+				g.info(fmt.Sprintf("skip unchanged model file(table <%s>): %s", data.TableName, modelFile))
+			} else if err := g.generateSingleModelFile(data, modelFile, manifest, &manifestMu); err != nil {
+				errs.add(genError(err, diagnostic.CodeModelGenerate, modelFile, data.TableName), diagnostic.CodeModelGenerate)
 				return
 			}
 
-			for _, method := range data.ModelMethods {
-				err = render(tmpl.ModelMethod, &buf, method)
-				if err != nil {
-					errChan <- err
-					return
+			manifestMu.Lock()
+			fingerprints[data.ModelStructName] = fingerprint
+			manifestMu.Unlock()
+		}(data)
+	}
+	pool.WaitAll()
+
+	if manifestEnabled {
+		previous := manifest.Tables
+		manifest.Tables = make(map[string]genManifestTable, len(g.models))
+		for _, data := range g.models {
+			if data == nil || !data.Generated {
+				continue
+			}
+			manifest.Tables[data.ModelStructName] = genManifestTable{
+				ModelStructName: data.ModelStructName,
+				TableName:       data.TableName,
+				FileName:        data.FileName,
+				Schema:          fingerprints[data.ModelStructName],
+			}
+		}
+		if g.MergeQuery { // tables generated in previous runs are merged into query files as well
+			for name, t := range previous {
+				if _, ok := manifest.Tables[name]; !ok {
+					manifest.Tables[name] = t
 				}
 			}
-
-			modelFile := modelOutPath + data.FileName + ".gen.go"
-			if manifestEnabled {
-				err = g.outputWithManifest(modelFile, buf.Bytes(), manifest, filepath.Base(modelFile), &manifestMu)
-			} else {
-				err = g.output(modelFile, buf.Bytes())
+		}
+		if g.schemaCache != nil {
+			manifest.Schemas = g.schemaCache.schemas()
+		}
+	}
+	if g.Prune {
+		// model files of merged tables are still imported by merged query files
+		protected := make(map[string]bool)
+		if g.MergeQuery {
+			fileNames := make(map[string]bool, len(manifest.Tables))
+			for _, t := range manifest.Tables {
+				fileNames[t.FileName+".gen.go"] = true
 			}
-			if err != nil {
-				errChan <- err
-				return
+			for key := range manifest.Files {
+				protected[key] = fileNames[path.Base(key)]
 			}
+		}
+		errs.add(g.pruneManifestFiles(modelOutPath, manifest, protected), diagnostic.CodePrune)
+	}
+	if manifestEnabled {
+		errs.add(genError(g.saveManifest(manifestPath, manifest), diagnostic.CodeModelGenerate, manifestPath, ""), diagnostic.CodeModelGenerate)
+	}
+	g.fillModelPkgPath(modelOutPath)
+	return errs.list.Err()
+}
 
-			g.info(fmt.Sprintf("generate model file(table <%s> -> {%s.%s}): %s", data.TableName, data.StructInfo.Package, data.StructInfo.Type, modelFile))
-		}(data)
+// generateSingleModelFile generate model structure and save to file
+func (g *Generator) generateSingleModelFile(data *generate.QueryStructMeta, modelFile string, m *genManifest, mu *sync.Mutex) (err error) {
+	var buf bytes.Buffer
+	err = render(tmpl.Model, &buf, data)
+	if err != nil {
+		return err
+	}
+
+	for _, method := range data.ModelMethods {
+		err = render(tmpl.ModelMethod, &buf, method)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, enum := range data.Enums {
+		err = render(tmpl.ModelEnum, &buf, enum)
+		if err != nil {
+			return err
+		}
+	}
+
+	if m != nil {
+		err = g.outputWithManifest(modelFile, buf.Bytes(), m, modelFileKey(data), mu)
+	} else {
+		err = g.output(modelFile, buf.Bytes())
 	}
-	select {
-	case err = <-errChan:
+	if err != nil {
 		return err
-	case <-pool.AsyncWaitAll():
-		if manifestEnabled {
-			if err := saveManifest(manifestPath, manifest); err != nil {
-				return err
+	}
+
+	g.info(fmt.Sprintf("generate model file(table <%s> -> {%s.%s}): %s", data.TableName, data.StructInfo.Package, data.StructInfo.Type, modelFile))
+	return nil
+}
+
+// dedupSharedEnums declare enum type generated from database enum type once in each model package,
+// in model file of the first table using it
+func dedupSharedEnums(models map[string]*generate.QueryStructMeta) {
+	keys := make([]string, 0, len(models))
+	for key, data := range models {
+		if data != nil && data.Generated && len(data.Enums) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return modelFileKey(models[keys[i]]) < modelFileKey(models[keys[j]]) })
+
+	declared := make(map[string]bool)
+	for _, key := range keys {
+		data := models[key]
+		enums := data.Enums[:0]
+		for _, enum := range data.Enums {
+			name := data.ModelSubPkg + "." + enum.Name
+			if enum.Shared && declared[name] {
+				continue
 			}
+			declared[name] = true
+			enums = append(enums, enum)
 		}
-		g.fillModelPkgPath(modelOutPath)
+		data.Enums = enums
 	}
-	return nil
+}
+
+// modelFileKey return model file path relative to model output path, slash separated
+func modelFileKey(data *generate.QueryStructMeta) string {
+	return path.Join(data.ModelSubPkg, data.FileName+".gen.go")
 }
 
 func (g *Generator) getModelOutputPath() (outPath string, err error) {
@@ -709,20 +896,15 @@ func (g *Generator) format(fileName string, content []byte) ([]byte, error) {
 		return result, nil
 	}
 
-	lines := strings.Split(string(content), "\n")
-	errLine, _ := strconv.Atoi(strings.Split(err.Error(), ":")[1])
-	startLine, endLine := errLine-5, errLine+5
-	fmt.Println("Format fail:", errLine, err)
-	if startLine < 0 {
-		startLine = 0
-	}
-	if endLine > len(lines)-1 {
-		endLine = len(lines) - 1
+	e := diagnostic.Wrap(err, diagnostic.CodeFormat, "cannot format file: "+err.Error())
+	e.Diag.File = fileName
+	var list scanner.ErrorList
+	if errors.As(err, &list) && len(list) > 0 {
+		e.Diag.Line, e.Diag.Column = list[0].Pos.Line, list[0].Pos.Column
+		e.Diag.Message = "cannot format file: " + list[0].Msg
+		e.Diag.Snippet = diagnostic.CodeFrame(content, e.Diag.Line, e.Diag.Column, 5)
 	}
-	for i := startLine; i <= endLine; i++ {
-		fmt.Println(i, lines[i])
-	}
-	return nil, fmt.Errorf("cannot format file: %w", err)
+	return nil, e
 }
 
 // output format and output
@@ -731,7 +913,7 @@ func (g *Generator) output(fileName string, content []byte) error {
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(fileName, result, 0640)
+	return g.writeFile(fileName, result)
 }
 
 func (g *Generator) outputWithManifest(fileName string, content []byte, m *genManifest, key string, mu *sync.Mutex) error {
@@ -741,20 +923,28 @@ func (g *Generator) outputWithManifest(fileName string, content []byte, m *genMa
 	}
 
 	hash := sha256Hex(result)
+	mu.Lock()
+	m.markGenerated(key)
+	mu.Unlock()
 	if g.Incremental {
 		mu.Lock()
 		old := m.Files[key]
 		mu.Unlock()
-		if old == hash {
-			if _, err := os.Stat(fileName); err == nil {
-				return nil
-			}
+		if old == hash && g.generatedFileIntact(fileName, hash) {
+			return nil
+		}
+	}
+
+	if g.EditProtection != EditOverwrite {
+		mu.Lock()
+		old, recorded := m.Files[key]
+		mu.Unlock()
+		if recorded && editedByHand(fileName, old) {
+			return g.protectEditedFile(fileName, result)
 		}
-	} else {
-		// always write
 	}
 
-	if err := os.WriteFile(fileName, result, 0640); err != nil {
+	if err := g.writeFile(fileName, result); err != nil {
 		return err
 	}
 
@@ -776,6 +966,38 @@ func (g *Generator) pushQueryStructMeta(meta *generate.QueryStructMeta) (*genInf
 	return g.Data[structName], nil
 }
 
+// addSyncError record failure of reading table or applying interface, Execute reports it before generating files
+func (g *Generator) addSyncError(err error, code, tableName string) {
+	g.syncErrs = g.syncErrs.Append(genError(err, code, "", tableName), code)
+}
+
+// genErrors collect diagnostics from concurrent generate workers
+type genErrors struct {
+	mu   sync.Mutex
+	list diagnostic.List
+}
+
+func (e *genErrors) add(err error, code string) {
+	if err == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.list = e.list.Append(err, code)
+}
+
+// genError wrap err as diagnostic, fill the generated file and table it belongs to
+func genError(err error, code, fileName, tableName string) error {
+	if err == nil {
+		return nil
+	}
+	var e *diagnostic.Error
+	if !errors.As(err, &e) {
+		e = diagnostic.Wrap(err, code, err.Error())
+	}
+	return diagnostic.WithTable(diagnostic.WithLocation(e, fileName, 0, 0), tableName)
+}
+
 func render(tmpl string, wr io.Writer, data interface{}) error {
 	if tmpl == "" {
 		return nil
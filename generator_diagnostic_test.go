@@ -0,0 +1,87 @@
+package gen
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"gorm.io/gen/internal/diagnostic"
+	"gorm.io/gen/internal/generate"
+	"gorm.io/gen/internal/model"
+	"gorm.io/gen/internal/parser"
+)
+
+func brokenModel(name, table string) *generate.QueryStructMeta {
+	return &generate.QueryStructMeta{
+		Generated:       true,
+		FileName:        table,
+		ModelStructName: name,
+		TableName:       table,
+		StructInfo:      parser.Param{Package: "model", Type: name},
+		Fields:          []*model.Field{{Name: "ID", Type: "map[", ColumnName: "id"}},
+	}
+}
+
+func TestExecuteE_AggregatesDiagnostics(t *testing.T) {
+	tmp := t.TempDir()
+	g := NewGenerator(Config{OutPath: filepath.Join(tmp, "query"), ModelPkgPath: filepath.Join(tmp, "model")})
+	g.models["User"] = brokenModel("User", "users")
+	g.models["Order"] = brokenModel("Order", "orders")
+
+	err := g.ExecuteE()
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+
+	var list Diagnostics
+	if !errors.As(err, &list) {
+		t.Fatalf("expected diagnostics, got %T: %v", err, err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d: %v", len(list), err)
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Diag.Table < list[j].Diag.Table })
+	for i, table := range []string{"orders", "users"} {
+		d := list[i].Diag
+		if d.Code != diagnostic.CodeFormat {
+			t.Fatalf("unexpected code: %s", d.Code)
+		}
+		if d.Table != table || filepath.Base(d.File) != table+".gen.go" {
+			t.Fatalf("unexpected context: table=%s file=%s", d.Table, d.File)
+		}
+		if d.Line == 0 || d.Snippet == "" {
+			t.Fatalf("expected position and snippet: %+v", d)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := WriteDiagnosticJSON(&buf, err); err != nil {
+		t.Fatalf("write json: %v", err)
+	}
+	var payload []map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	if len(payload) != 2 || payload[0]["table"] == "" {
+		t.Fatalf("unexpected json payload:\n%s", buf.String())
+	}
+}
+
+func TestExecuteE_ReportsApplyFailure(t *testing.T) {
+	tmp := t.TempDir()
+	g := NewGenerator(Config{OutPath: filepath.Join(tmp, "query"), ModelPkgPath: filepath.Join(tmp, "model")})
+	g.ApplyBasic("not a struct")
+
+	err := g.ExecuteE()
+	var list Diagnostics
+	if !errors.As(err, &list) || len(list) != 1 {
+		t.Fatalf("expected 1 diagnostic of apply failure, got %v", err)
+	}
+	if list[0].Diag.Code != diagnostic.CodeQueryGenerate {
+		t.Fatalf("expected diagnostic code %s, got %s", diagnostic.CodeQueryGenerate, list[0].Diag.Code)
+	}
+}
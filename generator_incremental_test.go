@@ -1,13 +1,22 @@
 package gen
 
 import (
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"sync"
 	"testing"
 
+	"gorm.io/gorm/migrator"
+
+	"gorm.io/gen/internal/diagnostic"
 	"gorm.io/gen/internal/generate"
+	"gorm.io/gen/internal/model"
+	"gorm.io/gen/internal/parser"
 )
 
 func TestOutputWithManifest_IncrementalSkipDoesNotOverwrite(t *testing.T) {
@@ -42,6 +51,44 @@ func TestOutputWithManifest_IncrementalSkipDoesNotOverwrite(t *testing.T) {
 	}
 }
 
+func TestOutputWithManifest_IncrementalEditProtectionChecksDisk(t *testing.T) {
+	tmp := t.TempDir()
+	g := NewGenerator(Config{OutPath: tmp, EditProtection: EditWriteNew})
+	g.Incremental = true
+
+	m := &genManifest{Version: 1, Files: map[string]string{}}
+	var mu sync.Mutex
+
+	fileName := filepath.Join(tmp, "x.go")
+	content := []byte("package p\n\nfunc A() {}\n")
+
+	if err := g.outputWithManifest(fileName, content, m, filepath.Base(fileName), &mu); err != nil {
+		t.Fatalf("first output: %v", err)
+	}
+
+	g.dryRun = &dryRunRecorder{}
+	if err := g.outputWithManifest(fileName, content, m, filepath.Base(fileName), &mu); err != nil {
+		t.Fatalf("second output: %v", err)
+	}
+	if changes := g.dryRun.result(); len(changes) != 0 {
+		t.Fatalf("expected unchanged file to be skipped, got %+v", changes)
+	}
+	g.dryRun = nil
+
+	if err := os.WriteFile(fileName, []byte("package p\n\nfunc B() {}\n"), 0640); err != nil {
+		t.Fatalf("tamper file: %v", err)
+	}
+	if err := g.outputWithManifest(fileName, content, m, filepath.Base(fileName), &mu); err != nil {
+		t.Fatalf("third output: %v", err)
+	}
+	if b, _ := os.ReadFile(fileName); !strings.Contains(string(b), "func B") {
+		t.Fatalf("expected file to keep tampered content, got:\n%s", b)
+	}
+	if b, err := os.ReadFile(fileName + ".new"); err != nil || string(b) != string(content) {
+		t.Fatalf("expected generated code to be written next to hand-edited file, got %q, %v", b, err)
+	}
+}
+
 func TestBuildMergedQueryData_MergeKeepsPreviousTables(t *testing.T) {
 	tmp := t.TempDir()
 	g := NewGenerator(Config{OutPath: tmp})
@@ -82,3 +129,314 @@ func TestBuildMergedQueryData_MergeKeepsPreviousTables(t *testing.T) {
 	}
 }
 
+func TestPruneManifestFiles_KeepsHandEditedFiles(t *testing.T) {
+	tmp := t.TempDir()
+	g := NewGenerator(Config{OutPath: tmp})
+	g.Prune = true
+
+	stale := []byte("package query\n\nfunc Stale() {}\n")
+	edited := []byte("package query\n\nfunc Edited() {}\n")
+	current := []byte("package query\n\nfunc Current() {}\n")
+	for name, content := range map[string][]byte{"stale.gen.go": stale, "edited.gen.go": append(edited, "// hand edit\n"...), "current.gen.go": current} {
+		if err := os.WriteFile(filepath.Join(tmp, name), content, 0640); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	m := &genManifest{
+		Version: 1,
+		Tables: map[string]genManifestTable{
+			"Edited": {ModelStructName: "Edited", TableName: "edited", FileName: "edited"},
+		},
+		Files: map[string]string{
+			"stale.gen.go":   sha256Hex(stale),
+			"edited.gen.go":  sha256Hex(edited),
+			"missing.gen.go": sha256Hex(stale),
+			"current.gen.go": sha256Hex(current),
+		},
+	}
+	m.markGenerated("current.gen.go")
+
+	err := g.pruneManifestFiles(tmp, m, nil)
+
+	var list Diagnostics
+	if !errors.As(err, &list) || len(list) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %v", err)
+	}
+	if d := list[0].Diag; d.Code != diagnostic.CodePrune || filepath.Base(d.File) != "edited.gen.go" || d.Table != "edited" {
+		t.Fatalf("unexpected diagnostic: %+v", d)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmp, "stale.gen.go")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected stale file to be deleted, stat err: %v", err)
+	}
+	for _, name := range []string{"edited.gen.go", "current.gen.go"} {
+		if _, err := os.Stat(filepath.Join(tmp, name)); err != nil {
+			t.Fatalf("expected %s to be kept: %v", name, err)
+		}
+	}
+
+	if _, ok := m.Files["stale.gen.go"]; ok {
+		t.Fatalf("expected stale file to be removed from manifest")
+	}
+	if _, ok := m.Files["missing.gen.go"]; ok {
+		t.Fatalf("expected missing file to be removed from manifest")
+	}
+	if _, ok := m.Files["edited.gen.go"]; !ok {
+		t.Fatalf("expected edited file to stay in manifest")
+	}
+}
+
+func TestPruneManifestFiles_DryRunRecordsDelete(t *testing.T) {
+	tmp := t.TempDir()
+	g := NewGenerator(Config{OutPath: tmp})
+	g.dryRun = &dryRunRecorder{}
+
+	stale := []byte("package query\n")
+	fileName := filepath.Join(tmp, "stale.gen.go")
+	if err := os.WriteFile(fileName, stale, 0640); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	m := &genManifest{Version: 1, Files: map[string]string{"stale.gen.go": sha256Hex(stale)}}
+
+	if err := g.pruneManifestFiles(tmp, m, nil); err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+	if _, err := os.Stat(fileName); err != nil {
+		t.Fatalf("expected file to be kept in dry run: %v", err)
+	}
+	if changes := g.dryRun.result(); len(changes) != 1 || changes[0].Type != FileDelete {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+}
+
+func TestOutputWithManifest_EditProtection(t *testing.T) {
+	first := []byte("package p\n\nfunc A() {}\n")
+	second := []byte("package p\n\nfunc A() {}\n\nfunc B() {}\n")
+	edited := []byte("package p\n\nfunc A() { println() }\n")
+
+	for _, mode := range []EditProtection{EditAbort, EditWriteNew} {
+		tmp := t.TempDir()
+		g := NewGenerator(Config{OutPath: tmp, EditProtection: mode})
+		m := &genManifest{Version: 1, Files: map[string]string{}}
+		var mu sync.Mutex
+
+		fileName := filepath.Join(tmp, "x.gen.go")
+		if err := g.outputWithManifest(fileName, first, m, filepath.Base(fileName), &mu); err != nil {
+			t.Fatalf("first output: %v", err)
+		}
+		if err := os.WriteFile(fileName, edited, 0640); err != nil {
+			t.Fatalf("edit file: %v", err)
+		}
+
+		err := g.outputWithManifest(fileName, second, m, filepath.Base(fileName), &mu)
+		switch mode {
+		case EditAbort:
+			var de *Diagnostic
+			if !errors.As(err, &de) || de.Diag.Code != diagnostic.CodeHandEdited || de.Diag.File != fileName {
+				t.Fatalf("expected hand edited diagnostic, got %v", err)
+			}
+		case EditWriteNew:
+			if err != nil {
+				t.Fatalf("second output: %v", err)
+			}
+			b, err := os.ReadFile(fileName + ".new")
+			if err != nil {
+				t.Fatalf("read new file: %v", err)
+			}
+			if !strings.Contains(string(b), "func B") {
+				t.Fatalf("unexpected new file content:\n%s", b)
+			}
+		}
+
+		b, err := os.ReadFile(fileName)
+		if err != nil {
+			t.Fatalf("read file: %v", err)
+		}
+		if string(b) != string(edited) {
+			t.Fatalf("expected hand edit to be kept, got:\n%s", b)
+		}
+		if m.Files[filepath.Base(fileName)] != sha256Hex(first) {
+			t.Fatalf("expected manifest hash to be kept")
+		}
+	}
+}
+
+func TestGenerateModelFile_IncrementalSkipsUnchangedTables(t *testing.T) {
+	tmp := t.TempDir()
+	modelPath := filepath.Join(tmp, "model")
+	g := NewGenerator(Config{OutPath: filepath.Join(tmp, "query"), ModelPkgPath: modelPath, Incremental: true})
+
+	user := &generate.QueryStructMeta{
+		Generated:       true,
+		FileName:        "users",
+		ModelStructName: "User",
+		TableName:       "users",
+		StructInfo:      parser.Param{Package: "model", Type: "User"},
+		Fields:          []*model.Field{{Name: "ID", Type: "int64", ColumnName: "id"}},
+	}
+	g.models["User"] = user
+
+	if err := g.generateModelFile(); err != nil {
+		t.Fatalf("first generate: %v", err)
+	}
+
+	fileName := filepath.Join(modelPath, "users.gen.go")
+	g.dryRun = &dryRunRecorder{}
+	if err := g.generateModelFile(); err != nil {
+		t.Fatalf("second generate: %v", err)
+	}
+	if changes := g.dryRun.result(); len(changes) != 0 {
+		t.Fatalf("expected unchanged table to be skipped, got %+v", changes)
+	}
+
+	g.dryRun = nil
+
+	if err := os.WriteFile(fileName, []byte("package model\n\n// untouched\n"), 0640); err != nil {
+		t.Fatalf("mark file: %v", err)
+	}
+	if err := g.generateModelFile(); err != nil {
+		t.Fatalf("incremental generate: %v", err)
+	}
+	if b, _ := os.ReadFile(fileName); !strings.Contains(string(b), "untouched") {
+		t.Fatalf("expected unchanged table to be skipped, got:\n%s", b)
+	}
+
+	user.Fields = append(user.Fields, &model.Field{Name: "Name", Type: "string", ColumnName: "name"})
+	if err := g.generateModelFile(); err != nil {
+		t.Fatalf("third generate: %v", err)
+	}
+	if b, _ := os.ReadFile(fileName); !strings.Contains(string(b), "Name string") {
+		t.Fatalf("expected changed table to be regenerated, got:\n%s", b)
+	}
+
+	m, _, err := loadManifest(modelPath)
+	if err != nil {
+		t.Fatalf("load manifest: %v", err)
+	}
+	if m.Tables["User"].Schema != modelFingerprint(user) {
+		t.Fatalf("expected manifest to record model fingerprint, got %+v", m.Tables["User"])
+	}
+}
+
+func TestSchemaCache_ReuseUnchangedTables(t *testing.T) {
+	column := func(name string) *model.Column {
+		return &model.Column{ColumnType: migrator.ColumnType{
+			NameValue:        sql.NullString{String: name, Valid: true},
+			DataTypeValue:    sql.NullString{String: "varchar", Valid: true},
+			LengthValue:      sql.NullInt64{Int64: 32, Valid: true},
+			DecimalSizeValue: sql.NullInt64{Valid: true},
+			NullableValue:    sql.NullBool{Valid: true},
+			ScanTypeValue:    reflect.TypeOf(""),
+		}}
+	}
+
+	first := &schemaCache{checksums: map[string]string{"users": "c1"}, current: map[string]genManifestSchema{}}
+	if _, _, ok := first.Load("users", false); ok {
+		t.Fatalf("expected cache miss without previous schema")
+	}
+	first.Store("users", false, []*model.Column{column("id"), column("name")}, "user table")
+	first.Store("orders", false, []*model.Column{column("id")}, "") // no checksum, not cached
+
+	b, err := json.Marshal(&genManifest{Version: 1, Schemas: first.schemas()})
+	if err != nil {
+		t.Fatalf("marshal manifest: %v", err)
+	}
+	var m genManifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatalf("unmarshal manifest: %v", err)
+	}
+	if _, ok := m.Schemas["orders"]; ok || len(m.Schemas) != 1 {
+		t.Fatalf("unexpected schemas: %s", b)
+	}
+
+	second := &schemaCache{checksums: map[string]string{"users": "c1"}, prev: m.Schemas, current: map[string]genManifestSchema{}}
+	columns, comment, ok := second.Load("users", false)
+	if !ok || len(columns) != 2 || comment != "user table" || columns[1].Name() != "name" {
+		t.Fatalf("expected cached schema, got ok=%t comment=%q columns=%d", ok, comment, len(columns))
+	}
+	if _, ok := second.schemas()["users"]; !ok {
+		t.Fatalf("expected reused schema to be kept in manifest")
+	}
+	if _, _, ok := second.Load("users", true); ok {
+		t.Fatalf("expected cache miss when index tag option changed")
+	}
+
+	changed := &schemaCache{checksums: map[string]string{"users": "c2"}, prev: m.Schemas, current: map[string]genManifestSchema{}}
+	if _, _, ok := changed.Load("users", false); ok {
+		t.Fatalf("expected cache miss when checksum changed")
+	}
+}
+
+func TestGenerateModelFile_SubPkg(t *testing.T) {
+	tmp := t.TempDir()
+	modelPath := filepath.Join(tmp, "model")
+	g := NewGenerator(Config{OutPath: filepath.Join(tmp, "query"), ModelPkgPath: modelPath, Incremental: true})
+
+	g.models["AuditLog"] = &generate.QueryStructMeta{
+		Generated:       true,
+		FileName:        "log",
+		ModelStructName: "AuditLog",
+		TableName:       "audit_logs",
+		ModelSubPkg:     "audit",
+		StructInfo:      parser.Param{Package: "audit", Type: "AuditLog"},
+		Fields:          []*model.Field{{Name: "ID", Type: "int64", ColumnName: "id"}},
+	}
+	if err := g.generateModelFile(); err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	b, err := os.ReadFile(filepath.Join(modelPath, "audit", "log.gen.go"))
+	if err != nil {
+		t.Fatalf("read model file: %v", err)
+	}
+	if !strings.Contains(string(b), "package audit\n") {
+		t.Fatalf("expected model in sub package, got:\n%s", b)
+	}
+
+	m, _, err := loadManifest(modelPath)
+	if err != nil {
+		t.Fatalf("load manifest: %v", err)
+	}
+	if _, ok := m.Files["audit/log.gen.go"]; !ok {
+		t.Fatalf("expected manifest key relative to model path, got %v", m.Files)
+	}
+}
+
+func TestGenerateModelFile_PruneKeepsMergedTables(t *testing.T) {
+	tmp := t.TempDir()
+	modelPath := filepath.Join(tmp, "model")
+	newModel := func(name, fileName string) *generate.QueryStructMeta {
+		return &generate.QueryStructMeta{
+			Generated:       true,
+			FileName:        fileName,
+			ModelStructName: name,
+			TableName:       fileName,
+			StructInfo:      parser.Param{Package: "model", Type: name},
+			Fields:          []*model.Field{{Name: "ID", Type: "int64", ColumnName: "id"}},
+		}
+	}
+
+	for _, merge := range []bool{true, false} {
+		g := NewGenerator(Config{OutPath: filepath.Join(tmp, "query"), ModelPkgPath: modelPath, MergeQuery: merge, Prune: true})
+		g.models["User"] = newModel("User", "users")
+		g.models["Post"] = newModel("Post", "posts")
+		if err := g.generateModelFile(); err != nil {
+			t.Fatalf("first generate: %v", err)
+		}
+
+		g = NewGenerator(Config{OutPath: filepath.Join(tmp, "query"), ModelPkgPath: modelPath, MergeQuery: merge, Prune: true})
+		g.models["User"] = newModel("User", "users")
+		if err := g.generateModelFile(); err != nil {
+			t.Fatalf("second generate: %v", err)
+		}
+
+		_, err := os.Stat(filepath.Join(modelPath, "posts.gen.go"))
+		if merge && err != nil {
+			t.Fatalf("expected model file of merged table to be kept, stat err: %v", err)
+		}
+		if !merge && !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("expected model file of stale table to be pruned, stat err: %v", err)
+		}
+	}
+}
@@ -34,6 +34,8 @@ type IGenericsDo[T any, E any] interface {
 	Join(table schema.Tabler, on ...field.Expr) T
 	LeftJoin(table schema.Tabler, on ...field.Expr) T
 	RightJoin(table schema.Tabler, on ...field.Expr) T
+	With(name string, q SubQuery) T
+	WithRecursive(name string, q SubQuery) T
 	Group(cols ...field.Expr) T
 	Having(conds ...Condition) T
 	Limit(limit int) T
@@ -41,6 +43,8 @@ type IGenericsDo[T any, E any] interface {
 	Count() (count int64, err error)
 	Scopes(funcs ...func(Dao) Dao) T
 	Unscoped() T
+	CrossTenant() T
+	OnConflict(cols ...field.Expr) Upsert[T]
 	Create(values ...E) error
 	CreateInBatches(values []E, batchSize int) error
 	Save(values ...E) error
@@ -50,6 +54,7 @@ type IGenericsDo[T any, E any] interface {
 	Find() ([]E, error)
 	FindInBatch(batchSize int, fc func(tx Dao, batch int) error) (results []E, err error)
 	FindInBatches(result *[]E, batchSize int, fc func(tx Dao, batch int) error) error
+	Iter() *Iterator[E]
 	Pluck(column field.Expr, dest interface{}) error
 	Delete(...E) (info ResultInfo, err error)
 	Update(column field.Expr, value interface{}) (info ResultInfo, err error)
@@ -67,6 +72,7 @@ type IGenericsDo[T any, E any] interface {
 	FirstOrCreate() (E, error)
 	FindByPage(offset int, limit int) (result []E, count int64, err error)
 	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	FindByCursor(cursor string, limit int, orderCols ...field.OrderExpr) (result []E, next string, prev string, err error)
 	Rows() (*sql.Rows, error)
 	Row() *sql.Row
 	Scan(result interface{}) (err error)
@@ -177,6 +183,16 @@ func (b GenericsDo[T, E]) RightJoin(table schema.Tabler, on ...field.Expr) T {
 	return b.withDO(b.DO.RightJoin(table, on...))
 }
 
+// With ...
+func (b GenericsDo[T, E]) With(name string, q SubQuery) T {
+	return b.withDO(b.DO.With(name, q))
+}
+
+// WithRecursive ...
+func (b GenericsDo[T, E]) WithRecursive(name string, q SubQuery) T {
+	return b.withDO(b.DO.WithRecursive(name, q))
+}
+
 // Group ...
 func (b GenericsDo[T, E]) Group(cols ...field.Expr) T {
 	return b.withDO(b.DO.Group(cols...))
@@ -207,6 +223,36 @@ func (b GenericsDo[T, E]) Unscoped() T {
 	return b.withDO(b.DO.Unscoped())
 }
 
+// CrossTenant ...
+func (b GenericsDo[T, E]) CrossTenant() T {
+	return b.withDO(b.DO.CrossTenant())
+}
+
+// OnConflict ...
+func (b GenericsDo[T, E]) OnConflict(cols ...field.Expr) Upsert[T] {
+	return NewUpsert(&b.DO, b.withDO, cols...)
+}
+
+// WithDeleted ...
+func (b GenericsDo[T, E]) WithDeleted() T {
+	return b.withDO(b.DO.WithDeleted())
+}
+
+// OnlyDeleted ...
+func (b GenericsDo[T, E]) OnlyDeleted() T {
+	return b.withDO(b.DO.OnlyDeleted())
+}
+
+// Restore ...
+func (b GenericsDo[T, E]) Restore() (info ResultInfo, err error) {
+	return b.DO.Restore()
+}
+
+// ForceDelete ...
+func (b GenericsDo[T, E]) ForceDelete(models ...E) (info ResultInfo, err error) {
+	return b.DO.ForceDelete(models)
+}
+
 // Create ...
 func (b GenericsDo[T, E]) Create(values ...E) error {
 	if len(values) == 0 {
@@ -302,6 +348,11 @@ func (b GenericsDo[T, E]) FindInBatches(result *[]E, batchSize int, fc func(tx D
 	return b.DO.FindInBatches(result, batchSize, fc)
 }
 
+// Iter ...
+func (b GenericsDo[T, E]) Iter() *Iterator[E] {
+	return NewIterator[E](&b.DO)
+}
+
 // Attrs ...
 func (b GenericsDo[T, E]) Attrs(attrs ...field.AssignExpr) T {
 	return b.withDO(b.DO.Attrs(attrs...))
@@ -374,6 +425,19 @@ func (b GenericsDo[T, E]) FindByPage(offset int, limit int) (result []E, count i
 	return
 }
 
+// FindByCursor ...
+func (b GenericsDo[T, E]) FindByCursor(cursor string, limit int, orderCols ...field.OrderExpr) (result []E, next string, prev string, err error) {
+	results, next, prev, err := b.DO.FindByCursor(cursor, limit, orderCols...)
+	if err != nil {
+		return nil, "", "", err
+	}
+	v, ok := results.([]E)
+	if !ok {
+		return nil, "", "", fmt.Errorf("gen: FindByCursor type mismatch, expected []E, got %T", results)
+	}
+	return v, next, prev, nil
+}
+
 // ScanByPage ...
 func (b GenericsDo[T, E]) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
 	count, err = b.Count()
@@ -0,0 +1,394 @@
+package ddl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gorm.io/gen/internal/model"
+)
+
+// typeSpec column type as written in DDL
+type typeSpec struct {
+	words        []string // lower-case words of type name, like [double precision]
+	name         string   // type name as written
+	args         []string // type arguments, like [10 2] of decimal(10,2)
+	unsigned     bool
+	zerofill     bool
+	array        bool
+	withTimeZone bool
+}
+
+// dialect describe columns and indexes the same as introspection of database with the dialect
+type dialect interface {
+	name() string
+	// foldName return identifier as stored by database
+	foldName(tok token) string
+	// typeWord report whether word continues a multi-word type name
+	typeWord(spec *typeSpec, word token) bool
+	// setType set column's data type, column type, length and decimal size
+	setType(c *column, spec *typeSpec)
+	// defaultValue return column default value as database reports it, ok is false if no default value
+	defaultValue(expr []token) (value string, ok bool)
+	// indexName return name database gives to unnamed index
+	indexName(t *table, idx *index) string
+	// indexVisible report whether index is reported by gorm migrator's GetIndexes
+	indexVisible(idx *index) bool
+	// snapshot describe column as gorm migrator's ColumnTypes reports it
+	snapshot(t *table, c *column) model.ColumnSnapshot
+}
+
+var dialects = map[string]dialect{
+	"mysql":    mysqlDialect{},
+	"postgres": postgresDialect{},
+	"sqlite":   sqliteDialect{},
+}
+
+// multiWordTypes type name prefix -> words may follow
+var multiWordTypes = map[string][]string{
+	"double":             {"precision"},
+	"character":          {"varying"},
+	"char":               {"varying"},
+	"nchar":              {"varying"},
+	"bit":                {"varying"},
+	"national":           {"char", "character", "varchar"},
+	"national char":      {"varying"},
+	"national character": {"varying"},
+	"long":               {"varchar", "varbinary"},
+}
+
+func typeWord(spec *typeSpec, word token) bool {
+	for _, w := range multiWordTypes[strings.Join(spec.words, " ")] {
+		if word.is(w) {
+			return true
+		}
+	}
+	return false
+}
+
+func isNull(expr []token) bool { return len(expr) == 1 && expr[0].is("NULL") }
+
+func intArg(args []string, i int, defaultValue int64) int64 {
+	if i < len(args) {
+		if v, err := strconv.ParseInt(strings.TrimSpace(args[i]), 10, 64); err == nil {
+			return v
+		}
+	}
+	return defaultValue
+}
+
+func ptr[T any](v T) *T { return &v }
+
+type mysqlDialect struct{}
+
+var (
+	mysqlTypeAliases = map[string]string{
+		"integer": "int", "int1": "tinyint", "int2": "smallint", "int3": "mediumint", "int4": "int", "int8": "bigint", "middleint": "mediumint",
+		"dec": "decimal", "numeric": "decimal", "fixed": "decimal",
+		"real": "double", "double precision": "double", "float8": "double", "float4": "float",
+		"character": "char", "nchar": "char", "national char": "char", "national character": "char",
+		"character varying": "varchar", "char varying": "varchar", "nchar varying": "varchar", "nvarchar": "varchar",
+		"national varchar": "varchar", "national char varying": "varchar", "national character varying": "varchar",
+		"long": "mediumtext", "long varchar": "mediumtext", "long varbinary": "mediumblob",
+	}
+	mysqlIntegerPrecision = map[string]int64{"tinyint": 3, "smallint": 5, "mediumint": 7, "int": 10, "bigint": 19}
+	mysqlTextLength       = map[string]int64{
+		"tinytext": 255, "text": 65535, "mediumtext": 16777215, "longtext": 4294967295,
+		"tinyblob": 255, "blob": 65535, "mediumblob": 16777215, "longblob": 4294967295,
+	}
+)
+
+func (mysqlDialect) name() string { return "mysql" }
+
+func (mysqlDialect) foldName(tok token) string { return tok.text }
+
+func (mysqlDialect) typeWord(spec *typeSpec, word token) bool { return typeWord(spec, word) }
+
+func (mysqlDialect) setType(c *column, spec *typeSpec) {
+	name, args := strings.Join(spec.words, " "), spec.args
+	if alias, ok := mysqlTypeAliases[name]; ok {
+		name = alias
+	}
+	switch name {
+	case "bool", "boolean":
+		name, args = "tinyint", []string{"1"}
+	case "serial":
+		name, spec.unsigned = "bigint", true
+		c.autoIncrement, c.nullable = true, false
+	case "float":
+		if len(args) == 1 {
+			if intArg(args, 0, 0) > 24 {
+				name = "double"
+			}
+			args = nil
+		}
+	}
+
+	c.dataType, c.columnType = name, name
+	switch {
+	case mysqlIntegerPrecision[name] > 0:
+		if len(args) == 1 && (spec.zerofill || name == "tinyint" && args[0] == "1") { // display width is dropped since MySQL 8.0.19
+			c.columnType += "(" + args[0] + ")"
+		}
+		precision := mysqlIntegerPrecision[name]
+		if spec.unsigned && (name == "mediumint" || name == "bigint") {
+			precision++
+		}
+		c.precision, c.scale = ptr(precision), ptr(int64(0))
+	case name == "decimal":
+		c.precision, c.scale = ptr(intArg(args, 0, 10)), ptr(intArg(args, 1, 0))
+		c.columnType = fmt.Sprintf("decimal(%d,%d)", *c.precision, *c.scale)
+	case name == "char" || name == "binary" || name == "bit":
+		length := intArg(args, 0, 1)
+		c.columnType = fmt.Sprintf("%s(%d)", name, length)
+		if name != "bit" {
+			c.length = ptr(length)
+		}
+	case name == "varchar" || name == "varbinary":
+		c.length = ptr(intArg(args, 0, 0))
+		c.columnType = fmt.Sprintf("%s(%d)", name, *c.length)
+	case mysqlTextLength[name] > 0:
+		c.length = ptr(mysqlTextLength[name])
+	case name == "year":
+	case len(args) > 0:
+		c.columnType += "(" + strings.Join(args, ",") + ")"
+	}
+
+	if spec.unsigned && (mysqlIntegerPrecision[name] > 0 || name == "decimal" || name == "float" || name == "double") {
+		c.columnType += " unsigned"
+	}
+	if spec.zerofill {
+		c.columnType += " zerofill"
+	}
+}
+
+func (mysqlDialect) defaultValue(expr []token) (string, bool) {
+	switch {
+	case len(expr) == 0 || isNull(expr):
+		return "", false
+	case len(expr) == 1 && expr[0].kind == tokenString:
+		return expr[0].text, true
+	case len(expr) == 1 && expr[0].is("TRUE"):
+		return "1", true
+	case len(expr) == 1 && expr[0].is("FALSE"):
+		return "0", true
+	case expr[0].is("CURRENT_TIMESTAMP", "NOW", "LOCALTIME", "LOCALTIMESTAMP"):
+		if len(expr) == 4 && expr[2].kind == tokenNumber { // with fractional seconds precision, like CURRENT_TIMESTAMP(3)
+			return "CURRENT_TIMESTAMP(" + expr[2].text + ")", true
+		}
+		return "CURRENT_TIMESTAMP", true
+	case expr[0].isPunct("(") && expr[len(expr)-1].isPunct(")"): // expression default value
+		return rawText(expr[1 : len(expr)-1]), true
+	}
+	return rawText(expr), true
+}
+
+func (mysqlDialect) indexName(t *table, idx *index) string {
+	if idx.primaryKey {
+		return "PRIMARY"
+	}
+	return t.uniqueName(idx.columns[0], "_", 2)
+}
+
+func (mysqlDialect) indexVisible(*index) bool { return true }
+
+func (mysqlDialect) snapshot(t *table, c *column) model.ColumnSnapshot {
+	primaryKey := t.isPrimaryKey(c.name)
+	comment := ""
+	if c.comment != nil {
+		comment = *c.comment
+	}
+	return model.ColumnSnapshot{
+		Name:          c.name,
+		DatabaseType:  c.dataType,
+		ColumnType:    ptr(c.columnType),
+		PrimaryKey:    ptr(primaryKey),
+		AutoIncrement: ptr(c.autoIncrement),
+		Unique:        ptr(!primaryKey && t.isUnique(c.name, false)),
+		Length:        c.length,
+		Precision:     c.precision,
+		Scale:         c.scale,
+		Nullable:      ptr(c.nullable && !primaryKey),
+		Comment:       &comment,
+		DefaultValue:  c.defaultValue,
+	}
+}
+
+type postgresDialect struct{}
+
+var (
+	postgresTypeAliases = map[string]string{
+		"integer": "int4", "int": "int4", "bigint": "int8", "smallint": "int2",
+		"serial": "int4", "serial4": "int4", "bigserial": "int8", "serial8": "int8", "smallserial": "int2", "serial2": "int2",
+		"real": "float4", "double precision": "float8", "decimal": "numeric", "boolean": "bool",
+		"character varying": "varchar", "char varying": "varchar", "character": "bpchar", "char": "bpchar",
+		"national character": "bpchar", "national char": "bpchar", "national character varying": "varchar", "national char varying": "varchar",
+		"bit varying": "varbit",
+	}
+	// postgresScanTypes scan types of pgx driver
+	postgresScanTypes = map[string]string{
+		"bool": "bool", "int2": "int16", "int4": "int32", "int8": "int64",
+		"float4": "float32", "float8": "float64", "numeric": "float64",
+		"date": "time.Time", "timestamp": "time.Time", "timestamptz": "time.Time", "bytea": "[]uint8",
+	}
+)
+
+func (postgresDialect) name() string { return "postgres" }
+
+func (postgresDialect) foldName(tok token) string {
+	if tok.kind == tokenWord {
+		return strings.ToLower(tok.text)
+	}
+	return tok.text
+}
+
+func (postgresDialect) typeWord(spec *typeSpec, word token) bool { return typeWord(spec, word) }
+
+func (postgresDialect) setType(c *column, spec *typeSpec) {
+	name := strings.Join(spec.words, " ")
+	if strings.Contains(name, "serial") {
+		c.autoIncrement, c.nullable = true, false
+	}
+	if alias, ok := postgresTypeAliases[name]; ok {
+		name = alias
+	}
+	switch name {
+	case "float":
+		name = "float8"
+		if intArg(spec.args, 0, 53) <= 24 {
+			name = "float4"
+		}
+	case "timestamp", "time":
+		if spec.withTimeZone {
+			name += "tz"
+		}
+	}
+
+	c.length, c.precision, c.scale = nil, nil, nil
+	c.dataType, c.columnType = name, name
+	switch name {
+	case "varchar", "bpchar", "bit", "varbit":
+		if len(spec.args) > 0 || name == "bit" || spec.words[0] == "char" || spec.words[0] == "character" {
+			c.length = ptr(intArg(spec.args, 0, 1))
+			c.columnType = fmt.Sprintf("%s(%d)", name, *c.length)
+		}
+	case "numeric":
+		if len(spec.args) > 0 {
+			c.precision, c.scale = ptr(intArg(spec.args, 0, 0)), ptr(intArg(spec.args, 1, 0))
+			c.columnType = fmt.Sprintf("numeric(%d,%d)", *c.precision, *c.scale)
+		}
+	}
+	if spec.array {
+		c.dataType, c.columnType = "_"+name, "_"+name
+	}
+}
+
+func (postgresDialect) defaultValue(expr []token) (string, bool) {
+	for i := 0; i+1 < len(expr); i++ { // remove type cast
+		if expr[i].isPunct(":") && expr[i+1].isPunct(":") {
+			expr = expr[:i]
+			break
+		}
+	}
+	switch {
+	case len(expr) == 0 || isNull(expr):
+		return "", false
+	case len(expr) == 1 && expr[0].kind == tokenString:
+		return expr[0].text, true
+	}
+	return rawText(expr), true
+}
+
+func (postgresDialect) indexName(t *table, idx *index) string {
+	switch {
+	case idx.primaryKey:
+		return t.uniqueName(t.name+"_pkey", "", 1)
+	case idx.constraint:
+		return t.uniqueName(t.name+"_"+strings.Join(idx.columns, "_")+"_key", "", 1)
+	default:
+		return t.uniqueName(t.name+"_"+strings.Join(idx.columns, "_")+"_idx", "", 1)
+	}
+}
+
+func (postgresDialect) indexVisible(*index) bool { return true }
+
+func (postgresDialect) snapshot(t *table, c *column) model.ColumnSnapshot {
+	primaryKey := t.isPrimaryKey(c.name)
+	autoIncrement, defaultValue := c.autoIncrement, c.defaultValue
+	if defaultValue != nil && strings.HasPrefix(*defaultValue, "nextval(") {
+		autoIncrement, defaultValue = true, nil
+	}
+	scanType, ok := postgresScanTypes[c.dataType]
+	if !ok {
+		scanType = "string"
+	}
+	return model.ColumnSnapshot{
+		Name:          c.name,
+		DatabaseType:  c.dataType,
+		ColumnType:    ptr(c.columnType),
+		PrimaryKey:    ptr(primaryKey),
+		AutoIncrement: ptr(autoIncrement),
+		Unique:        ptr(!primaryKey && t.isUnique(c.name, true)),
+		Length:        c.length,
+		Precision:     c.precision,
+		Scale:         c.scale,
+		Nullable:      ptr(c.nullable && !primaryKey),
+		Comment:       c.comment,
+		DefaultValue:  defaultValue,
+		ScanType:      scanType,
+		UseScanType:   true,
+	}
+}
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) name() string { return "sqlite" }
+
+func (sqliteDialect) foldName(tok token) string { return tok.text }
+
+// typeWord sqlite type name can be any words, like UNSIGNED BIG INT
+func (sqliteDialect) typeWord(_ *typeSpec, word token) bool {
+	return !word.is("CONSTRAINT", "PRIMARY", "NOT", "NULL", "UNIQUE", "CHECK", "DEFAULT", "COLLATE", "REFERENCES", "GENERATED", "AS", "AUTOINCREMENT", "ON")
+}
+
+// setType keep type name as written, like gorm sqlite migrator which reads columns from table's DDL
+func (sqliteDialect) setType(c *column, spec *typeSpec) {
+	c.dataType, c.columnType, c.length = spec.name, spec.name, nil
+	if len(spec.args) > 0 {
+		c.columnType += "(" + strings.Join(spec.args, ",") + ")"
+	}
+	if len(spec.args) == 1 {
+		c.length = ptr(intArg(spec.args, 0, 0))
+	}
+}
+
+func (sqliteDialect) defaultValue(expr []token) (string, bool) {
+	if len(expr) == 0 || isNull(expr) {
+		return "", false
+	}
+	return strings.Trim(rawText(expr), `"`), true
+}
+
+func (sqliteDialect) indexName(t *table, idx *index) string {
+	for n := 1; ; n++ {
+		if name := fmt.Sprintf("sqlite_autoindex_%s_%d", t.name, n); !t.hasIndex(name) {
+			return name
+		}
+	}
+}
+
+// indexVisible sqlite indexes created by constraints have no DDL, gorm migrator doesn't report them
+func (sqliteDialect) indexVisible(idx *index) bool { return !idx.constraint }
+
+func (sqliteDialect) snapshot(t *table, c *column) model.ColumnSnapshot {
+	return model.ColumnSnapshot{
+		Name:         c.name,
+		DatabaseType: c.dataType,
+		ColumnType:   ptr(c.columnType),
+		PrimaryKey:   ptr(t.isPrimaryKey(c.name)),
+		Unique:       ptr(t.isUnique(c.name, true)),
+		Length:       c.length,
+		Nullable:     ptr(c.nullable),
+		DefaultValue: c.defaultValue,
+	}
+}
@@ -0,0 +1,242 @@
+package ddl
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF    tokenKind = iota
+	tokenWord             // unquoted identifier or keyword
+	tokenQuoted           // quoted identifier: "name", `name` or [name]
+	tokenString           // string literal: 'text' or $$text$$
+	tokenNumber           // numeric literal
+	tokenPunct            // single punctuation character
+)
+
+type token struct {
+	kind tokenKind
+	text string // unquoted value
+	raw  string // text as written in source
+	line int
+}
+
+// is report whether token is one of keywords, case-insensitive
+func (t token) is(keywords ...string) bool {
+	if t.kind != tokenWord {
+		return false
+	}
+	for _, kw := range keywords {
+		if strings.EqualFold(t.text, kw) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t token) isPunct(p string) bool { return t.kind == tokenPunct && t.text == p }
+
+// isName report whether token can be used as identifier
+func (t token) isName() bool { return t.kind == tokenWord || t.kind == tokenQuoted }
+
+type lexer struct {
+	src     string
+	pos     int
+	line    int
+	dialect string
+}
+
+// tokenize split source into statements, semicolons are not included in result
+func tokenize(dialect, src string) (stmts [][]token, err error) {
+	l := &lexer{src: src, line: 1, dialect: dialect}
+	var stmt []token
+	for {
+		tok, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case tok.kind == tokenEOF:
+			if len(stmt) > 0 {
+				stmts = append(stmts, stmt)
+			}
+			return stmts, nil
+		case tok.isPunct(";"):
+			if len(stmt) > 0 {
+				stmts = append(stmts, stmt)
+			}
+			stmt = nil
+		default:
+			stmt = append(stmt, tok)
+		}
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	if err := l.skipSpaceAndComment(); err != nil {
+		return token{}, err
+	}
+	if l.pos >= len(l.src) {
+		return token{kind: tokenEOF, line: l.line}, nil
+	}
+
+	start, line := l.pos, l.line
+	c := l.src[l.pos]
+	switch {
+	case c == '\'':
+		text, err := l.quoted('\'', l.dialect == "mysql")
+		return token{kind: tokenString, text: text, raw: l.src[start:l.pos], line: line}, err
+	case c == '"' || c == '`':
+		text, err := l.quoted(c, false)
+		return token{kind: tokenQuoted, text: text, raw: l.src[start:l.pos], line: line}, err
+	case c == '[' && l.dialect == "sqlite":
+		end := strings.IndexByte(l.src[l.pos:], ']')
+		if end < 0 {
+			return token{}, fmt.Errorf("line %d: unterminated quoted identifier", line)
+		}
+		l.pos += end + 1
+		return token{kind: tokenQuoted, text: l.src[start+1 : l.pos-1], raw: l.src[start:l.pos], line: line}, nil
+	case c == '$' && l.dialect == "postgres":
+		if tag, ok := l.dollarTag(); ok {
+			end := strings.Index(l.src[l.pos+len(tag):], tag)
+			if end < 0 {
+				return token{}, fmt.Errorf("line %d: unterminated dollar-quoted string", line)
+			}
+			text := l.src[l.pos+len(tag) : l.pos+len(tag)+end]
+			l.line += strings.Count(text, "\n")
+			l.pos += len(tag)*2 + end
+			return token{kind: tokenString, text: text, raw: l.src[start:l.pos], line: line}, nil
+		}
+	case c >= '0' && c <= '9' || c == '.' && l.pos+1 < len(l.src) && isDigit(l.src[l.pos+1]):
+		l.number()
+		return token{kind: tokenNumber, text: l.src[start:l.pos], raw: l.src[start:l.pos], line: line}, nil
+	}
+
+	if r, size := utf8.DecodeRuneInString(l.src[l.pos:]); isWordStart(r) {
+		l.pos += size
+		for l.pos < len(l.src) {
+			r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+			if !isWordPart(r) {
+				break
+			}
+			l.pos += size
+		}
+		return token{kind: tokenWord, text: l.src[start:l.pos], raw: l.src[start:l.pos], line: line}, nil
+	}
+
+	l.pos++
+	return token{kind: tokenPunct, text: string(c), raw: string(c), line: line}, nil
+}
+
+func (l *lexer) skipSpaceAndComment() error {
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '\n':
+			l.line++
+			l.pos++
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f' || c == '\v':
+			l.pos++
+		case strings.HasPrefix(l.src[l.pos:], "--") || c == '#' && l.dialect == "mysql":
+			end := strings.IndexByte(l.src[l.pos:], '\n')
+			if end < 0 {
+				l.pos = len(l.src)
+			} else {
+				l.pos += end
+			}
+		case strings.HasPrefix(l.src[l.pos:], "/*"):
+			end := strings.Index(l.src[l.pos+2:], "*/")
+			if end < 0 {
+				return fmt.Errorf("line %d: unterminated comment", l.line)
+			}
+			l.line += strings.Count(l.src[l.pos:l.pos+2+end], "\n")
+			l.pos += end + 4
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// quoted read text between quote characters, doubled quote is an escaped quote
+func (l *lexer) quoted(quote byte, backslashEscape bool) (string, error) {
+	line := l.line
+	var sb strings.Builder
+	for l.pos++; l.pos < len(l.src); l.pos++ {
+		c := l.src[l.pos]
+		switch {
+		case c == quote && l.pos+1 < len(l.src) && l.src[l.pos+1] == quote:
+			sb.WriteByte(quote)
+			l.pos++
+		case c == quote:
+			l.pos++
+			return sb.String(), nil
+		case c == '\\' && backslashEscape && l.pos+1 < len(l.src):
+			l.pos++
+			sb.WriteByte(unescape(l.src[l.pos]))
+		default:
+			if c == '\n' {
+				l.line++
+			}
+			sb.WriteByte(c)
+		}
+	}
+	return "", fmt.Errorf("line %d: unterminated quoted text", line)
+}
+
+// dollarTag read tag of postgres dollar-quoted string, like $$ or $body$
+func (l *lexer) dollarTag() (string, bool) {
+	for i := l.pos + 1; i < len(l.src); i++ {
+		r, _ := utf8.DecodeRuneInString(l.src[i:])
+		switch {
+		case r == '$':
+			return l.src[l.pos : i+1], true
+		case !isWordPart(r) || r >= '0' && r <= '9' && i == l.pos+1:
+			return "", false
+		}
+	}
+	return "", false
+}
+
+func (l *lexer) number() {
+	for l.pos < len(l.src) && (isDigit(l.src[l.pos]) || l.src[l.pos] == '.') {
+		l.pos++
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		i := l.pos + 1
+		if i < len(l.src) && (l.src[i] == '+' || l.src[i] == '-') {
+			i++
+		}
+		if i < len(l.src) && isDigit(l.src[i]) {
+			for l.pos = i; l.pos < len(l.src) && isDigit(l.src[l.pos]); l.pos++ {
+			}
+		}
+	}
+}
+
+func unescape(c byte) byte {
+	switch c {
+	case 'n':
+		return '\n'
+	case 't':
+		return '\t'
+	case 'r':
+		return '\r'
+	case '0':
+		return 0
+	default:
+		return c
+	}
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func isWordStart(r rune) bool { return r == '_' || unicode.IsLetter(r) }
+
+func isWordPart(r rune) bool {
+	return r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
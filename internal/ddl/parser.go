@@ -0,0 +1,1051 @@
+package ddl
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gen/internal/model"
+)
+
+type parser struct {
+	d    dialect
+	toks []token
+	pos  int
+}
+
+func newParser(d dialect, toks []token) *parser { return &parser{d: d, toks: toks} }
+
+func (p *parser) peek() token { return p.peekAt(0) }
+
+func (p *parser) peekAt(n int) token {
+	if p.pos+n < len(p.toks) {
+		return p.toks[p.pos+n]
+	}
+	line := 0
+	if len(p.toks) > 0 {
+		line = p.toks[len(p.toks)-1].line
+	}
+	return token{kind: tokenEOF, line: line}
+}
+
+func (p *parser) next() token {
+	tok := p.peek()
+	if p.pos < len(p.toks) {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) done() bool { return p.pos >= len(p.toks) }
+
+// rest return tokens not consumed yet
+func (p *parser) rest() []token {
+	toks := p.toks[p.pos:]
+	p.pos = len(p.toks)
+	return toks
+}
+
+// accept consume keywords when all of them are following in order
+func (p *parser) accept(keywords ...string) bool {
+	for i, kw := range keywords {
+		if !p.peekAt(i).is(kw) {
+			return false
+		}
+	}
+	p.pos += len(keywords)
+	return true
+}
+
+func (p *parser) acceptPunct(s string) bool {
+	if p.peek().isPunct(s) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", p.peek().line, fmt.Sprintf(format, args...))
+}
+
+// name read a name which may be qualified by schema, return the last part
+func (p *parser) name() (string, error) {
+	parts, err := p.nameParts()
+	if err != nil {
+		return "", err
+	}
+	return parts[len(parts)-1], nil
+}
+
+func (p *parser) nameParts() (parts []string, err error) {
+	for {
+		tok := p.peek()
+		if !tok.isName() {
+			return nil, p.errorf("expect name, got %q", tok.raw)
+		}
+		p.next()
+		parts = append(parts, p.d.foldName(tok))
+		if !p.acceptPunct(".") {
+			return parts, nil
+		}
+	}
+}
+
+// group consume a parenthesized group, return tokens inside
+func (p *parser) group() ([]token, error) {
+	if !p.peek().isPunct("(") {
+		return nil, p.errorf("expect '(', got %q", p.peek().raw)
+	}
+	start, depth := p.pos+1, 0
+	for !p.done() {
+		tok := p.next()
+		switch {
+		case tok.isPunct("("):
+			depth++
+		case tok.isPunct(")"):
+			if depth--; depth == 0 {
+				return p.toks[start : p.pos-1], nil
+			}
+		}
+	}
+	return nil, p.errorf("unbalanced parentheses")
+}
+
+// skip consume next token, or the whole group if it is a parenthesized group
+func (p *parser) skip() {
+	if p.peek().isPunct("(") {
+		_, _ = p.group()
+		return
+	}
+	p.next()
+}
+
+// splitList split tokens by commas not in parentheses
+func splitList(toks []token) (list [][]token) {
+	depth, start := 0, 0
+	for i, tok := range toks {
+		switch {
+		case tok.isPunct("("):
+			depth++
+		case tok.isPunct(")"):
+			depth--
+		case tok.isPunct(",") && depth == 0:
+			list = append(list, toks[start:i])
+			start = i + 1
+		}
+	}
+	if start < len(toks) {
+		list = append(list, toks[start:])
+	}
+	return list
+}
+
+// rawText join tokens as written, tokens are separated by space only when needed
+func rawText(toks []token) string {
+	var sb strings.Builder
+	for i, tok := range toks {
+		if i > 0 && tok.kind != tokenPunct && toks[i-1].kind != tokenPunct {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(tok.raw)
+	}
+	return sb.String()
+}
+
+func quoteString(s string) string { return "'" + strings.ReplaceAll(s, "'", "''") + "'" }
+
+// stringLiteral read a string literal, mysql accept double-quoted text as string
+func (p *parser) stringLiteral() (string, error) {
+	tok := p.peek()
+	if tok.kind == tokenString || tok.kind == tokenQuoted && tok.raw[0] == '"' && p.d.name() == "mysql" {
+		p.next()
+		return tok.text, nil
+	}
+	return "", p.errorf("expect string, got %q", tok.raw)
+}
+
+func (s *Schema) exec(p *parser) error {
+	switch {
+	case p.accept("CREATE"):
+		return s.create(p)
+	case p.accept("ALTER", "TABLE"):
+		return s.alterTable(p)
+	case p.accept("DROP", "TABLE"):
+		return s.dropTable(p)
+	case p.accept("DROP", "INDEX"):
+		return s.dropIndex(p)
+	case p.accept("COMMENT", "ON"):
+		return s.commentOn(p)
+	case p.accept("RENAME", "TABLE"):
+		return s.renameTable(p)
+	case p.accept("ALTER", "TYPE"):
+		return s.alterType(p)
+	case p.accept("DROP", "TYPE"):
+		return s.dropType(p)
+	}
+	return nil
+}
+
+func (s *Schema) create(p *parser) error {
+	p.accept("OR", "REPLACE")
+	for p.peek().is("TEMPORARY", "TEMP", "GLOBAL", "LOCAL", "UNLOGGED") {
+		p.next()
+	}
+	if p.accept("TABLE") {
+		return s.createTable(p)
+	}
+
+	if p.accept("TYPE") {
+		return s.createType(p)
+	}
+
+	unique := p.accept("UNIQUE")
+	if !unique && p.peek().is("FULLTEXT", "SPATIAL") {
+		p.next()
+	}
+	if p.accept("INDEX") {
+		return s.createIndex(p, unique)
+	}
+	return nil
+}
+
+func (s *Schema) createTable(p *parser) error {
+	ifNotExists := p.accept("IF", "NOT", "EXISTS")
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if _, ok := s.tables[name]; ok && ifNotExists {
+		return nil
+	}
+
+	t := &table{name: name}
+	if p.accept("LIKE") {
+		like, err := p.name()
+		if err != nil {
+			return err
+		}
+		if src, ok := s.tables[like]; ok {
+			t = src.copy(name)
+		}
+		s.tables[name] = t
+		return nil
+	}
+	if !p.peek().isPunct("(") { // CREATE TABLE ... AS SELECT or PARTITION OF, columns are unknown
+		return nil
+	}
+
+	defs, err := p.group()
+	if err != nil {
+		return err
+	}
+	for _, def := range splitList(defs) {
+		if err := s.tableElement(t, newParser(p.d, def)); err != nil {
+			return err
+		}
+	}
+	for !p.done() { // table options
+		if !p.accept("COMMENT") {
+			p.skip()
+			continue
+		}
+		p.acceptPunct("=")
+		comment, err := p.stringLiteral()
+		if err != nil {
+			return err
+		}
+		t.comment = &comment
+	}
+	s.tables[name] = t
+	return nil
+}
+
+// tableElement parse column definition or table constraint
+func (s *Schema) tableElement(t *table, p *parser) error {
+	if p.peek().is("LIKE") && p.d.name() == "postgres" {
+		p.next()
+		like, err := p.name()
+		if err != nil {
+			return err
+		}
+		if src, ok := s.tables[like]; ok {
+			for _, c := range src.copy(t.name).columns {
+				t.addColumn(c, false, "")
+			}
+		}
+		return nil
+	}
+	if !p.peek().is("CONSTRAINT") && !p.isConstraint() {
+		return s.addColumn(t, p)
+	}
+
+	constraintName := ""
+	if p.accept("CONSTRAINT") && !p.isConstraint() {
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		constraintName = name
+	}
+
+	idx := &index{name: constraintName, constraint: true}
+	switch {
+	case p.accept("PRIMARY", "KEY"):
+		idx.primaryKey, idx.unique = true, true
+	case p.accept("UNIQUE"):
+		idx.unique = true
+		if p.accept("KEY") || p.accept("INDEX") || p.d.name() == "mysql" {
+			if err := p.indexName(idx); err != nil {
+				return err
+			}
+		}
+	case p.peek().is("KEY", "INDEX", "FULLTEXT", "SPATIAL"): // mysql only, see isConstraint
+		p.next()
+		p.accept("KEY")
+		p.accept("INDEX")
+		idx.constraint = false
+		if err := p.indexName(idx); err != nil {
+			return err
+		}
+	case p.accept("CHECK"):
+		ch, err := p.enumCheck(constraintName)
+		if err != nil || ch == nil {
+			return err
+		}
+		if column, ok := t.columnName(ch.column); ok {
+			ch.column = column
+			t.addCheck(ch)
+		}
+		return nil
+	default: // FOREIGN KEY and EXCLUDE constraints
+		return nil
+	}
+	if p.d.name() == "mysql" && idx.primaryKey {
+		idx.name = "" // mysql primary key is always named PRIMARY
+	}
+
+	columns, ok, err := p.indexColumns()
+	if err != nil || !ok {
+		return err
+	}
+	idx.columns = columns
+	t.addIndex(p.d, idx)
+	return nil
+}
+
+// isConstraint report whether next token starts a table constraint
+func (p *parser) isConstraint() bool {
+	tok := p.peek()
+	switch {
+	case tok.is("PRIMARY", "UNIQUE", "FOREIGN", "CHECK", "EXCLUDE"):
+		return true
+	case tok.is("KEY", "INDEX", "FULLTEXT", "SPATIAL"):
+		return p.d.name() == "mysql"
+	}
+	return false
+}
+
+// enumCheck read parenthesized CHECK expression, return nil if it does not limit a column to string values
+func (p *parser) enumCheck(name string) (*check, error) {
+	expr, err := p.group()
+	if err != nil {
+		return nil, err
+	}
+	column, values, ok := model.ParseEnumCheck(rawText(expr))
+	if !ok {
+		return nil, nil
+	}
+	return &check{name: name, column: column, values: values}, nil
+}
+
+// indexName read optional index name and index type before index columns
+func (p *parser) indexName(idx *index) error {
+	if p.peek().isName() && !p.peek().is("USING") {
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		idx.name = name
+	}
+	if p.accept("USING") {
+		p.next()
+	}
+	return nil
+}
+
+// indexColumns read columns of index, ok is false when index contains expressions
+func (p *parser) indexColumns() (columns []string, ok bool, err error) {
+	toks, err := p.group()
+	if err != nil {
+		return nil, false, err
+	}
+	for _, part := range splitList(toks) {
+		if len(part) == 0 || !part[0].isName() {
+			return nil, false, nil
+		}
+		if len(part) > 1 && part[1].isPunct("(") && !(p.d.name() == "mysql" && len(part) > 2 && part[2].kind == tokenNumber) { // function, but not mysql prefix length like name(10)
+			return nil, false, nil
+		}
+		columns = append(columns, p.d.foldName(part[0]))
+	}
+	return columns, len(columns) > 0, nil
+}
+
+// addColumn parse column definition and add it to table
+func (s *Schema) addColumn(t *table, p *parser) error {
+	c, first, after, err := s.columnDef(t, p)
+	if err != nil {
+		return err
+	}
+	t.addColumn(c, first, after)
+	return nil
+}
+
+// columnDef parse column definition, inline PRIMARY KEY and UNIQUE constraints are added to table as indexes.
+// first and after are the position of column given by mysql FIRST or AFTER clause
+func (s *Schema) columnDef(t *table, p *parser) (c *column, first bool, after string, err error) {
+	nameTok := p.next()
+	if !nameTok.isName() {
+		return nil, false, "", p.errorf("expect column name, got %q", nameTok.raw)
+	}
+	c = &column{name: p.d.foldName(nameTok), nullable: true}
+
+	spec, err := p.typeSpec()
+	if err != nil {
+		return nil, false, "", err
+	}
+	p.d.setType(c, spec)
+
+	var indexes []*index
+	var checks []*check
+	constraintName := ""
+	for !p.done() {
+		switch {
+		case p.accept("NOT", "NULL"):
+			c.nullable = false
+		case p.accept("NULL"):
+			c.nullable = true
+		case p.accept("DEFAULT"):
+			expr, err := p.expression()
+			if err != nil {
+				return nil, false, "", err
+			}
+			c.defaultValue = nil
+			if v, ok := p.d.defaultValue(expr); ok {
+				c.defaultValue = &v
+			}
+		case p.accept("PRIMARY", "KEY"), p.d.name() == "mysql" && p.accept("KEY"):
+			indexes = append(indexes, &index{name: constraintName, columns: []string{c.name}, primaryKey: true, unique: true, constraint: true})
+			constraintName = ""
+		case p.accept("UNIQUE"):
+			p.accept("KEY")
+			indexes = append(indexes, &index{name: constraintName, columns: []string{c.name}, unique: true, constraint: true})
+			constraintName = ""
+		case p.accept("AUTO_INCREMENT"), p.accept("AUTOINCREMENT"), p.accept("IDENTITY"):
+			c.autoIncrement = true
+		case p.accept("GENERATED"):
+			if !p.accept("ALWAYS") {
+				p.accept("BY", "DEFAULT")
+			}
+			p.accept("AS")
+			if p.accept("IDENTITY") {
+				c.autoIncrement = true
+				c.nullable = false
+			}
+			if p.peek().isPunct("(") {
+				p.skip()
+			}
+		case p.accept("COMMENT"):
+			comment, err := p.stringLiteral()
+			if err != nil {
+				return nil, false, "", err
+			}
+			c.comment = &comment
+		case p.accept("CONSTRAINT"):
+			if p.peek().isName() && !p.isConstraint() && !p.peek().is("NOT", "NULL", "DEFAULT", "REFERENCES") {
+				constraintName = p.d.foldName(p.next())
+			}
+		case p.accept("COLLATE"), p.accept("CHARACTER", "SET"), p.accept("CHARSET"):
+			if _, err := p.name(); err != nil {
+				return nil, false, "", err
+			}
+		case p.accept("REFERENCES"):
+			if _, err := p.name(); err != nil {
+				return nil, false, "", err
+			}
+		case p.accept("CHECK"):
+			ch, err := p.enumCheck(constraintName)
+			if err != nil {
+				return nil, false, "", err
+			}
+			if ch != nil && strings.EqualFold(ch.column, c.name) {
+				ch.column = c.name
+				checks = append(checks, ch)
+			}
+			constraintName = ""
+		case p.d.name() == "mysql" && p.accept("FIRST"):
+			first = true
+		case p.d.name() == "mysql" && p.accept("AFTER"):
+			if after, err = p.name(); err != nil {
+				return nil, false, "", err
+			}
+		default: // ON UPDATE, ON DELETE, STORED and other options not affecting model
+			p.skip()
+		}
+	}
+
+	for _, idx := range indexes {
+		if p.d.name() == "mysql" && idx.primaryKey {
+			idx.name = ""
+		}
+		t.addIndex(p.d, idx)
+	}
+	for _, ch := range checks {
+		t.addCheck(ch)
+	}
+	return c, first, after, nil
+}
+
+// typeSpec read column type, like varchar(255), int unsigned, timestamp with time zone, text[]
+func (p *parser) typeSpec() (*typeSpec, error) {
+	spec := &typeSpec{}
+	if !p.peek().isName() || p.isColumnConstraint() { // sqlite column may have no type
+		return spec, nil
+	}
+	parts, err := p.nameParts()
+	if err != nil {
+		return nil, err
+	}
+	spec.words = []string{strings.ToLower(parts[len(parts)-1])}
+	spec.name = parts[len(parts)-1]
+
+	for !p.done() {
+		tok := p.peek()
+		switch {
+		case tok.isPunct("(") && spec.args == nil:
+			args, err := p.group()
+			if err != nil {
+				return nil, err
+			}
+			spec.args = []string{}
+			for _, arg := range splitList(args) {
+				if len(arg) == 1 && arg[0].kind == tokenString {
+					spec.args = append(spec.args, quoteString(arg[0].text))
+				} else {
+					spec.args = append(spec.args, rawText(arg))
+				}
+			}
+		case tok.isPunct("["):
+			for p.next(); !p.done() && !p.next().isPunct("]"); {
+			}
+			spec.array = true
+		case tok.is("ARRAY") && p.d.name() == "postgres":
+			p.next()
+			spec.array = true
+		case tok.is("UNSIGNED") && p.d.name() == "mysql":
+			p.next()
+			spec.unsigned = true
+		case tok.is("SIGNED") && p.d.name() == "mysql":
+			p.next()
+		case tok.is("ZEROFILL") && p.d.name() == "mysql":
+			p.next()
+			spec.unsigned, spec.zerofill = true, true
+		case p.accept("WITH", "TIME", "ZONE"):
+			spec.withTimeZone = true
+		case p.accept("WITHOUT", "TIME", "ZONE"):
+		case tok.kind == tokenWord && spec.args == nil && p.d.typeWord(spec, tok):
+			p.next()
+			spec.words = append(spec.words, strings.ToLower(tok.text))
+			spec.name += " " + tok.text
+		default:
+			return spec, nil
+		}
+	}
+	return spec, nil
+}
+
+// isColumnConstraint report whether next token starts a column constraint
+func (p *parser) isColumnConstraint() bool {
+	return p.peek().is("CONSTRAINT", "PRIMARY", "NOT", "NULL", "UNIQUE", "CHECK", "DEFAULT", "COLLATE", "REFERENCES", "GENERATED", "AS", "AUTOINCREMENT")
+}
+
+// expression read a simple expression: literal, function call, parenthesized expression, with optional type casts
+func (p *parser) expression() (expr []token, err error) {
+	start := p.pos
+	if p.peek().isPunct("-") || p.peek().isPunct("+") {
+		p.next()
+	}
+	switch tok := p.next(); {
+	case tok.isPunct("("):
+		p.pos--
+		if _, err := p.group(); err != nil {
+			return nil, err
+		}
+	case tok.kind == tokenWord:
+		if p.peek().kind == tokenString { // typed literal, like b'01', DATE '2000-01-01'
+			p.next()
+		} else if p.peek().isPunct("(") {
+			if _, err := p.group(); err != nil {
+				return nil, err
+			}
+		}
+	case tok.kind == tokenEOF:
+		return nil, p.errorf("expect expression")
+	}
+	for p.peek().isPunct(":") && p.peekAt(1).isPunct(":") { // postgres type cast, like 'a'::character varying
+		p.pos += 2
+		if _, err := p.typeSpec(); err != nil {
+			return nil, err
+		}
+	}
+	return p.toks[start:p.pos], nil
+}
+
+func (s *Schema) createIndex(p *parser, unique bool) error {
+	p.accept("CONCURRENTLY")
+	p.accept("IF", "NOT", "EXISTS")
+
+	idx := &index{unique: unique}
+	if !p.peek().is("ON") {
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		idx.name = name
+	}
+	if p.accept("USING") {
+		p.next()
+	}
+	if !p.accept("ON") {
+		return p.errorf("expect ON, got %q", p.peek().raw)
+	}
+	p.accept("ONLY")
+	tableName, err := p.name()
+	if err != nil {
+		return err
+	}
+	if p.accept("USING") {
+		p.next()
+	}
+	t, ok := s.tables[tableName]
+	if !ok {
+		return nil
+	}
+
+	columns, ok, err := p.indexColumns()
+	if err != nil || !ok {
+		return err
+	}
+	idx.columns = columns
+	t.addIndex(p.d, idx)
+	return nil
+}
+
+func (s *Schema) alterTable(p *parser) error {
+	p.accept("IF", "EXISTS")
+	p.accept("ONLY")
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	t, ok := s.tables[name]
+	if !ok {
+		return nil
+	}
+	for _, action := range splitList(p.rest()) {
+		if err := s.alterAction(t, newParser(p.d, action)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Schema) alterAction(t *table, p *parser) error {
+	switch {
+	case p.accept("ADD"):
+		if p.peek().is("CONSTRAINT") || p.isConstraint() {
+			return s.tableElement(t, p)
+		}
+		p.accept("COLUMN")
+		p.accept("IF", "NOT", "EXISTS")
+		if !p.peek().isPunct("(") {
+			return s.addColumn(t, p)
+		}
+		defs, err := p.group() // mysql add multiple columns: ADD (a int, b int)
+		if err != nil {
+			return err
+		}
+		for _, def := range splitList(defs) {
+			if err := s.addColumn(t, newParser(p.d, def)); err != nil {
+				return err
+			}
+		}
+	case p.accept("DROP"):
+		return s.dropAction(t, p)
+	case p.accept("MODIFY"):
+		p.accept("COLUMN")
+		if !p.peek().isName() {
+			return nil
+		}
+		return s.replaceColumn(t, p, p.d.foldName(p.peek()))
+	case p.accept("CHANGE"):
+		p.accept("COLUMN")
+		oldName, err := p.name()
+		if err != nil {
+			return err
+		}
+		return s.replaceColumn(t, p, oldName)
+	case p.accept("ALTER"):
+		p.accept("COLUMN")
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		if _, c := t.column(name); c != nil {
+			return s.alterColumn(t, c, p)
+		}
+	case p.accept("RENAME"):
+		return s.renameAction(t, p)
+	case p.accept("COMMENT"):
+		p.acceptPunct("=")
+		comment, err := p.stringLiteral()
+		if err != nil {
+			return err
+		}
+		t.comment = &comment
+	}
+	return nil
+}
+
+func (s *Schema) replaceColumn(t *table, p *parser, oldName string) error {
+	c, first, after, err := s.columnDef(t, p)
+	if err != nil {
+		return err
+	}
+	t.replaceColumn(oldName, c, first, after)
+	return nil
+}
+
+func (s *Schema) dropAction(t *table, p *parser) error {
+	switch {
+	case p.accept("PRIMARY", "KEY"):
+		t.dropPrimaryKey()
+	case p.d.name() == "mysql" && (p.accept("INDEX") || p.accept("KEY")), p.accept("CONSTRAINT"):
+		p.accept("IF", "EXISTS")
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		t.dropIndex(name)
+		t.dropCheck(name)
+	case p.accept("CHECK"): // mysql
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		t.dropCheck(name)
+	case p.peek().is("FOREIGN"):
+	default:
+		p.accept("COLUMN")
+		p.accept("IF", "EXISTS")
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		t.dropColumn(name)
+	}
+	return nil
+}
+
+// alterColumn apply postgres ALTER COLUMN actions, and mysql SET DEFAULT / DROP DEFAULT
+func (s *Schema) alterColumn(t *table, c *column, p *parser) error {
+	switch {
+	case p.accept("SET", "NOT", "NULL"):
+		c.nullable = false
+	case p.accept("DROP", "NOT", "NULL"):
+		c.nullable = true
+	case p.accept("SET", "DEFAULT"):
+		expr, err := p.expression()
+		if err != nil {
+			return err
+		}
+		c.defaultValue = nil
+		if v, ok := p.d.defaultValue(expr); ok {
+			c.defaultValue = &v
+		}
+	case p.accept("DROP", "DEFAULT"):
+		c.defaultValue = nil
+	case p.accept("DROP", "IDENTITY"):
+		c.autoIncrement = false
+	case p.accept("ADD", "GENERATED"):
+		c.autoIncrement = true
+	case p.accept("SET", "DATA", "TYPE"), p.accept("TYPE"):
+		spec, err := p.typeSpec()
+		if err != nil {
+			return err
+		}
+		autoIncrement := c.autoIncrement
+		p.d.setType(c, spec)
+		c.autoIncrement = c.autoIncrement || autoIncrement
+	}
+	return nil
+}
+
+func (s *Schema) renameAction(t *table, p *parser) error {
+	switch {
+	case p.accept("TO"), p.accept("AS"):
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		s.rename(t.name, name)
+	case p.d.name() == "mysql" && (p.accept("INDEX") || p.accept("KEY")), p.accept("CONSTRAINT"):
+		oldName, newName, err := p.renamePair()
+		if err != nil {
+			return err
+		}
+		if _, idx := t.index(oldName); idx != nil {
+			idx.name = newName
+		}
+	default:
+		p.accept("COLUMN")
+		oldName, newName, err := p.renamePair()
+		if err != nil {
+			return err
+		}
+		if _, c := t.column(oldName); c != nil {
+			c.name = newName
+			t.renameColumnRef(oldName, newName)
+		}
+	}
+	return nil
+}
+
+// renamePair read: old_name TO new_name
+func (p *parser) renamePair() (oldName, newName string, err error) {
+	if oldName, err = p.name(); err != nil {
+		return "", "", err
+	}
+	if !p.accept("TO") {
+		return "", "", p.errorf("expect TO, got %q", p.peek().raw)
+	}
+	newName, err = p.name()
+	return oldName, newName, err
+}
+
+func (s *Schema) renameTable(p *parser) error {
+	for _, part := range splitList(p.rest()) {
+		oldName, newName, err := newParser(p.d, part).renamePair()
+		if err != nil {
+			return err
+		}
+		s.rename(oldName, newName)
+	}
+	return nil
+}
+
+func (s *Schema) rename(oldName, newName string) {
+	if t, ok := s.tables[oldName]; ok {
+		delete(s.tables, oldName)
+		t.name = newName
+		s.tables[newName] = t
+	}
+}
+
+func (s *Schema) dropTable(p *parser) error {
+	p.accept("IF", "EXISTS")
+	for _, part := range splitList(p.rest()) {
+		name, err := newParser(p.d, part).name()
+		if err != nil {
+			return err
+		}
+		delete(s.tables, name)
+	}
+	return nil
+}
+
+func (s *Schema) dropIndex(p *parser) error {
+	p.accept("CONCURRENTLY")
+	p.accept("IF", "EXISTS")
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if p.accept("ON") {
+		tableName, err := p.name()
+		if err != nil {
+			return err
+		}
+		if t, ok := s.tables[tableName]; ok {
+			t.dropIndex(name)
+		}
+		return nil
+	}
+	for _, tableName := range s.Tables() {
+		if s.tables[tableName].dropIndex(name) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// createType read postgres enum type: CREATE TYPE name AS ENUM ('a', 'b'), other types are ignored
+func (s *Schema) createType(p *parser) error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if !p.accept("AS", "ENUM") {
+		return nil
+	}
+	list, err := p.group()
+	if err != nil {
+		return err
+	}
+	values := []string{}
+	for _, value := range splitList(list) {
+		if len(value) != 1 || value[0].kind != tokenString {
+			return p.errorf("expect enum value, got %q", rawText(value))
+		}
+		values = append(values, value[0].text)
+	}
+	s.enums[name] = values
+	return nil
+}
+
+// alterType apply ALTER TYPE name ADD VALUE [BEFORE | AFTER], RENAME VALUE and RENAME TO of postgres enum type
+func (s *Schema) alterType(p *parser) error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	values, ok := s.enums[name]
+	if !ok {
+		return nil
+	}
+	switch {
+	case p.accept("ADD", "VALUE"):
+		p.accept("IF", "NOT", "EXISTS")
+		value, err := p.stringLiteral()
+		if err != nil || contains(values, value) {
+			return err
+		}
+		pos := len(values)
+		if before, after := p.accept("BEFORE"), p.accept("AFTER"); before || after {
+			neighbor, err := p.stringLiteral()
+			if err != nil {
+				return err
+			}
+			for i, v := range values {
+				if v == neighbor {
+					if pos = i; after {
+						pos++
+					}
+				}
+			}
+		}
+		s.enums[name] = append(values[:pos:pos], append([]string{value}, values[pos:]...)...)
+	case p.accept("RENAME", "VALUE"):
+		oldValue, err := p.stringLiteral()
+		if err != nil {
+			return err
+		}
+		if !p.accept("TO") {
+			return p.errorf("expect TO, got %q", p.peek().raw)
+		}
+		newValue, err := p.stringLiteral()
+		if err != nil {
+			return err
+		}
+		for i, v := range values {
+			if v == oldValue {
+				values[i] = newValue
+			}
+		}
+	case p.accept("RENAME", "TO"):
+		newName, err := p.name()
+		if err != nil {
+			return err
+		}
+		delete(s.enums, name)
+		s.enums[newName] = values
+		for _, t := range s.tables {
+			for _, c := range t.columns {
+				if c.dataType == name {
+					c.dataType, c.columnType = newName, newName
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Schema) dropType(p *parser) error {
+	p.accept("IF", "EXISTS")
+	for _, part := range splitList(p.rest()) {
+		name, err := newParser(p.d, part).name()
+		if err != nil {
+			return err
+		}
+		delete(s.enums, name)
+	}
+	return nil
+}
+
+// commentOn apply postgres COMMENT ON TABLE / COLUMN statements
+func (s *Schema) commentOn(p *parser) error {
+	isColumn := p.accept("COLUMN")
+	if !isColumn && !p.accept("TABLE") {
+		return nil
+	}
+	parts, err := p.nameParts()
+	if err != nil {
+		return err
+	}
+	if !p.accept("IS") {
+		return p.errorf("expect IS, got %q", p.peek().raw)
+	}
+	var comment *string
+	if !p.accept("NULL") {
+		text, err := p.stringLiteral()
+		if err != nil {
+			return err
+		}
+		comment = &text
+	}
+
+	if !isColumn {
+		if t, ok := s.tables[parts[len(parts)-1]]; ok {
+			t.comment = comment
+		}
+		return nil
+	}
+	if len(parts) < 2 {
+		return p.errorf("expect table.column")
+	}
+	if t, ok := s.tables[parts[len(parts)-2]]; ok {
+		if _, c := t.column(parts[len(parts)-1]); c != nil {
+			c.comment = comment
+		}
+	}
+	return nil
+}
+
+// copy return a deep copy of table named name
+func (t *table) copy(name string) *table {
+	cp := &table{name: name, comment: t.comment}
+	for _, c := range t.columns {
+		c := *c
+		cp.columns = append(cp.columns, &c)
+	}
+	for _, idx := range t.indexes {
+		idx := *idx
+		idx.columns = append([]string(nil), idx.columns...)
+		cp.indexes = append(cp.indexes, &idx)
+	}
+	for _, ch := range t.checks {
+		ch := *ch
+		cp.checks = append(cp.checks, &ch)
+	}
+	return cp
+}
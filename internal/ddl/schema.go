@@ -0,0 +1,361 @@
+// Package ddl read table schema from SQL DDL statements, so models can be generated without a database connection
+package ddl
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/migrator"
+
+	"gorm.io/gen/internal/model"
+)
+
+// Schema tables defined by DDL statements
+type Schema struct {
+	dialect dialect
+	tables  map[string]*table
+	enums   map[string][]string // values of postgres enum types
+}
+
+var _ model.SchemaSource = (*Schema)(nil)
+
+// NewSchema create an empty schema, supported dialects: mysql, postgres and sqlite
+func NewSchema(dialectName string) (*Schema, error) {
+	d, ok := dialects[dialectName]
+	if !ok {
+		return nil, fmt.Errorf("unsupported DDL dialect %q (support mysql || postgres || sqlite for now)", dialectName)
+	}
+	return &Schema{dialect: d, tables: make(map[string]*table), enums: make(map[string][]string)}, nil
+}
+
+// Parse apply DDL statements to schema in order, statements not about tables, columns, indexes or comments are ignored
+func (s *Schema) Parse(src string) error {
+	stmts, err := tokenize(s.dialect.name(), src)
+	if err != nil {
+		return err
+	}
+	for _, stmt := range stmts {
+		if err := s.exec(newParser(s.dialect, stmt)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Dialect return name of dialect
+func (s *Schema) Dialect() string { return s.dialect.name() }
+
+// Tables return names of tables in alphabetical order
+func (s *Schema) Tables() []string {
+	names := make([]string, 0, len(s.tables))
+	for name := range s.tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TableSchema return columns and comment of table, columns are described as the database introspection reports them
+func (s *Schema) TableSchema(tableName string, withIndex bool) (columns []*model.Column, comment string, err error) {
+	t, ok := s.tables[tableName]
+	if !ok {
+		return nil, "", fmt.Errorf("table %q is not defined in DDL", tableName)
+	}
+
+	columns = make([]*model.Column, 0, len(t.columns))
+	for _, c := range t.columns {
+		col, ok := s.dialect.snapshot(t, c).Restore(tableName)
+		if !ok {
+			return nil, "", fmt.Errorf("restore column %s.%s fail", tableName, c.name)
+		}
+		if values, ok := s.enums[c.dataType]; ok {
+			col.Enum = &model.Enum{TypeName: c.dataType, Values: values}
+		} else if ch := t.check(c.name); ch != nil {
+			col.Enum = &model.Enum{Values: ch.values}
+		}
+		columns = append(columns, col)
+	}
+
+	if withIndex {
+		indexes := make([]gorm.Index, 0, len(t.indexes))
+		for _, idx := range t.indexes {
+			if !s.dialect.indexVisible(idx) {
+				continue
+			}
+			indexes = append(indexes, &migrator.Index{
+				TableName:       tableName,
+				NameValue:       idx.name,
+				ColumnList:      idx.columns,
+				PrimaryKeyValue: sql.NullBool{Bool: idx.primaryKey, Valid: true},
+				UniqueValue:     sql.NullBool{Bool: idx.unique, Valid: true},
+			})
+		}
+		im := model.GroupByColumn(indexes)
+		for _, c := range columns {
+			c.Indexes = im[c.Name()]
+		}
+	}
+
+	if t.comment != nil {
+		comment = *t.comment
+	}
+	return columns, comment, nil
+}
+
+type table struct {
+	name    string
+	comment *string
+	columns []*column
+	indexes []*index
+	checks  []*check
+}
+
+type column struct {
+	name          string
+	dataType      string // type name reported by database, like varchar, int4
+	columnType    string // full column type, like varchar(255), int unsigned
+	length        *int64
+	precision     *int64
+	scale         *int64
+	nullable      bool
+	autoIncrement bool
+	defaultValue  *string
+	comment       *string
+}
+
+type index struct {
+	name       string
+	columns    []string
+	primaryKey bool
+	unique     bool
+	constraint bool // created by PRIMARY KEY or UNIQUE constraint instead of index definition
+}
+
+// check CHECK constraint limiting column to enum values, other CHECK constraints are ignored
+type check struct {
+	name   string
+	column string
+	values []string
+}
+
+func (t *table) column(name string) (int, *column) {
+	for i, c := range t.columns {
+		if c.name == name {
+			return i, c
+		}
+	}
+	return -1, nil
+}
+
+// addColumn add column to the end, or at position given by first and after
+func (t *table) addColumn(c *column, first bool, after string) {
+	if i, _ := t.column(c.name); i >= 0 {
+		return
+	}
+	t.columns = append(t.columns, c)
+	t.moveColumn(len(t.columns)-1, first, after)
+}
+
+// replaceColumn replace column in place, or move it to position given by first and after
+func (t *table) replaceColumn(oldName string, c *column, first bool, after string) {
+	i, _ := t.column(oldName)
+	if i < 0 {
+		return
+	}
+	t.columns[i] = c
+	t.renameColumnRef(oldName, c.name)
+	t.moveColumn(i, first, after)
+}
+
+func (t *table) moveColumn(i int, first bool, after string) {
+	if !first && after == "" {
+		return
+	}
+	c := t.columns[i]
+	t.columns = append(t.columns[:i], t.columns[i+1:]...)
+	pos := 0
+	if !first {
+		if pos, _ = t.column(after); pos < 0 {
+			pos = len(t.columns)
+		} else {
+			pos++
+		}
+	}
+	t.columns = append(t.columns[:pos], append([]*column{c}, t.columns[pos:]...)...)
+}
+
+func (t *table) dropColumn(name string) {
+	i, _ := t.column(name)
+	if i < 0 {
+		return
+	}
+	t.columns = append(t.columns[:i], t.columns[i+1:]...)
+
+	indexes := t.indexes[:0]
+	for _, idx := range t.indexes {
+		cols := idx.columns[:0]
+		for _, col := range idx.columns {
+			if col != name {
+				cols = append(cols, col)
+			}
+		}
+		if idx.columns = cols; len(cols) > 0 {
+			indexes = append(indexes, idx)
+		}
+	}
+	t.indexes = indexes
+
+	checks := t.checks[:0]
+	for _, ch := range t.checks {
+		if ch.column != name {
+			checks = append(checks, ch)
+		}
+	}
+	t.checks = checks
+}
+
+// renameColumnRef rename column in indexes and checks
+func (t *table) renameColumnRef(oldName, newName string) {
+	for _, idx := range t.indexes {
+		for i, col := range idx.columns {
+			if col == oldName {
+				idx.columns[i] = newName
+			}
+		}
+	}
+	for _, ch := range t.checks {
+		if ch.column == oldName {
+			ch.column = newName
+		}
+	}
+}
+
+// columnName return name of column matching name case-insensitively if no column has the exact name
+func (t *table) columnName(name string) (string, bool) {
+	if _, c := t.column(name); c != nil {
+		return c.name, true
+	}
+	for _, c := range t.columns {
+		if strings.EqualFold(c.name, name) {
+			return c.name, true
+		}
+	}
+	return "", false
+}
+
+func (t *table) addCheck(ch *check) {
+	if ch.name == "" {
+		ch.name = t.name + "_" + ch.column + "_check"
+	}
+	t.dropCheck(ch.name)
+	t.checks = append(t.checks, ch)
+}
+
+func (t *table) dropCheck(name string) {
+	for i, ch := range t.checks {
+		if ch.name == name {
+			t.checks = append(t.checks[:i], t.checks[i+1:]...)
+			return
+		}
+	}
+}
+
+// check return the last CHECK constraint of column
+func (t *table) check(column string) *check {
+	for i := len(t.checks) - 1; i >= 0; i-- {
+		if t.checks[i].column == column {
+			return t.checks[i]
+		}
+	}
+	return nil
+}
+
+func (t *table) index(name string) (int, *index) {
+	for i, idx := range t.indexes {
+		if idx.name == name {
+			return i, idx
+		}
+	}
+	return -1, nil
+}
+
+func (t *table) addIndex(d dialect, idx *index) {
+	if idx.primaryKey {
+		t.dropPrimaryKey()
+	}
+	if idx.name == "" {
+		idx.name = d.indexName(t, idx)
+	}
+	if i, _ := t.index(idx.name); i >= 0 {
+		t.indexes[i] = idx
+		return
+	}
+	t.indexes = append(t.indexes, idx)
+}
+
+func (t *table) dropIndex(name string) bool {
+	i, _ := t.index(name)
+	if i < 0 {
+		return false
+	}
+	t.indexes = append(t.indexes[:i], t.indexes[i+1:]...)
+	return true
+}
+
+func (t *table) dropPrimaryKey() {
+	for _, idx := range t.indexes {
+		if idx.primaryKey {
+			t.dropIndex(idx.name)
+			return
+		}
+	}
+}
+
+// isPrimaryKey report whether column is part of primary key
+func (t *table) isPrimaryKey(name string) bool {
+	for _, idx := range t.indexes {
+		if idx.primaryKey && contains(idx.columns, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// isUnique report whether column has a single column unique index, only indexes created by constraint are counted if constraintOnly
+func (t *table) isUnique(name string, constraintOnly bool) bool {
+	for _, idx := range t.indexes {
+		if idx.unique && !idx.primaryKey && (idx.constraint || !constraintOnly) && len(idx.columns) == 1 && idx.columns[0] == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *table) hasIndex(name string) bool {
+	i, _ := t.index(name)
+	return i >= 0
+}
+
+// uniqueName return name, or name with the smallest number suffix not used by other indexes
+func (t *table) uniqueName(name, sep string, from int) string {
+	if !t.hasIndex(name) {
+		return name
+	}
+	for n := from; ; n++ {
+		if numbered := fmt.Sprintf("%s%s%d", name, sep, n); !t.hasIndex(numbered) {
+			return numbered
+		}
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,235 @@
+package ddl
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func parseSchema(t *testing.T, dialect, src string) *Schema {
+	t.Helper()
+	s, err := NewSchema(dialect)
+	if err != nil {
+		t.Fatalf("new schema: %v", err)
+	}
+	if err := s.Parse(src); err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	return s
+}
+
+// signatures return column signatures of table, with data type and go type
+func signatures(t *testing.T, s *Schema, tableName string) (comment string, result []string) {
+	t.Helper()
+	columns, comment, err := s.TableSchema(tableName, true)
+	if err != nil {
+		t.Fatalf("table schema: %v", err)
+	}
+	for _, c := range columns {
+		result = append(result, c.Signature()+" => "+c.GetDataType())
+	}
+	return comment, result
+}
+
+func assertSignatures(t *testing.T, got, want []string) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected columns:\ngot:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestSchema_MySQL(t *testing.T) {
+	s := parseSchema(t, "mysql", "-- schema dump\n"+
+		"/*!40101 SET NAMES utf8mb4 */;\n"+
+		"CREATE TABLE `users` (\n"+
+		"  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,\n"+
+		"  `name` varchar(64) NOT NULL DEFAULT '' COMMENT 'user''s name',\n"+
+		"  `age` int(11) DEFAULT NULL,\n"+
+		"  `is_admin` boolean NOT NULL DEFAULT FALSE,\n"+
+		"  `balance` decimal(10,2) NOT NULL DEFAULT '0.00',\n"+
+		"  `role` enum('admin','member') DEFAULT 'member',\n"+
+		"  `company_id` int NOT NULL,\n"+
+		"  `created_at` datetime(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),\n"+
+		"  PRIMARY KEY (`id`),\n"+
+		"  UNIQUE KEY `idx_name` (`name`),\n"+
+		"  KEY `idx_company_age` (`company_id`, `age`),\n"+
+		"  CONSTRAINT `fk_company` FOREIGN KEY (`company_id`) REFERENCES `companies` (`id`)\n"+
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='all users';\n"+
+		"ALTER TABLE users ADD COLUMN nickname varchar(32) NULL AFTER name, DROP COLUMN age;\n"+
+		"CREATE INDEX idx_nickname ON users (nickname(10));\n")
+
+	comment, got := signatures(t, s, "users")
+	if comment != "all users" {
+		t.Errorf("unexpected table comment: %q", comment)
+	}
+	assertSignatures(t, got, []string{
+		`"id" "bigint" "bigint unsigned" nullable:false primary:true auto_increment:true comment:"" index:"PRIMARY",1,true,true => int64`,
+		`"name" "varchar" "varchar(64)" nullable:false primary:false auto_increment:false default:"" comment:"user's name" index:"idx_name",1,true,false => string`,
+		`"nickname" "varchar" "varchar(32)" nullable:true primary:false auto_increment:false comment:"" index:"idx_nickname",1,false,false => string`,
+		`"is_admin" "tinyint" "tinyint(1)" nullable:false primary:false auto_increment:false default:"0" comment:"" => bool`,
+		`"balance" "decimal" "decimal(10,2)" nullable:false primary:false auto_increment:false default:"0.00" comment:"" => float64`,
+		`"role" "enum" "enum('admin','member')" nullable:true primary:false auto_increment:false default:"member" comment:"" => string`,
+		`"company_id" "int" "int" nullable:false primary:false auto_increment:false comment:"" index:"idx_company_age",1,false,false => int32`,
+		`"created_at" "datetime" "datetime(3)" nullable:false primary:false auto_increment:false default:"CURRENT_TIMESTAMP(3)" comment:"" => time.Time`,
+	})
+
+	columns, _, _ := s.TableSchema("users", false)
+	if unique, _ := columns[1].Unique(); !unique {
+		t.Errorf("expect name to be unique")
+	}
+	if len(columns[0].Indexes) != 0 {
+		t.Errorf("expect no index without withIndex, got %d", len(columns[0].Indexes))
+	}
+}
+
+func TestSchema_Postgres(t *testing.T) {
+	s := parseSchema(t, "postgres", `
+CREATE TABLE public.Orders (
+    id bigserial PRIMARY KEY,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    "Code" character varying(32) NOT NULL UNIQUE,
+    amount numeric(12, 2) DEFAULT 0,
+    status text DEFAULT 'new'::text NOT NULL,
+    tags text[],
+    paid boolean DEFAULT false,
+    created_at timestamp with time zone DEFAULT now(),
+    CONSTRAINT orders_amount_check CHECK (amount >= 0)
+);
+COMMENT ON TABLE orders IS 'customer orders';
+COMMENT ON COLUMN public.orders.status IS 'order status';
+CREATE UNIQUE INDEX IF NOT EXISTS orders_user_code ON orders USING btree (user_id, "Code");
+CREATE INDEX ON orders (lower(status));
+CREATE FUNCTION touch() RETURNS trigger AS $$ BEGIN NEW.updated_at = now(); RETURN NEW; END; $$ LANGUAGE plpgsql;
+ALTER TABLE orders ALTER COLUMN paid SET NOT NULL, ADD COLUMN note varchar(255);
+`)
+
+	if got := s.Tables(); !reflect.DeepEqual(got, []string{"orders"}) {
+		t.Fatalf("unexpected tables: %v", got)
+	}
+	comment, got := signatures(t, s, "orders")
+	if comment != "customer orders" {
+		t.Errorf("unexpected table comment: %q", comment)
+	}
+	assertSignatures(t, got, []string{
+		`"id" "int8" "int8" nullable:false primary:true auto_increment:true index:"orders_pkey",1,true,true => int64`,
+		`"user_id" "int4" "int4" nullable:false primary:false auto_increment:false index:"orders_user_code",1,true,false => int32`,
+		`"Code" "varchar" "varchar(32)" nullable:false primary:false auto_increment:false index:"orders_Code_key",1,true,false index:"orders_user_code",2,true,false => string`,
+		`"amount" "numeric" "numeric(12,2)" nullable:true primary:false auto_increment:false default:"0" => float64`,
+		`"status" "text" "text" nullable:false primary:false auto_increment:false default:"new" comment:"order status" => string`,
+		`"tags" "_text" "_text" nullable:true primary:false auto_increment:false => string`,
+		`"paid" "bool" "bool" nullable:false primary:false auto_increment:false default:"false" => bool`,
+		`"created_at" "timestamptz" "timestamptz" nullable:true primary:false auto_increment:false default:"now()" => time.Time`,
+		`"note" "varchar" "varchar(255)" nullable:true primary:false auto_increment:false => string`,
+	})
+}
+
+func TestSchema_SQLite(t *testing.T) {
+	s := parseSchema(t, "sqlite", `
+CREATE TABLE IF NOT EXISTS "pets" (
+	"id" INTEGER PRIMARY KEY AUTOINCREMENT,
+	[name] VARCHAR(32) NOT NULL UNIQUE,
+	owner_id integer,
+	weight REAL DEFAULT 1.5,
+	born_at datetime DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (owner_id) REFERENCES users (id)
+);
+CREATE INDEX idx_pets_owner ON pets(owner_id);
+CREATE TABLE tmp (a, b);
+DROP TABLE tmp;
+`)
+
+	if got := s.Tables(); !reflect.DeepEqual(got, []string{"pets"}) {
+		t.Fatalf("unexpected tables: %v", got)
+	}
+	_, got := signatures(t, s, "pets")
+	assertSignatures(t, got, []string{
+		`"id" "INTEGER" "INTEGER" nullable:true primary:true => int32`,
+		`"name" "VARCHAR" "VARCHAR(32)" nullable:false primary:false => string`,
+		`"owner_id" "integer" "integer" nullable:true primary:false index:"idx_pets_owner",1,false,false => int32`,
+		`"weight" "REAL" "REAL" nullable:true primary:false default:"1.5" => float64`,
+		`"born_at" "datetime" "datetime" nullable:true primary:false default:"CURRENT_TIMESTAMP" => time.Time`,
+	})
+}
+
+func TestSchema_AlterTable(t *testing.T) {
+	s := parseSchema(t, "mysql", `
+CREATE TABLE a (id int PRIMARY KEY, x int, y int, KEY idx_xy (x, y));
+ALTER TABLE a CHANGE COLUMN x x2 bigint NOT NULL FIRST, MODIFY y varchar(8), ADD (z int, w int), DROP INDEX idx_xy;
+ALTER TABLE a ADD UNIQUE (z), RENAME COLUMN w TO v;
+RENAME TABLE a TO b;
+CREATE TABLE c LIKE b;
+`)
+
+	if got := s.Tables(); !reflect.DeepEqual(got, []string{"b", "c"}) {
+		t.Fatalf("unexpected tables: %v", got)
+	}
+	for _, tableName := range []string{"b", "c"} {
+		_, got := signatures(t, s, tableName)
+		assertSignatures(t, got, []string{
+			`"x2" "bigint" "bigint" nullable:false primary:false auto_increment:false comment:"" => int64`,
+			`"id" "int" "int" nullable:false primary:true auto_increment:false comment:"" index:"PRIMARY",1,true,true => int32`,
+			`"y" "varchar" "varchar(8)" nullable:true primary:false auto_increment:false comment:"" => string`,
+			`"z" "int" "int" nullable:true primary:false auto_increment:false comment:"" index:"z",1,true,false => int32`,
+			`"v" "int" "int" nullable:true primary:false auto_increment:false comment:"" => int32`,
+		})
+	}
+}
+
+func TestSchema_Error(t *testing.T) {
+	if _, err := NewSchema("oracle"); err == nil {
+		t.Errorf("expect error for unsupported dialect")
+	}
+
+	s, _ := NewSchema("mysql")
+	for _, src := range []string{
+		"CREATE TABLE a (id int",
+		"CREATE TABLE a (name varchar(10) DEFAULT 'x)",
+		"/* unterminated",
+	} {
+		if err := s.Parse(src); err == nil {
+			t.Errorf("expect error for %q", src)
+		}
+	}
+	if _, _, err := s.TableSchema("missing", false); err == nil {
+		t.Errorf("expect error for undefined table")
+	}
+}
+
+func TestSchema_Enum(t *testing.T) {
+	s := parseSchema(t, "postgres", `
+CREATE TYPE mood AS ENUM ('sad', 'ok');
+ALTER TYPE mood ADD VALUE 'happy' AFTER 'ok';
+ALTER TYPE mood ADD VALUE IF NOT EXISTS 'angry' BEFORE 'sad';
+CREATE TYPE point AS (x int, y int);
+CREATE TABLE people (
+    id serial PRIMARY KEY,
+    mood mood NOT NULL,
+    kind varchar(16) CHECK (kind IN ('admin', 'member')),
+    level text,
+    CONSTRAINT people_level_check CHECK (level = 'low' OR level = 'high'),
+    CHECK (id > 0)
+);
+ALTER TABLE people RENAME COLUMN kind TO role;
+ALTER TABLE people DROP CONSTRAINT people_level_check;
+`)
+
+	enums := map[string]string{}
+	columns, _, _ := s.TableSchema("people", false)
+	for _, c := range columns {
+		if e := c.GetEnum(); e != nil {
+			enums[c.Name()] = e.TypeName + strings.Join(e.Values, ",")
+		}
+	}
+	if want := map[string]string{"mood": "moodangry,sad,ok,happy", "role": "admin,member"}; !reflect.DeepEqual(enums, want) {
+		t.Errorf("unexpected enums: %v", enums)
+	}
+
+	s = parseSchema(t, "mysql", "CREATE TABLE a (role enum('x','y'), `kind` varchar(8), CONSTRAINT chk_kind CHECK (`kind` IN ('p','q')));")
+	columns, _, _ = s.TableSchema("a", false)
+	if e := columns[0].GetEnum(); e == nil || !reflect.DeepEqual(e.Values, []string{"x", "y"}) {
+		t.Errorf("unexpected enum of mysql enum column: %+v", e)
+	}
+	if e := columns[1].GetEnum(); e == nil || !reflect.DeepEqual(e.Values, []string{"p", "q"}) {
+		t.Errorf("unexpected enum of mysql check constraint: %+v", e)
+	}
+}
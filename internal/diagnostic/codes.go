@@ -5,4 +5,12 @@ const (
 	CodeSQLVar        = "SQL_VAR"
 	CodeTemplateParse = "TEMPLATE_PARSE"
 	CodeSQLBuild      = "SQL_BUILD"
+
+	CodeModelGenerate    = "MODEL_GENERATE"
+	CodeQueryGenerate    = "QUERY_GENERATE"
+	CodeUnitTestGenerate = "UNIT_TEST_GENERATE"
+	CodeFormat           = "FORMAT"
+	CodePrune            = "PRUNE"
+	CodeHandEdited       = "HAND_EDITED"
+	CodeTableFilter      = "TABLE_FILTER"
 )
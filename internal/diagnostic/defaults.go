@@ -10,6 +10,20 @@ func DefaultMessage(code string) string {
 		return "template parse error"
 	case CodeSQLBuild:
 		return "build SQL error"
+	case CodeModelGenerate:
+		return "generate model file error"
+	case CodeQueryGenerate:
+		return "generate query file error"
+	case CodeUnitTestGenerate:
+		return "generate unit test file error"
+	case CodeFormat:
+		return "format generated code error"
+	case CodePrune:
+		return "prune generated file error"
+	case CodeHandEdited:
+		return "generated file was edited by hand"
+	case CodeTableFilter:
+		return "invalid table filter"
 	default:
 		return ""
 	}
@@ -25,6 +39,20 @@ func DefaultHint(code string) string {
 		return "Check template syntax inside {{...}} blocks."
 	case CodeSQLBuild:
 		return "Check template variables and ensure generated SQL is valid."
+	case CodeModelGenerate:
+		return "Check the table schema, model options and model output path."
+	case CodeQueryGenerate:
+		return "Check the applied models, interfaces and query output path."
+	case CodeUnitTestGenerate:
+		return "Check the unit test template and query output path."
+	case CodeFormat:
+		return "Check custom data types, tags and method templates used in the generated code."
+	case CodePrune:
+		return "The stale file was edited by hand, move the changes elsewhere and delete it manually."
+	case CodeHandEdited:
+		return "Move the changes out of the generated file (e.g. into a separate file of the same package), or delete it to regenerate."
+	case CodeTableFilter:
+		return "Check glob patterns and RegExps given to WithTableIncludes/WithTableExcludes and their Reg variants."
 	default:
 		return ""
 	}
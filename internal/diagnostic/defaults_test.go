@@ -11,6 +11,13 @@ func TestDefaultMessageKnownCodes(t *testing.T) {
 		{CodeSQLVar, "variable parse error"},
 		{CodeTemplateParse, "template parse error"},
 		{CodeSQLBuild, "build SQL error"},
+		{CodeModelGenerate, "generate model file error"},
+		{CodeQueryGenerate, "generate query file error"},
+		{CodeUnitTestGenerate, "generate unit test file error"},
+		{CodeFormat, "format generated code error"},
+		{CodePrune, "prune generated file error"},
+		{CodeHandEdited, "generated file was edited by hand"},
+		{CodeTableFilter, "invalid table filter"},
 	}
 	for _, c := range cases {
 		if got := DefaultMessage(c.code); got != c.want {
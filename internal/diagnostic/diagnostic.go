@@ -11,6 +11,7 @@ type Diagnostic struct {
 	File      string `json:"file,omitempty"`
 	Line      int    `json:"line,omitempty"`
 	Column    int    `json:"column,omitempty"`
+	Table     string `json:"table,omitempty"`
 	Interface string `json:"interface,omitempty"`
 	Method    string `json:"method,omitempty"`
 	Snippet   string `json:"snippet,omitempty"`
@@ -122,3 +123,16 @@ func WithMethod(err error, iface, method string) error {
 	}
 	return &Error{Diag: Diagnostic{Interface: iface, Method: method, Message: err.Error()}, Err: err}
 }
+
+func WithTable(err error, table string) error {
+	if err == nil {
+		return nil
+	}
+	if e, ok := err.(*Error); ok {
+		if e.Diag.Table == "" && table != "" {
+			e.Diag.Table = table
+		}
+		return e
+	}
+	return &Error{Diag: Diagnostic{Table: table, Message: err.Error()}, Err: err}
+}
@@ -0,0 +1,58 @@
+package diagnostic
+
+import (
+	"errors"
+	"strings"
+)
+
+// List diagnostics collected from multiple failures
+type List []*Error
+
+func (l List) Error() string {
+	msgs := make([]string, len(l))
+	for i, e := range l {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (l List) Unwrap() []error {
+	errs := make([]error, len(l))
+	for i, e := range l {
+		errs[i] = e
+	}
+	return errs
+}
+
+// As match the first diagnostic, keep errors.As working before go1.20 multiple unwrap
+func (l List) As(target interface{}) bool {
+	if t, ok := target.(**Error); ok && len(l) > 0 {
+		*t = l[0]
+		return true
+	}
+	return false
+}
+
+// Append add err to list, nested lists are flattened and plain errors are wrapped with code
+func (l List) Append(err error, code string) List {
+	if err == nil {
+		return l
+	}
+	var list List
+	if errors.As(err, &list) {
+		return append(l, list...)
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return append(l, e)
+	}
+	return append(l, Wrap(err, code, err.Error()))
+}
+
+// Err return nil when list is empty
+func (l List) Err() error {
+	if len(l) == 0 {
+		return nil
+	}
+	return l
+}
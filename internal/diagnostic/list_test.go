@@ -0,0 +1,48 @@
+package diagnostic
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestList_AppendFlattensAndWraps(t *testing.T) {
+	var l List
+	l = l.Append(nil, CodeFormat)
+	if l.Err() != nil {
+		t.Fatalf("expected nil error for empty list")
+	}
+
+	l = l.Append(errors.New("plain"), CodeModelGenerate)
+	l = l.Append(New(CodeFormat, "bad format"), CodeQueryGenerate)
+	l = l.Append(List{New(CodeSQLBuild, ""), New(CodeSQLVar, "")}, CodeQueryGenerate)
+
+	if len(l) != 4 {
+		t.Fatalf("expected 4 diagnostics, got %d", len(l))
+	}
+	if l[0].Diag.Code != CodeModelGenerate || l[0].Diag.Message != "plain" {
+		t.Fatalf("unexpected wrapped diagnostic: %+v", l[0].Diag)
+	}
+	if l[1].Diag.Code != CodeFormat {
+		t.Fatalf("expected code to be kept, got %s", l[1].Diag.Code)
+	}
+	if !strings.Contains(l.Error(), "bad format") {
+		t.Fatalf("unexpected error string: %s", l.Error())
+	}
+
+	var de *Error
+	if !errors.As(l.Err(), &de) {
+		t.Fatalf("expected errors.As to find diagnostic in list")
+	}
+}
+
+func TestWithTable(t *testing.T) {
+	err := WithTable(WithLocation(errors.New("x"), "user.gen.go", 0, 0), "users")
+	e, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("expected *Error, got %T", err)
+	}
+	if e.Diag.File != "user.gen.go" || e.Diag.Table != "users" {
+		t.Fatalf("unexpected diagnostic: %+v", e.Diag)
+	}
+}
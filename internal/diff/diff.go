@@ -0,0 +1,206 @@
+// Package diff : line based unified diff for generated files
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// contextLines number of unchanged lines shown around each change
+const contextLines = 3
+
+// maxLCSCells limit of the LCS table, larger changes are reported as a full replacement
+const maxLCSCells = 1 << 24
+
+type opKind byte
+
+const (
+	opEqual  opKind = ' '
+	opDelete opKind = '-'
+	opInsert opKind = '+'
+)
+
+type op struct {
+	kind opKind
+	line string
+}
+
+// Unified return unified diff between oldContent and newContent, return "" when they are equal
+func Unified(oldName, newName string, oldContent, newContent []byte) string {
+	if bytes.Equal(oldContent, newContent) {
+		return ""
+	}
+
+	ops := lineOps(splitLines(oldContent), splitLines(newContent))
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", oldName, newName)
+	for _, h := range hunks(ops) {
+		writeHunk(&out, ops, h)
+	}
+	return out.String()
+}
+
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// lineOps compute edit script, common prefix and suffix are trimmed before LCS
+func lineOps(a, b []string) []op {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]op, 0, len(a)+len(b))
+	for _, l := range a[:prefix] {
+		ops = append(ops, op{opEqual, l})
+	}
+	ops = append(ops, lcsOps(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, l := range a[len(a)-suffix:] {
+		ops = append(ops, op{opEqual, l})
+	}
+	return ops
+}
+
+func lcsOps(a, b []string) []op {
+	ops := make([]op, 0, len(a)+len(b))
+	if len(a)*len(b) > maxLCSCells {
+		for _, l := range a {
+			ops = append(ops, op{opDelete, l})
+		}
+		for _, l := range b {
+			ops = append(ops, op{opInsert, l})
+		}
+		return ops
+	}
+
+	// table[i][j] length of LCS of a[i:] and b[j:]
+	width := len(b) + 1
+	table := make([]int32, (len(a)+1)*width)
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				table[i*width+j] = table[(i+1)*width+j+1] + 1
+			} else if down, right := table[(i+1)*width+j], table[i*width+j+1]; down >= right {
+				table[i*width+j] = down
+			} else {
+				table[i*width+j] = right
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, op{opEqual, a[i]})
+			i++
+			j++
+		case table[(i+1)*width+j] >= table[i*width+j+1]:
+			ops = append(ops, op{opDelete, a[i]})
+			i++
+		default:
+			ops = append(ops, op{opInsert, b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, op{opDelete, a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, op{opInsert, b[j]})
+	}
+	return ops
+}
+
+// hunk range of ops [start, end)
+type hunk struct{ start, end int }
+
+func hunks(ops []op) (result []hunk) {
+	for i := 0; i < len(ops); i++ {
+		if ops[i].kind == opEqual {
+			continue
+		}
+		start := i - contextLines
+		if start < 0 {
+			start = 0
+		}
+		// extend while the next change is close enough to share context
+		end, equals := i+1, 0
+		for ; end < len(ops) && equals <= 2*contextLines; end++ {
+			if ops[end].kind == opEqual {
+				equals++
+			} else {
+				equals = 0
+			}
+		}
+		end -= equals
+		if end += contextLines; end > len(ops) {
+			end = len(ops)
+		}
+
+		if n := len(result); n > 0 && result[n-1].end >= start {
+			result[n-1].end = end
+		} else {
+			result = append(result, hunk{start, end})
+		}
+		i = end - 1
+	}
+	return result
+}
+
+func writeHunk(out *strings.Builder, ops []op, h hunk) {
+	oldStart, newStart := 1, 1
+	for _, o := range ops[:h.start] {
+		if o.kind != opInsert {
+			oldStart++
+		}
+		if o.kind != opDelete {
+			newStart++
+		}
+	}
+	oldCount, newCount := 0, 0
+	for _, o := range ops[h.start:h.end] {
+		if o.kind != opInsert {
+			oldCount++
+		}
+		if o.kind != opDelete {
+			newCount++
+		}
+	}
+	if oldCount == 0 {
+		oldStart--
+	}
+	if newCount == 0 {
+		newStart--
+	}
+
+	fmt.Fprintf(out, "@@ -%s +%s @@\n", hunkRange(oldStart, oldCount), hunkRange(newStart, newCount))
+	for _, o := range ops[h.start:h.end] {
+		out.WriteByte(byte(o.kind))
+		out.WriteString(o.line)
+		if !strings.HasSuffix(o.line, "\n") {
+			out.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+func hunkRange(start, count int) string {
+	if count == 1 {
+		return fmt.Sprint(start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
@@ -0,0 +1,53 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnified_Equal(t *testing.T) {
+	if out := Unified("a", "b", []byte("x\n"), []byte("x\n")); out != "" {
+		t.Fatalf("expected empty diff, got:\n%s", out)
+	}
+}
+
+func TestUnified_Create(t *testing.T) {
+	out := Unified("/dev/null", "b", nil, []byte("x\ny\n"))
+	want := "--- /dev/null\n+++ b\n@@ -0,0 +1,2 @@\n+x\n+y\n"
+	if out != want {
+		t.Fatalf("unexpected diff:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestUnified_ModifyWithContext(t *testing.T) {
+	oldContent := "1\n2\n3\n4\n5\n6\n7\n8\n9\n"
+	newContent := "1\n2\n3\n4\nfive\n6\n7\n8\n9\n"
+	out := Unified("a", "b", []byte(oldContent), []byte(newContent))
+	want := "--- a\n+++ b\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n"
+	if out != want {
+		t.Fatalf("unexpected diff:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestUnified_SeparateHunks(t *testing.T) {
+	var oldLines, newLines []string
+	for i := 0; i < 30; i++ {
+		line := strings.Repeat("x", i+1)
+		oldLines = append(oldLines, line)
+		if i == 2 || i == 25 {
+			line += "!"
+		}
+		newLines = append(newLines, line)
+	}
+	out := Unified("a", "b", []byte(strings.Join(oldLines, "\n")+"\n"), []byte(strings.Join(newLines, "\n")+"\n"))
+	if n := strings.Count(out, "@@ -"); n != 2 {
+		t.Fatalf("expected 2 hunks, got %d:\n%s", n, out)
+	}
+}
+
+func TestUnified_NoTrailingNewline(t *testing.T) {
+	out := Unified("a", "b", []byte("x"), []byte("y"))
+	if !strings.Contains(out, "\\ No newline at end of file") {
+		t.Fatalf("missing no newline marker:\n%s", out)
+	}
+}
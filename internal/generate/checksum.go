@@ -0,0 +1,107 @@
+package generate
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"hash"
+
+	"gorm.io/gorm"
+)
+
+// schemaChecksumQueries queries return cheap schema change indicators of all tables in current schema,
+// the first column of each row is table name, the others are hashed into table checksum
+var schemaChecksumQueries = map[string][]string{
+	"mysql": {
+		"SELECT TABLE_NAME, TABLE_TYPE, TABLE_COMMENT FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() ORDER BY TABLE_NAME",
+		"SELECT TABLE_NAME, ORDINAL_POSITION, COLUMN_NAME, COLUMN_TYPE, DATA_TYPE, IS_NULLABLE, COLUMN_DEFAULT, COLUMN_KEY, EXTRA, COLUMN_COMMENT, CHARACTER_MAXIMUM_LENGTH, NUMERIC_PRECISION, NUMERIC_SCALE FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() ORDER BY TABLE_NAME, ORDINAL_POSITION",
+		"SELECT TABLE_NAME, INDEX_NAME, SEQ_IN_INDEX, COLUMN_NAME, NON_UNIQUE FROM information_schema.STATISTICS WHERE TABLE_SCHEMA = DATABASE() ORDER BY TABLE_NAME, INDEX_NAME, SEQ_IN_INDEX",
+	},
+	"postgres": {
+		"SELECT c.relname, c.relkind, obj_description(c.oid, 'pg_class'), a.attnum, a.attname, format_type(a.atttypid, a.atttypmod), a.attnotnull, a.attidentity, pg_get_expr(d.adbin, d.adrelid), col_description(c.oid, a.attnum) " +
+			"FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace JOIN pg_attribute a ON a.attrelid = c.oid LEFT JOIN pg_attrdef d ON d.adrelid = c.oid AND d.adnum = a.attnum " +
+			"WHERE n.nspname = CURRENT_SCHEMA() AND c.relkind IN ('r', 'p', 'v', 'm', 'f') AND a.attnum > 0 AND NOT a.attisdropped ORDER BY c.relname, a.attnum",
+		"SELECT tablename, indexname, indexdef FROM pg_indexes WHERE schemaname = CURRENT_SCHEMA() ORDER BY tablename, indexname",
+		"SELECT c.relname, a.attnum, e.enumsortorder, e.enumlabel " +
+			"FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace JOIN pg_attribute a ON a.attrelid = c.oid JOIN pg_type t ON t.oid = a.atttypid JOIN pg_enum e ON e.enumtypid IN (t.oid, t.typelem) " +
+			"WHERE n.nspname = CURRENT_SCHEMA() AND c.relkind IN ('r', 'p', 'v', 'm', 'f') AND a.attnum > 0 AND NOT a.attisdropped ORDER BY c.relname, a.attnum, e.enumsortorder",
+		"SELECT c.relname, con.conname, pg_get_constraintdef(con.oid) " +
+			"FROM pg_constraint con JOIN pg_class c ON c.oid = con.conrelid JOIN pg_namespace n ON n.oid = c.relnamespace " +
+			"WHERE n.nspname = CURRENT_SCHEMA() AND con.contype = 'c' ORDER BY c.relname, con.conname",
+	},
+	"sqlite": { // CHECK constraints are part of the CREATE TABLE sql
+		"SELECT tbl_name, type, name, sql FROM sqlite_master WHERE sql IS NOT NULL ORDER BY tbl_name, type, name",
+	},
+}
+
+// optionalChecksumQueries queries of sources older servers do not have, skipped when they fail.
+// information_schema.CHECK_CONSTRAINTS exists since MySQL 8.0.16, earlier versions ignore CHECK anyway
+var optionalChecksumQueries = map[string][]string{
+	"mysql": {
+		"SELECT tc.TABLE_NAME, tc.CONSTRAINT_NAME, cc.CHECK_CLAUSE FROM information_schema.TABLE_CONSTRAINTS tc " +
+			"JOIN information_schema.CHECK_CONSTRAINTS cc ON cc.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND cc.CONSTRAINT_NAME = tc.CONSTRAINT_NAME " +
+			"WHERE tc.TABLE_SCHEMA = DATABASE() AND tc.CONSTRAINT_TYPE = 'CHECK' ORDER BY tc.TABLE_NAME, tc.CONSTRAINT_NAME",
+	},
+}
+
+// GetTableChecksums return checksum of each table's columns, indexes, comments, enum labels and CHECK constraints,
+// read with a few queries for all tables.
+// ok is false when dialect is not supported
+func GetTableChecksums(db *gorm.DB) (checksums map[string]string, ok bool, err error) {
+	queries, ok := schemaChecksumQueries[db.Dialector.Name()]
+	if !ok {
+		return nil, false, nil
+	}
+
+	hashes := make(map[string]hash.Hash)
+	for _, query := range queries {
+		if err = hashTableRows(db, hashes, query); err != nil {
+			return nil, true, fmt.Errorf("get table checksums fail: %w", err)
+		}
+	}
+	for _, query := range optionalChecksumQueries[db.Dialector.Name()] {
+		_ = hashTableRows(db, hashes, query)
+	}
+
+	checksums = make(map[string]string, len(hashes))
+	for table, h := range hashes {
+		checksums[table] = hex.EncodeToString(h.Sum(nil))
+	}
+	return checksums, true, nil
+}
+
+func hashTableRows(db *gorm.DB, hashes map[string]hash.Hash, query string) error {
+	rows, err := db.Raw(query).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close() // nolint
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	values := make([]sql.NullString, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		table := values[0].String
+		h, ok := hashes[table]
+		if !ok {
+			h = sha256.New()
+			hashes[table] = h
+		}
+		for _, v := range values[1:] {
+			fmt.Fprintf(h, "%t:%q,", v.Valid, v.String)
+		}
+		fmt.Fprintln(h)
+	}
+	return rows.Err()
+}
@@ -0,0 +1,101 @@
+package generate
+
+import (
+	"context"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"gorm.io/gen/internal/model"
+)
+
+const (
+	postgresEnumSQL = `SELECT a.attname, t.typname, e.enumlabel
+FROM pg_catalog.pg_attribute a
+JOIN pg_catalog.pg_class c ON c.oid = a.attrelid
+JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+JOIN pg_catalog.pg_type t ON t.oid = a.atttypid
+JOIN pg_catalog.pg_enum e ON e.enumtypid = t.oid
+WHERE c.relname = ? AND n.nspname = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA()) AND a.attnum > 0 AND NOT a.attisdropped
+ORDER BY a.attnum, e.enumsortorder`
+
+	postgresCheckSQL = `SELECT pg_catalog.pg_get_constraintdef(con.oid)
+FROM pg_catalog.pg_constraint con
+JOIN pg_catalog.pg_class c ON c.oid = con.conrelid
+JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+WHERE con.contype = 'c' AND c.relname = ? AND n.nspname = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA())`
+)
+
+// fillColumnEnums read enum values of columns from postgres enum types and CHECK constraints,
+// mysql enum values are read from column type, see model.Column.GetEnum
+func fillColumnEnums(db *gorm.DB, schemaName string, tableName string, columns []*model.Column) {
+	if db.Dialector.Name() != "postgres" {
+		return
+	}
+
+	enums := make(map[string]*model.Enum)
+	rows, err := db.Raw(postgresEnumSQL, tableName, schemaName).Rows()
+	if err != nil {
+		db.Logger.Warn(context.Background(), "read enum types of %s fail: %s", tableName, err.Error())
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var columnName, typeName, value string
+		if err := rows.Scan(&columnName, &typeName, &value); err != nil {
+			db.Logger.Warn(context.Background(), "read enum types of %s fail: %s", tableName, err.Error())
+			return
+		}
+		if enums[columnName] == nil {
+			enums[columnName] = &model.Enum{TypeName: typeName}
+		}
+		enums[columnName].Values = append(enums[columnName].Values, value)
+	}
+
+	var checks []string
+	if err := db.Raw(postgresCheckSQL, tableName, schemaName).Scan(&checks).Error; err != nil {
+		db.Logger.Warn(context.Background(), "read check constraints of %s fail: %s", tableName, err.Error())
+	}
+	for _, check := range checks {
+		if columnName, values, ok := model.ParseEnumCheck(check); ok && enums[columnName] == nil {
+			enums[columnName] = &model.Enum{Values: values}
+		}
+	}
+
+	for _, c := range columns {
+		if enum, ok := enums[c.Name()]; ok {
+			c.Enum = enum
+		}
+	}
+}
+
+// getEnumTypes change type of string fields with enum values to generated enum types, return enum types to declare in model file.
+// enum type is named after database enum type, or model and field name for mysql enum column and CHECK constraint
+func getEnumTypes(db *gorm.DB, conf *model.Config, structName string, fields []*model.Field) (enums []*model.EnumType) {
+	if !conf.FieldWithEnumType {
+		return nil
+	}
+
+	declared := make(map[string]bool)
+	for _, f := range fields {
+		if f.Column == nil || f.CustomGenType != "" || strings.TrimLeft(f.Type, "*") != "string" {
+			continue
+		}
+		enum := f.Column.GetEnum()
+		if enum == nil || len(enum.Values) == 0 {
+			continue
+		}
+
+		name, comment := structName+f.Name, "enum values of "+f.Column.TableName+"."+f.ColumnName
+		if enum.TypeName != "" {
+			name, comment = toSchemaName(db, enum.TypeName), "enum values of database type "+enum.TypeName
+		}
+		f.Type = strings.TrimSuffix(f.Type, "string") + name
+		f.CustomGenType = "Enum[" + conf.ModelPkg + "." + name + "]"
+		if !declared[name] {
+			declared[name] = true
+			enums = append(enums, model.NewEnumType(name, comment, enum))
+		}
+	}
+	return enums
+}
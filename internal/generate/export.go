@@ -19,8 +19,8 @@ import (
 
 // GetQueryStructMeta generate db model by table name
 func GetQueryStructMeta(db *gorm.DB, conf *model.Config) (*QueryStructMeta, error) {
-	if _, ok := db.Config.Dialector.(tests.DummyDialector); ok {
-		return nil, fmt.Errorf("UseDB() is necessary to generate model struct [%s] from database table [%s]", conf.ModelName, conf.TableName)
+	if _, ok := db.Config.Dialector.(tests.DummyDialector); ok && conf.SchemaSource == nil {
+		return nil, fmt.Errorf("UseDB() or UseDDL() is necessary to generate model struct [%s] from database table [%s]", conf.ModelName, conf.TableName)
 	}
 
 	conf = conf.Preprocess()
@@ -32,12 +32,17 @@ func GetQueryStructMeta(db *gorm.DB, conf *model.Config) (*QueryStructMeta, erro
 		return nil, fmt.Errorf("model name %q is invalid: %w", structName, err)
 	}
 
-	columns, err := getTableColumns(db, conf.GetSchemaName(db), tableName, conf.FieldWithIndexTag)
+	columns, tc, err := getTableSchema(db, conf, tableName)
 	if err != nil {
 		return nil, err
 	}
 
-	tc := getTableComment(db, tableName)
+	dialect := db.Dialector.Name()
+	if conf.SchemaSource != nil {
+		dialect = conf.SchemaSource.Dialect()
+	}
+	fields := getFields(db, conf, columns, model.DialectDataTypeMap(dialect, conf.FieldWithDialectType, conf.DataTypeMap))
+	enums := getEnumTypes(db, conf, structName, fields)
 
 	return (&QueryStructMeta{
 		db:                    db,
@@ -51,8 +56,10 @@ func GetQueryStructMeta(db *gorm.DB, conf *model.Config) (*QueryStructMeta, erro
 		QueryStructName:       uncaptialize(structName),
 		S:                     strings.ToLower(structName[0:1]),
 		StructInfo:            parser.Param{Type: structName, Package: conf.ModelPkg},
-		ImportPkgPaths:        conf.ImportPkgPaths,
-		Fields:                getFields(db, conf, columns),
+		ImportPkgPaths:        append(append([]string(nil), conf.ImportPkgPaths...), model.DialectImportPaths(dialect, conf.FieldWithDialectType, fields, conf.ImportPkgPaths)...),
+		Fields:                fields,
+		Enums:                 enums,
+		ModelSubPkg:           conf.SubPkg,
 	}).addMethodFromAddMethodOpt(conf.GetModelMethods()...), nil
 }
 
@@ -0,0 +1,31 @@
+package generate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// ModelFingerprint digest of everything the model file is rendered from:
+// table schema (columns, types and indexes) and the fields, tags and methods produced by model options
+func (b *QueryStructMeta) ModelFingerprint() string {
+	h := sha256.New()
+
+	fmt.Fprintf(h, "struct %q %q %q %q %q %t %q\n", b.StructInfo.Package, b.ModelSubPkg, b.ModelStructName, b.TableName, b.TableComment, b.MultilineTableComment, b.FileName)
+	for _, path := range b.ImportPkgPaths {
+		fmt.Fprintf(h, "import %q\n", path)
+	}
+	for _, f := range b.Fields {
+		fmt.Fprintf(h, "field %q %q %q %q %t\n", f.Name, f.Type, f.Tags(), f.ColumnComment, f.MultilineComment)
+		if f.Column != nil {
+			fmt.Fprintf(h, "column %s\n", f.Column.Signature())
+		}
+	}
+	for _, e := range b.Enums {
+		fmt.Fprintf(h, "enum %q %q %+v\n", e.Name, e.Comment, e.Values)
+	}
+	for _, m := range b.ModelMethods {
+		fmt.Fprintf(h, "method %q %q %q %q %q %q\n", m.Receiver.Type, m.MethodName, m.GetParamInTmpl(), m.GetResultParamInTmpl(), m.Doc, m.Body)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
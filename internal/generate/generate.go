@@ -16,9 +16,9 @@ import (
 ** Provided by @qqxhb
  */
 
-func getFields(db *gorm.DB, conf *model.Config, columns []*model.Column) (fields []*model.Field) {
+func getFields(db *gorm.DB, conf *model.Config, columns []*model.Column, dataTypeMap model.DataTypeMap) (fields []*model.Field) {
 	for _, col := range columns {
-		col.SetDataTypeMap(conf.DataTypeMap)
+		col.SetDataTypeMap(dataTypeMap)
 		col.WithNS(conf.FieldJSONTagNS)
 
 		m := col.ToField(conf.FieldNullable, conf.FieldCoverable, conf.FieldSignable, conf.FieldWithDefaultTag)
@@ -31,12 +31,7 @@ func getFields(db *gorm.DB, conf *model.Config, columns []*model.Column) (fields
 		}
 
 		m = modifyField(m, conf.ModifyOpts)
-		if ns, ok := db.NamingStrategy.(schema.NamingStrategy); ok {
-			ns.SingularTable = true
-			m.Name = ns.SchemaName(ns.TablePrefix + m.Name)
-		} else if db.NamingStrategy != nil {
-			m.Name = db.NamingStrategy.SchemaName(m.Name)
-		}
+		m.Name = toSchemaName(db, m.Name)
 
 		fields = append(fields, m)
 	}
@@ -58,6 +53,17 @@ func getFields(db *gorm.DB, conf *model.Config, columns []*model.Column) (fields
 	return fields
 }
 
+// toSchemaName convert database name to go name with db's naming strategy, without singularizing it
+func toSchemaName(db *gorm.DB, name string) string {
+	if ns, ok := db.NamingStrategy.(schema.NamingStrategy); ok {
+		ns.SingularTable = true
+		return ns.SchemaName(ns.TablePrefix + name)
+	} else if db.NamingStrategy != nil {
+		return db.NamingStrategy.SchemaName(name)
+	}
+	return name
+}
+
 func filterField(m *model.Field, opts []model.FieldOption) *model.Field {
 	for _, opt := range opts {
 		if opt.Operator()(m) == nil {
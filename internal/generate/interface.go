@@ -2,8 +2,10 @@ package generate
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"gorm.io/gen/internal/diagnostic"
 	"gorm.io/gen/internal/model"
@@ -33,8 +35,14 @@ type InterfaceMethod struct { // feature will replace InterfaceMethod to parser.
 	InterfaceName string         // origin interface name
 	Package       string         // interface package name
 	HasForParams  bool           //
+	DBOperation   string         // db of dbresolver the method runs on, read or write
 }
 
+const (
+	dbOperationRead  = "read"
+	dbOperationWrite = "write"
+)
+
 // FuncSign function signature
 func (m *InterfaceMethod) FuncSign() string {
 	return fmt.Sprintf("%s(%s) (%s)", m.MethodName, m.GetParamInTmpl(), m.GetResultParamInTmpl())
@@ -278,7 +286,10 @@ func (m *InterfaceMethod) checkResult(result []parser.Param) (err error) {
 // checkSQL get sql from comment and check it
 func (m *InterfaceMethod) checkSQL() (err error) {
 	m.SQLString, m.sqlBaseLine, m.sqlBaseColumn = m.parseDocString()
-	if err = m.sqlStateCheckAndSplit(); err != nil {
+	if err = m.sqlStateCheckAndSplit(); err == nil {
+		err = m.checkDBOperation()
+	}
+	if err != nil {
 		err = diagnostic.WithMethod(err, m.InterfaceName, m.MethodName)
 		err = diagnostic.WithLocation(err, m.File, m.DocLine, m.DocColumn)
 	}
@@ -288,6 +299,13 @@ func (m *InterfaceMethod) checkSQL() (err error) {
 func (m *InterfaceMethod) parseDocString() (string, int, int) {
 	docString, lineOffset, colOffset := m.getSQLDocString()
 	leftTrimmed := strings.TrimLeft(docString, " \t\r\n")
+	for { // skip @read/@write annotation lines before SQL
+		line, rest, found := strings.Cut(leftTrimmed, "\n")
+		if !found || dbOperationAnnotation(line) == "" {
+			break
+		}
+		leftTrimmed = strings.TrimLeft(rest, " \t\r\n")
+	}
 	trimmedPrefix := docString[:len(docString)-len(leftTrimmed)]
 	if trimmedPrefix != "" {
 		lineOffset += strings.Count(trimmedPrefix, "\n")
@@ -297,7 +315,14 @@ func (m *InterfaceMethod) parseDocString() (string, int, int) {
 			colOffset += len(trimmedPrefix)
 		}
 	}
-	docString = strings.TrimSpace(docString)
+	docString = strings.TrimSpace(leftTrimmed)
+	for { // skip @read/@write annotation lines after SQL
+		index := strings.LastIndex(docString, "\n")
+		if index == -1 || dbOperationAnnotation(docString[index+1:]) == "" {
+			break
+		}
+		docString = strings.TrimSpace(docString[:index])
+	}
 
 	baseLine := m.DocLine + lineOffset
 	baseCol := m.DocColumn + colOffset
@@ -354,6 +379,73 @@ func (m *InterfaceMethod) getSQLDocString() (string, int, int) {
 	return docString, lineOffset, colOffset
 }
 
+// DBResolver dbresolver operation of the method
+func (m *InterfaceMethod) DBResolver() string {
+	if m.DBOperation == dbOperationRead {
+		return "dbresolver.Read"
+	}
+	return "dbresolver.Write"
+}
+
+// checkDBOperation route method by @read/@write annotation,
+// method without annotation runs on read db only if its SQL is SELECT only
+func (m *InterfaceMethod) checkDBOperation() error {
+	for _, line := range strings.Split(m.Doc, "\n") {
+		op := dbOperationAnnotation(line)
+		if op == "" {
+			continue
+		}
+		if m.DBOperation != "" && m.DBOperation != op {
+			return fmt.Errorf("can not annotate method with both @read and @write")
+		}
+		m.DBOperation = op
+	}
+	if m.DBOperation == "" {
+		m.DBOperation = m.guessDBOperation()
+	}
+	return nil
+}
+
+// dbOperationAnnotation return operation of annotation line like `@read`, empty if line is not annotation
+func dbOperationAnnotation(line string) string {
+	switch strings.TrimSpace(line) {
+	case "@read":
+		return dbOperationRead
+	case "@write":
+		return dbOperationWrite
+	default:
+		return ""
+	}
+}
+
+var (
+	sqlTemplateRegexp = regexp.MustCompile(`\{\{.*?\}\}|@@?[\w.]+|'[^']*'|"[^"]*"`)
+
+	mutatingSQLKeywords = map[string]bool{
+		"INSERT": true, "UPDATE": true, "DELETE": true, "REPLACE": true, "MERGE": true, "UPSERT": true,
+		"CREATE": true, "DROP": true, "ALTER": true, "TRUNCATE": true, "LOCK": true, "CALL": true,
+	}
+)
+
+// guessDBOperation SELECT only SQL runs on read db, SQL mutating data or locking rows (SELECT ... FOR UPDATE) on write db
+func (m *InterfaceMethod) guessDBOperation() string {
+	if m.GormOption == "Exec" || m.ReturnSQLResult() {
+		return dbOperationWrite
+	}
+	words := strings.FieldsFunc(sqlTemplateRegexp.ReplaceAllString(m.SQLString, " "), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+	if m.GormOption != "Where" && (len(words) == 0 || !strings.EqualFold(words[0], "SELECT") && !strings.EqualFold(words[0], "WITH")) {
+		return dbOperationWrite
+	}
+	for _, word := range words {
+		if mutatingSQLKeywords[strings.ToUpper(word)] {
+			return dbOperationWrite
+		}
+	}
+	return dbOperationRead
+}
+
 // sqlStateCheckAndSplit check sql with an adeterministic finite automaton
 func (m *InterfaceMethod) sqlStateCheckAndSplit() error {
 	sqlString := m.SQLString
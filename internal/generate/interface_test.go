@@ -0,0 +1,83 @@
+package generate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildDIYMethod_DBOperation(t *testing.T) {
+	src := `package dal
+
+import "gorm.io/gen"
+
+type UserMethods interface {
+	// SELECT * FROM @@table WHERE id=@id
+	FindByID(id int) (gen.T, error)
+	// SELECT * FROM @@table {{where}}{{if update != ""}}name=@update{{end}}{{end}}
+	FindByName(update string) ([]gen.T, error)
+	// WITH t AS (SELECT id FROM @@table) SELECT * FROM t
+	FindWith() ([]gen.T, error)
+	// SELECT * FROM @@table WHERE id=@id FOR UPDATE
+	LockByID(id int) (gen.T, error)
+	// UPDATE @@table SET name=@name
+	UpdateName(name string) error
+	// where(id=@id)
+	FilterByID(id int) (gen.T, error)
+	// FindFromSource find row on source db
+	// @write
+	//
+	// SELECT * FROM @@table WHERE id=@id
+	FindFromSource(id int) (gen.T, error)
+	// @read
+	// DELETE FROM @@table WHERE id=@id
+	DeleteOnReplica(id int) error
+	// SELECT * FROM @@table WHERE id=@id
+	// @write
+	FindTrailing(id int) (gen.T, error)
+}
+`
+	methods, err := BuildDIYMethod(parseInterfaceSet(t, src), testMeta(), nil)
+	if err != nil {
+		t.Fatalf("build DIY method fail: %v", err)
+	}
+
+	expects := map[string]string{
+		"FindByID":        dbOperationRead,
+		"FindByName":      dbOperationRead,
+		"FindWith":        dbOperationRead,
+		"LockByID":        dbOperationWrite,
+		"UpdateName":      dbOperationWrite,
+		"FilterByID":      dbOperationRead,
+		"FindFromSource":  dbOperationWrite,
+		"DeleteOnReplica": dbOperationRead,
+		"FindTrailing":    dbOperationWrite,
+	}
+	if len(methods) != len(expects) {
+		t.Fatalf("expect %d methods, got %d", len(expects), len(methods))
+	}
+	for _, m := range methods {
+		if m.DBOperation != expects[m.MethodName] {
+			t.Errorf("method %s expects %s, got %s", m.MethodName, expects[m.MethodName], m.DBOperation)
+		}
+		if strings.Contains(m.SQLString, "@read") || strings.Contains(m.SQLString, "@write") {
+			t.Errorf("SQL of method %s should not contain annotation: %s", m.MethodName, m.SQLString)
+		}
+	}
+}
+
+func TestBuildDIYMethod_ConflictDBOperation(t *testing.T) {
+	src := `package dal
+
+import "gorm.io/gen"
+
+type UserMethods interface {
+	// @read
+	// @write
+	// SELECT * FROM @@table WHERE id=@id
+	FindByID(id int) (gen.T, error)
+}
+`
+	if _, err := BuildDIYMethod(parseInterfaceSet(t, src), testMeta(), nil); err == nil || !strings.Contains(err.Error(), "both @read and @write") {
+		t.Fatalf("expect error of both @read and @write, got %v", err)
+	}
+}
@@ -38,7 +38,10 @@ type QueryStructMeta struct {
 	Fields                []*model.Field
 	Source                model.SourceCode
 	ImportPkgPaths        []string
-	ModelMethods          []*parser.Method // user custom method bind to db base struct
+	ModelMethods          []*parser.Method  // user custom method bind to db base struct
+	Enums                 []*model.EnumType // enum types declared in model file
+	ModelSubPkg           string            // sub package of model package the model is generated into, slash separated
+	VersionColumn         string            // column of optimistic locking version
 
 	interfaceMode bool
 
@@ -97,6 +100,10 @@ func (b *QueryStructMeta) getFieldRealType(f reflect.Type) string {
 	if f.Implements(serializerInterface) || reflect.New(f).Type().Implements(serializerInterface) {
 		return "serializer"
 	}
+	switch f.String() {
+	case "datatypes.JSON", "gorm.DeletedAt", "soft_delete.DeletedAt":
+		return f.String()
+	}
 	scanValuer := reflect.TypeOf((*field.ScanValuer)(nil)).Elem()
 	if f.Implements(scanValuer) || reflect.New(f).Type().Implements(scanValuer) {
 		return "field"
@@ -157,6 +164,33 @@ func (b *QueryStructMeta) HasUniqueIndex() bool {
 	return false
 }
 
+// HasSoftDelete check if model has soft delete field
+func (b *QueryStructMeta) HasSoftDelete() bool {
+	for _, f := range b.Fields {
+		if f != nil && f.IsSoftDelete() {
+			return true
+		}
+	}
+	return false
+}
+
+// VersionField field of optimistic locking version column, nil if model hasn't integer column of VersionColumn
+func (b *QueryStructMeta) VersionField() *model.Field {
+	if b.VersionColumn == "" {
+		return nil
+	}
+	for _, f := range b.Fields {
+		if f == nil || f.IsRelation() || f.ColumnName != b.VersionColumn {
+			continue
+		}
+		switch strings.TrimLeft(f.Type, "*") {
+		case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+			return f
+		}
+	}
+	return nil
+}
+
 // HasField check if BaseStruct has fields
 func (b *QueryStructMeta) HasField() bool { return len(b.Fields) > 0 }
 
@@ -38,6 +38,31 @@ func getTableType(db *gorm.DB, tableName string) (result gorm.TableType, err err
 	return db.Migrator().TableType(tableName)
 }
 
+// getTableSchema get columns and comment of table from schema source or database, reuse cached schema if table is unchanged
+func getTableSchema(db *gorm.DB, conf *model.Config, tableName string) (columns []*model.Column, comment string, err error) {
+	if conf.SchemaSource != nil {
+		return conf.SchemaSource.TableSchema(tableName, conf.FieldWithIndexTag)
+	}
+
+	cache := conf.SchemaCache
+	if cache != nil {
+		if columns, comment, ok := cache.Load(tableName, conf.FieldWithIndexTag); ok {
+			return columns, comment, nil
+		}
+	}
+
+	columns, err = getTableColumns(db, conf.GetSchemaName(db), tableName, conf.FieldWithIndexTag)
+	if err != nil {
+		return nil, "", err
+	}
+	comment = getTableComment(db, tableName)
+
+	if cache != nil {
+		cache.Store(tableName, conf.FieldWithIndexTag, columns, comment)
+	}
+	return columns, comment, nil
+}
+
 func getTableColumns(db *gorm.DB, schemaName string, tableName string, indexTag bool) (result []*model.Column, err error) {
 	if db == nil {
 		return nil, errors.New("gorm db is nil")
@@ -48,6 +73,9 @@ func getTableColumns(db *gorm.DB, schemaName string, tableName string, indexTag
 	if err != nil {
 		return nil, err
 	}
+	if len(result) > 0 {
+		fillColumnEnums(db, schemaName, tableName, result)
+	}
 	if !indexTag || len(result) == 0 {
 		return result, nil
 	}
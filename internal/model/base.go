@@ -192,6 +192,16 @@ func (m *Field) Tags() string {
 // IsRelation ...
 func (m *Field) IsRelation() bool { return m.Relation != nil }
 
+// IsSoftDelete field is soft delete column of gorm.DeletedAt or gorm.io/plugin/soft_delete
+func (m *Field) IsSoftDelete() bool {
+	switch strings.TrimLeft(m.Type, "*") {
+	case "gorm.DeletedAt", "soft_delete.DeletedAt":
+		return true
+	default:
+		return false
+	}
+}
+
 // GenType ...
 func (m *Field) GenType() string {
 	if m.IsRelation() {
@@ -214,6 +224,8 @@ func (m *Field) GenType() string {
 		return "Time"
 	case "json.RawMessage", "[]byte":
 		return "Bytes"
+	case "datatypes.JSON":
+		return "JSON"
 	case "serializer":
 		return "Serializer"
 	default:
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -13,15 +14,38 @@ type Config struct {
 	TablePrefix string
 	TableName   string
 	ModelName   string
+	FileName    string // specified by FileNameOpt, take precedence over FileNameNS
+	SubPkg      string // sub package of model package, specified by SubPkgOpt
 
 	ImportPkgPaths []string
 	ModelOpts      []Option
 
+	SchemaCache  SchemaCache  // reuse table schema read in last run, nil means always introspect database
+	SchemaSource SchemaSource // read table schema from source other than database, like DDL files
+
 	NameStrategy
 	FieldConfig
 	MethodConfig
 }
 
+// SchemaCache cache of table schema
+type SchemaCache interface {
+	// Load return cached columns and comment of table, ok is false when table need to be introspected
+	Load(tableName string, withIndex bool) (columns []*Column, comment string, ok bool)
+	// Store save columns and comment read from database
+	Store(tableName string, withIndex bool, columns []*Column, comment string)
+}
+
+// SchemaSource source of table schema instead of database
+type SchemaSource interface {
+	// Dialect return name of database dialect, like mysql or postgres
+	Dialect() string
+	// Tables return names of all tables
+	Tables() []string
+	// TableSchema return columns and comment of table
+	TableSchema(tableName string, withIndex bool) (columns []*Column, comment string, err error)
+}
+
 // NameStrategy name strategy
 type NameStrategy struct {
 	SchemaNameOpts []SchemaNameOpt
@@ -35,12 +59,14 @@ type NameStrategy struct {
 type FieldConfig struct {
 	DataTypeMap map[string]func(columnType gorm.ColumnType) (dataType string)
 
-	FieldNullable       bool // generate pointer when field is nullable
-	FieldCoverable      bool // generate pointer when field has default value
-	FieldSignable       bool // detect integer field's unsigned type, adjust generated data type
-	FieldWithIndexTag   bool // generate with gorm index tag
-	FieldWithTypeTag    bool // generate with gorm column type tag
-	FieldWithDefaultTag bool
+	FieldNullable        bool // generate pointer when field is nullable
+	FieldCoverable       bool // generate pointer when field has default value
+	FieldSignable        bool // detect integer field's unsigned type, adjust generated data type
+	FieldWithIndexTag    bool // generate with gorm index tag
+	FieldWithTypeTag     bool // generate with gorm column type tag
+	FieldWithDefaultTag  bool
+	FieldWithEnumType    bool // generate named type with constants for enum column
+	FieldWithDialectType bool // map column type with built-in data type mapping of dialect
 
 	FieldJSONTagNS func(columnName string) string
 
@@ -62,6 +88,17 @@ func (cfg *Config) Preprocess() *Config {
 	cfg.ModelPkg = filepath.Base(cfg.ModelPkg)
 
 	cfg.ModifyOpts, cfg.FilterOpts, cfg.CreateOpts, cfg.MethodOpts = sortOptions(cfg.ModelOpts)
+	for _, opt := range cfg.ModelOpts {
+		switch opt := opt.(type) {
+		case FileNameOpt:
+			cfg.FileName = opt()
+		case SubPkgOpt:
+			cfg.SubPkg = strings.Trim(filepath.ToSlash(opt()), "/")
+		}
+	}
+	if cfg.SubPkg != "" {
+		cfg.ModelPkg = path.Base(cfg.SubPkg)
+	}
 
 	return cfg
 }
@@ -82,7 +119,10 @@ func (cfg *Config) GetNames() (tableName, structName, fileName string) {
 	}
 
 	fileName = strings.ToLower(tableName)
-	if cfg.FileNameNS != nil {
+	switch {
+	case cfg.FileName != "":
+		fileName = cfg.FileName
+	case cfg.FileNameNS != nil:
 		fileName = cfg.FileNameNS(cfg.TableName)
 	}
 
@@ -0,0 +1,30 @@
+package model
+
+import "testing"
+
+func TestConfigFileNameAndSubPkgOpt(t *testing.T) {
+	cfg := (&Config{
+		ModelPkg:  "dal/model",
+		TableName: "audit_logs",
+		ModelName: "AuditLog",
+		NameStrategy: NameStrategy{
+			FileNameNS: func(tableName string) string { return "ns_" + tableName },
+		},
+		ModelOpts: []Option{FileNameOpt(func() string { return "log" }), SubPkgOpt(func() string { return "/audit/v1/" })},
+	}).Preprocess()
+
+	if cfg.SubPkg != "audit/v1" || cfg.ModelPkg != "v1" {
+		t.Fatalf("unexpected package: sub=%q pkg=%q", cfg.SubPkg, cfg.ModelPkg)
+	}
+	if _, _, fileName := cfg.GetNames(); fileName != "log" {
+		t.Fatalf("expected file name option to take precedence, got %q", fileName)
+	}
+
+	cfg = (&Config{
+		TableName:    "audit_logs",
+		NameStrategy: NameStrategy{FileNameNS: func(tableName string) string { return "ns_" + tableName }},
+	}).Preprocess()
+	if _, _, fileName := cfg.GetNames(); fileName != "ns_audit_logs" || cfg.ModelPkg != DefaultModelPkg {
+		t.Fatalf("unexpected defaults: file=%q pkg=%q", fileName, cfg.ModelPkg)
+	}
+}
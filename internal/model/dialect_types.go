@@ -0,0 +1,187 @@
+package model
+
+import (
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// DataTypeMap database type -> go type mapping
+type DataTypeMap = map[string]func(columnType gorm.ColumnType) (dataType string)
+
+// dialectDataType default data type mapping of dialect, take precedence over column's scan type and mysql-centric dataType
+type dialectDataType struct {
+	typeMap     DataTypeMap
+	importPaths []string // packages of mapped types
+}
+
+// registered data type mapping of dialect, always applied
+var dialectDataTypes = struct {
+	sync.RWMutex
+	m map[string]*dialectDataType
+}{m: map[string]*dialectDataType{}}
+
+// builtinDialectDataTypes built-in data type mapping of dialect, only applied when enabled by FieldWithDialectType
+var builtinDialectDataTypes = map[string]*dialectDataType{
+	"postgres": {
+		typeMap: DataTypeMap{
+			"bool":        goType("bool"),
+			"int2":        goType("int16"),
+			"int4":        goType("int32"),
+			"int8":        goType("int64"),
+			"float4":      goType("float32"),
+			"float8":      goType("float64"),
+			"numeric":     goType("string"), // keep precision, same as mysql decimal scanned into string
+			"text":        goType("string"),
+			"varchar":     goType("string"),
+			"bpchar":      goType("string"),
+			"bytea":       goType("[]byte"),
+			"date":        goType("time.Time"),
+			"timestamp":   goType("time.Time"),
+			"timestamptz": goType("time.Time"),
+			"uuid":        goType("uuid.UUID"),
+			"json":        goType("datatypes.JSON"),
+			"jsonb":       goType("datatypes.JSON"),
+			"inet":        goType("pgtype.Inet"),
+			"cidr":        goType("pgtype.CIDR"),
+			"macaddr":     goType("pgtype.Macaddr"),
+			"interval":    goType("pgtype.Interval"),
+			"_bool":       goType("pq.BoolArray"),
+			"_int2":       goType("pq.Int32Array"),
+			"_int4":       goType("pq.Int32Array"),
+			"_int8":       goType("pq.Int64Array"),
+			"_float4":     goType("pq.Float32Array"),
+			"_float8":     goType("pq.Float64Array"),
+			"_numeric":    goType("pq.StringArray"),
+			"_text":       goType("pq.StringArray"),
+			"_varchar":    goType("pq.StringArray"),
+			"_bpchar":     goType("pq.StringArray"),
+			"_uuid":       goType("pq.StringArray"),
+			"_bytea":      goType("pq.ByteaArray"),
+		},
+		importPaths: []string{"github.com/google/uuid", "github.com/jackc/pgtype", "github.com/lib/pq"},
+	},
+}
+
+func goType(typ string) func(gorm.ColumnType) string {
+	return func(gorm.ColumnType) string { return typ }
+}
+
+// RegisterDialectDataTypeMap register default data type mapping of dialect, override registered mapping of the same database type.
+// importPaths are packages of mapped types, imported by model files using them
+func RegisterDialectDataTypeMap(dialect string, typeMap DataTypeMap, importPaths ...string) {
+	dialectDataTypes.Lock()
+	defer dialectDataTypes.Unlock()
+
+	d, ok := dialectDataTypes.m[dialect]
+	if !ok {
+		d = &dialectDataType{typeMap: make(DataTypeMap, len(typeMap))}
+		dialectDataTypes.m[dialect] = d
+	}
+	for dataType, mapping := range typeMap {
+		d.typeMap[strings.ToLower(dataType)] = mapping
+	}
+	for _, importPath := range importPaths {
+		if !contains(d.importPaths, importPath) {
+			d.importPaths = append(d.importPaths, importPath)
+		}
+	}
+}
+
+// dialectsOf return data type mappings of dialect in order of precedence from low to high
+func dialectsOf(dialect string, builtin bool) (dialects []*dialectDataType) {
+	if d, ok := builtinDialectDataTypes[dialect]; ok && builtin {
+		dialects = append(dialects, d)
+	}
+	if d, ok := dialectDataTypes.m[dialect]; ok {
+		dialects = append(dialects, d)
+	}
+	return dialects
+}
+
+// DialectDataTypeMap return registered (and built-in if enabled) data type mapping of dialect merged with custom mapping,
+// custom mapping takes precedence
+func DialectDataTypeMap(dialect string, builtin bool, custom DataTypeMap) DataTypeMap {
+	dialectDataTypes.RLock()
+	defer dialectDataTypes.RUnlock()
+
+	dialects := dialectsOf(dialect, builtin)
+	if len(dialects) == 0 {
+		return custom
+	}
+	m := make(DataTypeMap, len(custom))
+	for _, d := range dialects {
+		for dataType, mapping := range d.typeMap {
+			m[dataType] = mapping
+		}
+	}
+	for dataType, mapping := range custom {
+		m[dataType] = mapping
+	}
+	return m
+}
+
+// DialectImportPaths return quoted import paths of dialect's mapped types used by fields, paths in imported are skipped
+func DialectImportPaths(dialect string, builtin bool, fields []*Field, imported []string) (paths []string) {
+	dialectDataTypes.RLock()
+	defer dialectDataTypes.RUnlock()
+
+	for _, d := range dialectsOf(dialect, builtin) {
+		for _, importPath := range d.importPaths {
+			quoted := strconv.Quote(importPath)
+			if contains(imported, quoted) || contains(paths, quoted) {
+				continue
+			}
+			name := pkgName(importPath)
+			for _, f := range fields {
+				if usePkg(f.Type, name) {
+					paths = append(paths, quoted)
+					break
+				}
+			}
+		}
+	}
+	return paths
+}
+
+// usePkg report whether type refers to package, like pq.StringArray or *uuid.UUID
+func usePkg(typ, pkg string) bool {
+	for offset := 0; ; {
+		i := strings.Index(typ[offset:], pkg+".")
+		if i < 0 {
+			return false
+		}
+		if i += offset; i == 0 || !isIdentChar(typ[i-1]) && typ[i-1] != '.' {
+			return true
+		}
+		offset = i + 1
+	}
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+var majorVersionSuffix = regexp.MustCompile(`^v[0-9]+$`)
+
+// pkgName return package name assumed by import path, like uuid of github.com/google/uuid, pgtype of github.com/jackc/pgx/v5/pgtype
+func pkgName(importPath string) string {
+	name := path.Base(importPath)
+	if majorVersionSuffix.MatchString(name) {
+		name = path.Base(path.Dir(importPath))
+	}
+	return strings.TrimPrefix(name, "go-")
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDialectDataTypeMap(t *testing.T) {
+	RegisterDialectDataTypeMap("test_dialect", DataTypeMap{
+		"HSTORE": goType("hstore.Hstore"),
+		"money":  goType("decimal.Decimal"),
+	}, "github.com/lib/pq/hstore", "github.com/shopspring/decimal", "github.com/lib/pq/hstore")
+
+	m := DialectDataTypeMap("test_dialect", false, DataTypeMap{"money": goType("int64")})
+	if got := m["hstore"](nil); got != "hstore.Hstore" {
+		t.Errorf("expect registered type name to be lowercased, got %q", got)
+	}
+	if got := m["money"](nil); got != "int64" {
+		t.Errorf("expect custom mapping to take precedence, got %q", got)
+	}
+	if got := DialectDataTypeMap("mysql", true, nil); got != nil {
+		t.Errorf("expect no default mapping of mysql, got %v", got)
+	}
+	if got := DialectDataTypeMap("postgres", false, nil); got != nil {
+		t.Errorf("expect built-in postgres mapping to be disabled, got %v", got)
+	}
+	if got := DialectDataTypeMap("postgres", true, nil)["jsonb"](nil); got != "datatypes.JSON" {
+		t.Errorf("unexpected postgres jsonb mapping: %q", got)
+	}
+	if got := DialectDataTypeMap("postgres", true, nil)["numeric"](nil); got != "string" {
+		t.Errorf("expect postgres numeric mapped to string, got %q", got)
+	}
+
+	fields := []*Field{{Type: "*hstore.Hstore"}, {Type: "string"}, {Type: "mydecimal.Value"}}
+	if got := DialectImportPaths("test_dialect", false, fields, nil); !reflect.DeepEqual(got, []string{`"github.com/lib/pq/hstore"`}) {
+		t.Errorf("unexpected import paths: %v", got)
+	}
+	if got := DialectImportPaths("test_dialect", false, fields, []string{`"github.com/lib/pq/hstore"`}); len(got) != 0 {
+		t.Errorf("expect imported path to be skipped, got %v", got)
+	}
+}
+
+func TestPkgName(t *testing.T) {
+	for importPath, want := range map[string]string{
+		"github.com/google/uuid":         "uuid",
+		"github.com/jackc/pgx/v5/pgtype": "pgtype",
+		"github.com/go-sql-driver/mysql": "mysql",
+		"github.com/lib/pq":              "pq",
+	} {
+		if got := pkgName(importPath); got != want {
+			t.Errorf("pkgName(%q) = %q, want %q", importPath, got, want)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Enum enum values of column, defined by database enum type or CHECK constraint
+type Enum struct {
+	TypeName string   `json:"type_name,omitempty"` // name of database enum type like postgres CREATE TYPE ... AS ENUM, empty for mysql enum column and CHECK constraint
+	Values   []string `json:"values"`
+}
+
+// EnumType go type generated for enum column
+type EnumType struct {
+	Name    string // go type name
+	Comment string
+	Shared  bool // generated from database enum type, which can be used by columns of different tables
+	Values  []EnumValue
+}
+
+// EnumValue constant of enum type
+type EnumValue struct {
+	Name  string // go constant name
+	Value string
+}
+
+// GetEnum return enum values of column, from database enum type, CHECK constraint or mysql enum column type
+func (c *Column) GetEnum() *Enum {
+	if c.Enum != nil {
+		return c.Enum
+	}
+	ct := c.columnType()
+	if len(ct) < len("enum()") || !strings.EqualFold(ct[:len("enum(")], "enum(") || ct[len(ct)-1] != ')' {
+		return nil
+	}
+	values, ok := parseStringList(tokenizeExpr(ct[len("enum(") : len(ct)-1]))
+	if !ok {
+		return nil
+	}
+	return &Enum{Values: values}
+}
+
+// NewEnumType build go enum type of name with enum values, constants are named after type name and value
+func NewEnumType(name, comment string, enum *Enum) *EnumType {
+	t := &EnumType{Name: name, Comment: comment, Shared: enum.TypeName != ""}
+	used := make(map[string]bool, len(enum.Values))
+	for _, v := range enum.Values {
+		constName := name + exportedName(v)
+		for i := 2; used[constName]; i++ {
+			constName = name + exportedName(v) + "_" + strconv.Itoa(i)
+		}
+		used[constName] = true
+		t.Values = append(t.Values, EnumValue{Name: constName, Value: v})
+	}
+	return t
+}
+
+// exportedName convert enum value to exported identifier part, like in_progress -> InProgress
+func exportedName(value string) string {
+	var sb strings.Builder
+	upper := true
+	for _, r := range value {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		sb.WriteRune(r)
+	}
+	if sb.Len() == 0 {
+		return "Empty"
+	}
+	return sb.String()
+}
+
+// ParseEnumCheck parse CHECK constraint limiting column to a list of string values, like
+// status IN ('a', 'b'), status = 'a' OR status = 'b' and postgres ((status)::text = ANY ((ARRAY['a'::character varying, 'b'::character varying])::text[]))
+func ParseEnumCheck(expr string) (column string, values []string, ok bool) {
+	toks := normalizeCheck(tokenizeExpr(expr))
+	if len(toks) < 3 || !toks[0].isName() {
+		return "", nil, false
+	}
+	column = toks[0].text
+
+	switch {
+	case toks[1].isWord("IN"):
+		values, ok = parseStringList(toks[2:])
+	case toks[1].text == "=" && toks[2].isWord("ANY"):
+		values, ok = parseStringList(toks[3:])
+	case toks[1].text == "=":
+		for i := 0; i < len(toks); i += 4 { // col = 'a' OR col = 'b'
+			if i+2 >= len(toks) || toks[i].text != column || toks[i+1].text != "=" || toks[i+2].kind != exprString ||
+				i+3 < len(toks) && !toks[i+3].isWord("OR") {
+				return "", nil, false
+			}
+			values = append(values, toks[i+2].text)
+		}
+		ok = true
+	}
+	if !ok || len(values) == 0 {
+		return "", nil, false
+	}
+	return column, values, true
+}
+
+type exprTokenKind int
+
+const (
+	exprWord exprTokenKind = iota
+	exprQuoted
+	exprString
+	exprPunct
+)
+
+type exprToken struct {
+	kind exprTokenKind
+	text string
+}
+
+func (t exprToken) isName() bool { return t.kind == exprWord || t.kind == exprQuoted }
+
+func (t exprToken) isWord(word string) bool {
+	return t.kind == exprWord && strings.EqualFold(t.text, word)
+}
+
+// tokenizeExpr split sql expression into words, quoted identifiers, string literals and punctuations, nil is returned if quotes are unbalanced
+func tokenizeExpr(expr string) (toks []exprToken) {
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '\'' || c == '"' || c == '`':
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(expr); j++ {
+				if expr[j] == '\\' && c == '\'' && j+1 < len(expr) {
+					j++
+				} else if expr[j] == c {
+					if j+1 < len(expr) && expr[j+1] == c {
+						j++
+					} else {
+						break
+					}
+				}
+				sb.WriteByte(expr[j])
+			}
+			if j >= len(expr) {
+				return nil
+			}
+			kind := exprQuoted
+			if c == '\'' {
+				kind = exprString
+			}
+			toks = append(toks, exprToken{kind: kind, text: sb.String()})
+			i = j + 1
+		case c == ':' && i+1 < len(expr) && expr[i+1] == ':':
+			toks = append(toks, exprToken{kind: exprPunct, text: "::"})
+			i += 2
+		case c == '_' || c == '$' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80:
+			j := i + 1
+			for j < len(expr) && (expr[j] == '_' || expr[j] == '.' || expr[j] == '$' || expr[j] >= '0' && expr[j] <= '9' ||
+				expr[j] >= 'a' && expr[j] <= 'z' || expr[j] >= 'A' && expr[j] <= 'Z' || expr[j] >= 0x80) {
+				j++
+			}
+			toks = append(toks, exprToken{kind: exprWord, text: expr[i:j]})
+			i = j
+		default:
+			toks = append(toks, exprToken{kind: exprPunct, text: string(c)})
+			i++
+		}
+	}
+	return toks
+}
+
+// normalizeCheck remove CHECK keyword, parentheses, brackets, ARRAY keyword, type casts, charset introducers and table qualifiers
+func normalizeCheck(toks []exprToken) (result []exprToken) {
+	for i := 0; i < len(toks); i++ {
+		tok := toks[i]
+		switch {
+		case tok.kind == exprPunct && strings.Contains("()[]", tok.text):
+		case tok.isWord("CHECK"), tok.isWord("ARRAY"):
+		case tok.text == "::" && tok.kind == exprPunct:
+			for i+1 < len(toks) && toks[i+1].kind == exprWord && !toks[i+1].isWord("OR") {
+				i++
+			}
+		case tok.kind == exprWord && strings.HasPrefix(tok.text, "_") && i+1 < len(toks) && toks[i+1].kind == exprString: // mysql charset introducer, like _utf8mb4'a'
+		case tok.kind == exprWord && strings.Contains(tok.text, "."):
+			tok.text = tok.text[strings.LastIndexByte(tok.text, '.')+1:]
+			result = append(result, tok)
+		case tok.kind == exprPunct && tok.text == "." && len(result) > 0 && i+1 < len(toks) && toks[i+1].isName(): // "t"."status"
+			result = result[:len(result)-1]
+		default:
+			result = append(result, tok)
+		}
+	}
+	return result
+}
+
+// parseStringList parse comma separated string literals
+func parseStringList(toks []exprToken) (values []string, ok bool) {
+	if len(toks) == 0 {
+		return nil, false
+	}
+	for i, tok := range toks {
+		if i%2 == 0 && tok.kind != exprString || i%2 == 1 && (tok.kind != exprPunct || tok.text != ",") {
+			return nil, false
+		}
+		if i%2 == 0 {
+			values = append(values, tok.text)
+		}
+	}
+	return values, len(toks)%2 == 1
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseEnumCheck(t *testing.T) {
+	for expr, want := range map[string][]string{
+		"status IN ('active', 'blocked')":                                                                      {"status", "active", "blocked"},
+		"CHECK (`status` in (_utf8mb4'active',_utf8mb4'blocked'))":                                             {"status", "active", "blocked"},
+		"CHECK (((status)::text = ANY ((ARRAY['a'::character varying, 'it''s'::character varying])::text[])))": {"status", "a", "it's"},
+		`CHECK ((("t"."status" = 'a'::text) OR (status = 'b'::text)))`:                                         {"status", "a", "b"},
+		"kind = 'only'": {"kind", "only"},
+	} {
+		column, values, ok := ParseEnumCheck(expr)
+		if !ok || !reflect.DeepEqual(append([]string{column}, values...), want) {
+			t.Errorf("ParseEnumCheck(%q) = %q, %q, %t", expr, column, values, ok)
+		}
+	}
+
+	for _, expr := range []string{
+		"amount >= 0",
+		"status NOT IN ('a', 'b')",
+		"status IN ('a', 1)",
+		"status = 'a' OR kind = 'b'",
+		"status = 'a' AND status = 'b'",
+		"status IN ()",
+	} {
+		if column, values, ok := ParseEnumCheck(expr); ok {
+			t.Errorf("ParseEnumCheck(%q) = %q, %q, expect not enum check", expr, column, values)
+		}
+	}
+}
+
+func TestColumn_GetEnum(t *testing.T) {
+	c := &Column{ColumnType: testColumnType{name: "role", databaseType: "enum", columnType: "enum('admin','it\\'s','')"}}
+	if e := c.GetEnum(); e == nil || !reflect.DeepEqual(e.Values, []string{"admin", "it's", ""}) {
+		t.Errorf("unexpected enum of mysql enum column: %+v", e)
+	}
+
+	c = &Column{ColumnType: testColumnType{name: "name", databaseType: "varchar", columnType: "varchar(20)"}}
+	if e := c.GetEnum(); e != nil {
+		t.Errorf("expect no enum, got %+v", e)
+	}
+	c.Enum = &Enum{TypeName: "mood", Values: []string{"ok"}}
+	if e := c.GetEnum(); e != c.Enum {
+		t.Errorf("expect enum read from database, got %+v", e)
+	}
+}
+
+func TestNewEnumType(t *testing.T) {
+	e := NewEnumType("UserRole", "", &Enum{Values: []string{"admin", "in-progress", "in_progress", "", "2nd"}})
+	var names []string
+	for _, v := range e.Values {
+		names = append(names, v.Name)
+	}
+	want := []string{"UserRoleAdmin", "UserRoleInProgress", "UserRoleInProgress_2", "UserRoleEmpty", "UserRole2nd"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("unexpected constant names: %v", names)
+	}
+	if e.Shared {
+		t.Errorf("expect enum of column not shared")
+	}
+}
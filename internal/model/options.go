@@ -34,6 +34,9 @@ var (
 	_ Option = CreateFieldOpt(nil)
 
 	_ Option = AddMethodOpt(nil)
+
+	_ Option = FileNameOpt(nil)
+	_ Option = SubPkgOpt(nil)
 )
 
 // ModifyFieldOpt modify field option
@@ -72,6 +75,22 @@ func (AddMethodOpt) OptionType() string { return methodType }
 // Methods ...
 func (o AddMethodOpt) Methods() []interface{} { return o() }
 
+const fileType = "file"
+
+// FileNameOpt specify generated model file name
+type FileNameOpt func() (fileName string)
+
+// OptionType implement for interface Option
+func (FileNameOpt) OptionType() string { return fileType }
+
+const pkgType = "pkg"
+
+// SubPkgOpt specify sub package of model package to generate model into
+type SubPkgOpt func() (subPkg string)
+
+// OptionType implement for interface Option
+func (SubPkgOpt) OptionType() string { return pkgType }
+
 func sortOptions(opts []Option) (modifyOpts []FieldOption, filterOpts []FieldOption, createOpts []FieldOption, methodOpt []MethodOption) {
 	for _, opt := range opts {
 		switch opt := opt.(type) {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 
 	"gorm.io/gen/field"
@@ -16,6 +17,7 @@ type Column struct {
 	TableName   string                                                        `gorm:"column:TABLE_NAME"`
 	Indexes     []*Index                                                      `gorm:"-"`
 	UseScanType bool                                                          `gorm:"-"`
+	Enum        *Enum                                                         `gorm:"-"` // enum values read from database enum type or CHECK constraint
 	dataTypeMap map[string]func(columnType gorm.ColumnType) (dataType string) `gorm:"-"`
 	jsonTagNS   func(columnName string) string                                `gorm:"-"`
 }
@@ -74,10 +76,21 @@ func (c *Column) ToField(nullable, coverable, signable, withDefaultTag bool) *Fi
 		GORMTag:          c.buildGormTag(withDefaultTag),
 		Tag:              map[string]string{field.TagKeyJson: c.jsonTagNS(c.Name())},
 		ColumnComment:    c.sanitizeComment(comment),
+		CustomGenType:    c.genType(),
 		Column:           c,
 	}
 }
 
+// genType field type of json column is field.JSON whatever go type it's mapped to
+func (c *Column) genType() string {
+	switch strings.ToLower(c.DatabaseTypeName()) {
+	case "json", "jsonb":
+		return "JSON"
+	default:
+		return ""
+	}
+}
+
 func (c *Column) multilineComment() bool {
 	cm, ok := c.Comment()
 	return ok && strings.Contains(cm, "\n")
@@ -199,3 +212,46 @@ func (c *Column) columnType() (v string) {
 	}
 	return c.DatabaseTypeName()
 }
+
+// Signature describe column metadata (name, types, nullable, keys, default, comment and indexes) in a stable format
+func (c *Column) Signature() string {
+	var sb strings.Builder
+	sb.WriteString(strconv.Quote(c.Name()))
+	sb.WriteString(" " + strconv.Quote(c.DatabaseTypeName()))
+	sb.WriteString(" " + strconv.Quote(c.columnType()))
+	if n, ok := c.Nullable(); ok {
+		sb.WriteString(fmt.Sprintf(" nullable:%t", n))
+	}
+	if pk, ok := c.PrimaryKey(); ok {
+		sb.WriteString(fmt.Sprintf(" primary:%t", pk))
+	}
+	if ai, ok := c.AutoIncrement(); ok {
+		sb.WriteString(fmt.Sprintf(" auto_increment:%t", ai))
+	}
+	if v, ok := c.DefaultValue(); ok {
+		sb.WriteString(" default:" + strconv.Quote(v))
+	}
+	if cm, ok := c.Comment(); ok {
+		sb.WriteString(" comment:" + strconv.Quote(cm))
+	}
+	if c.Enum != nil {
+		sb.WriteString(" enum:" + strconv.Quote(c.Enum.TypeName))
+		for _, v := range c.Enum.Values {
+			sb.WriteString("," + strconv.Quote(v))
+		}
+	}
+	indexes := make([]string, 0, len(c.Indexes))
+	for _, idx := range c.Indexes {
+		if idx == nil {
+			continue
+		}
+		uniq, _ := idx.Unique()
+		pk, _ := idx.PrimaryKey()
+		indexes = append(indexes, fmt.Sprintf(" index:%s,%d,%t,%t", strconv.Quote(idx.Name()), idx.Priority, uniq, pk))
+	}
+	sort.Strings(indexes)
+	for _, idx := range indexes {
+		sb.WriteString(idx)
+	}
+	return sb.String()
+}
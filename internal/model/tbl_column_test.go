@@ -74,3 +74,27 @@ func TestBuildGormTagIndexOrderDeterministic(t *testing.T) {
 		t.Fatalf("unexpected index order: got=%v want=%v", got, want)
 	}
 }
+
+func TestColumnSignature(t *testing.T) {
+	newIndex := func(name string, priority int32) *Index {
+		return &Index{Index: migrator.Index{NameValue: name, ColumnList: []string{"tenant_id"}}, Priority: priority}
+	}
+	col := func(columnType string, indexes ...*Index) *Column {
+		return &Column{
+			ColumnType: testColumnType{name: "tenant_id", databaseType: "varchar", columnType: columnType, nullable: true},
+			Indexes:    indexes,
+		}
+	}
+
+	a := col("varchar(32)", newIndex("idx_a", 1), newIndex("idx_b", 2)).Signature()
+	b := col("varchar(32)", newIndex("idx_b", 2), newIndex("idx_a", 1)).Signature()
+	if a != b {
+		t.Fatalf("expected signature to ignore index order:\n%s\n%s", a, b)
+	}
+	if c := col("varchar(64)", newIndex("idx_a", 1), newIndex("idx_b", 2)).Signature(); c == a {
+		t.Fatalf("expected signature to change with column type: %s", c)
+	}
+	if c := col("varchar(32)", newIndex("idx_a", 1)).Signature(); c == a {
+		t.Fatalf("expected signature to change with indexes: %s", c)
+	}
+}
@@ -0,0 +1,195 @@
+package model
+
+import (
+	"database/sql"
+	"reflect"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/migrator"
+)
+
+// ColumnSnapshot serializable copy of column metadata, used to cache table schema between runs
+type ColumnSnapshot struct {
+	Name          string          `json:"name"`
+	DatabaseType  string          `json:"database_type"`
+	ColumnType    *string         `json:"column_type,omitempty"`
+	PrimaryKey    *bool           `json:"primary_key,omitempty"`
+	AutoIncrement *bool           `json:"auto_increment,omitempty"`
+	Unique        *bool           `json:"unique,omitempty"`
+	Length        *int64          `json:"length,omitempty"`
+	Precision     *int64          `json:"precision,omitempty"`
+	Scale         *int64          `json:"scale,omitempty"`
+	Nullable      *bool           `json:"nullable,omitempty"`
+	Comment       *string         `json:"comment,omitempty"`
+	DefaultValue  *string         `json:"default_value,omitempty"`
+	ScanType      string          `json:"scan_type,omitempty"`
+	UseScanType   bool            `json:"use_scan_type,omitempty"`
+	Enum          *Enum           `json:"enum,omitempty"`
+	Indexes       []IndexSnapshot `json:"indexes,omitempty"`
+}
+
+// IndexSnapshot serializable copy of index metadata
+type IndexSnapshot struct {
+	Name       string   `json:"name"`
+	Columns    []string `json:"columns,omitempty"`
+	PrimaryKey *bool    `json:"primary_key,omitempty"`
+	Unique     *bool    `json:"unique,omitempty"`
+	Option     string   `json:"option,omitempty"`
+	Priority   int32    `json:"priority"`
+}
+
+// scanTypes scan types can be restored from snapshot
+var scanTypes = func() map[string]reflect.Type {
+	types := make(map[string]reflect.Type)
+	for _, v := range []interface{}{
+		false, "", []byte(nil), time.Time{},
+		int(0), int8(0), int16(0), int32(0), int64(0),
+		uint(0), uint8(0), uint16(0), uint32(0), uint64(0),
+		float32(0), float64(0),
+		sql.NullBool{}, sql.NullByte{}, sql.NullInt16{}, sql.NullInt32{}, sql.NullInt64{},
+		sql.NullFloat64{}, sql.NullString{}, sql.NullTime{}, sql.RawBytes(nil),
+	} {
+		t := reflect.TypeOf(v)
+		types[t.String()] = t
+	}
+	t := reflect.TypeOf((*interface{})(nil)).Elem()
+	types[t.String()] = t
+	return types
+}()
+
+// Snapshot copy column metadata, ok is false when column's scan type cannot be restored from snapshot
+func (c *Column) Snapshot() (s ColumnSnapshot, ok bool) {
+	s = ColumnSnapshot{Name: c.Name(), DatabaseType: c.DatabaseTypeName(), UseScanType: c.UseScanType, Enum: c.Enum}
+	if v, ok := c.ColumnType.ColumnType(); ok {
+		s.ColumnType = &v
+	}
+	if v, ok := c.PrimaryKey(); ok {
+		s.PrimaryKey = &v
+	}
+	if v, ok := c.AutoIncrement(); ok {
+		s.AutoIncrement = &v
+	}
+	if v, ok := c.Unique(); ok {
+		s.Unique = &v
+	}
+	if v, ok := c.Length(); ok {
+		s.Length = &v
+	}
+	if precision, scale, ok := c.DecimalSize(); ok {
+		s.Precision, s.Scale = &precision, &scale
+	}
+	if v, ok := c.Nullable(); ok {
+		s.Nullable = &v
+	}
+	if v, ok := c.Comment(); ok {
+		s.Comment = &v
+	}
+	if v, ok := c.DefaultValue(); ok {
+		s.DefaultValue = &v
+	}
+	if st := c.ScanType(); st != nil {
+		if _, ok := scanTypes[st.String()]; !ok {
+			return s, false
+		}
+		s.ScanType = st.String()
+	}
+	for _, idx := range c.Indexes {
+		if idx == nil {
+			continue
+		}
+		is := IndexSnapshot{Name: idx.Name(), Columns: idx.Columns(), Option: idx.Option(), Priority: idx.Priority}
+		if v, ok := idx.PrimaryKey(); ok {
+			is.PrimaryKey = &v
+		}
+		if v, ok := idx.Unique(); ok {
+			is.Unique = &v
+		}
+		s.Indexes = append(s.Indexes, is)
+	}
+	return s, true
+}
+
+// Restore rebuild column from snapshot, ok is false when scan type is unknown
+func (s ColumnSnapshot) Restore(tableName string) (c *Column, ok bool) {
+	ct := snapshotColumnType{ColumnSnapshot: s}
+	if s.ScanType != "" {
+		if ct.scanType, ok = scanTypes[s.ScanType]; !ok {
+			return nil, false
+		}
+	}
+	c = &Column{ColumnType: ct, TableName: tableName, UseScanType: s.UseScanType, Enum: s.Enum}
+	for _, is := range s.Indexes {
+		idx := migrator.Index{TableName: tableName, NameValue: is.Name, ColumnList: is.Columns, OptionValue: is.Option}
+		if is.PrimaryKey != nil {
+			idx.PrimaryKeyValue = sql.NullBool{Bool: *is.PrimaryKey, Valid: true}
+		}
+		if is.Unique != nil {
+			idx.UniqueValue = sql.NullBool{Bool: *is.Unique, Valid: true}
+		}
+		c.Indexes = append(c.Indexes, &Index{Index: idx, Priority: is.Priority})
+	}
+	return c, true
+}
+
+// snapshotColumnType gorm.ColumnType restored from snapshot
+type snapshotColumnType struct {
+	ColumnSnapshot
+	scanType reflect.Type
+}
+
+var _ gorm.ColumnType = snapshotColumnType{}
+
+func (t snapshotColumnType) Name() string { return t.ColumnSnapshot.Name }
+
+func (t snapshotColumnType) DatabaseTypeName() string { return t.DatabaseType }
+
+func (t snapshotColumnType) ColumnType() (string, bool) {
+	return stringValue(t.ColumnSnapshot.ColumnType)
+}
+
+func (t snapshotColumnType) PrimaryKey() (bool, bool) { return boolValue(t.ColumnSnapshot.PrimaryKey) }
+
+func (t snapshotColumnType) AutoIncrement() (bool, bool) {
+	return boolValue(t.ColumnSnapshot.AutoIncrement)
+}
+
+func (t snapshotColumnType) Length() (int64, bool) {
+	if t.ColumnSnapshot.Length == nil {
+		return 0, false
+	}
+	return *t.ColumnSnapshot.Length, true
+}
+
+func (t snapshotColumnType) DecimalSize() (precision int64, scale int64, ok bool) {
+	if t.Precision == nil || t.Scale == nil {
+		return 0, 0, false
+	}
+	return *t.Precision, *t.Scale, true
+}
+
+func (t snapshotColumnType) Nullable() (bool, bool) { return boolValue(t.ColumnSnapshot.Nullable) }
+
+func (t snapshotColumnType) Unique() (bool, bool) { return boolValue(t.ColumnSnapshot.Unique) }
+
+func (t snapshotColumnType) ScanType() reflect.Type { return t.scanType }
+
+func (t snapshotColumnType) Comment() (string, bool) { return stringValue(t.ColumnSnapshot.Comment) }
+
+func (t snapshotColumnType) DefaultValue() (string, bool) {
+	return stringValue(t.ColumnSnapshot.DefaultValue)
+}
+
+func stringValue(v *string) (string, bool) {
+	if v == nil {
+		return "", false
+	}
+	return *v, true
+}
+
+func boolValue(v *bool) (bool, bool) {
+	if v == nil {
+		return false, false
+	}
+	return *v, true
+}
@@ -0,0 +1,88 @@
+package model
+
+import (
+	"database/sql"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"gorm.io/gorm/migrator"
+)
+
+type customScanType struct{}
+
+func TestColumnSnapshotRestore(t *testing.T) {
+	col := &Column{
+		ColumnType: migrator.ColumnType{
+			NameValue:          sql.NullString{String: "amount", Valid: true},
+			DataTypeValue:      sql.NullString{String: "decimal", Valid: true},
+			ColumnTypeValue:    sql.NullString{String: "decimal(10,2)", Valid: true},
+			PrimaryKeyValue:    sql.NullBool{Valid: true},
+			AutoIncrementValue: sql.NullBool{Valid: true},
+			LengthValue:        sql.NullInt64{Valid: true},
+			DecimalSizeValue:   sql.NullInt64{Int64: 10, Valid: true},
+			ScaleValue:         sql.NullInt64{Int64: 2, Valid: true},
+			NullableValue:      sql.NullBool{Bool: true, Valid: true},
+			ScanTypeValue:      reflect.TypeOf(sql.NullFloat64{}),
+			CommentValue:       sql.NullString{String: "order amount", Valid: true},
+			DefaultValueValue:  sql.NullString{String: "0.00", Valid: true},
+		},
+		TableName:   "orders",
+		UseScanType: true,
+		Indexes: []*Index{{
+			Index:    migrator.Index{NameValue: "idx_amount", ColumnList: []string{"amount"}, UniqueValue: sql.NullBool{Valid: true}},
+			Priority: 1,
+		}},
+	}
+
+	s, ok := col.Snapshot()
+	if !ok {
+		t.Fatalf("expected column to be snapshotted")
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var loaded ColumnSnapshot
+	if err := json.Unmarshal(b, &loaded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	restored, ok := loaded.Restore("orders")
+	if !ok {
+		t.Fatalf("expected column to be restored from %s", b)
+	}
+	if restored.Signature() != col.Signature() {
+		t.Fatalf("signature mismatch:\n%s\n%s", restored.Signature(), col.Signature())
+	}
+	if restored.ScanType() != col.ScanType() || !restored.UseScanType || restored.TableName != "orders" {
+		t.Fatalf("unexpected restored column: %+v", restored)
+	}
+	if p, s, ok := restored.DecimalSize(); !ok || p != 10 || s != 2 {
+		t.Fatalf("unexpected decimal size: %d,%d,%t", p, s, ok)
+	}
+
+	col.WithNS(nil)
+	restored.WithNS(nil)
+	want, got := col.ToField(true, true, true, false), restored.ToField(true, true, true, false)
+	if got.Type != want.Type || got.GORMTag.Build() != want.GORMTag.Build() {
+		t.Fatalf("unexpected field: %s %s, want %s %s", got.Type, got.GORMTag.Build(), want.Type, want.GORMTag.Build())
+	}
+}
+
+func TestColumnSnapshotUnknownScanType(t *testing.T) {
+	col := &Column{ColumnType: migrator.ColumnType{
+		NameValue:        sql.NullString{String: "payload", Valid: true},
+		DataTypeValue:    sql.NullString{String: "json", Valid: true},
+		LengthValue:      sql.NullInt64{Valid: true},
+		DecimalSizeValue: sql.NullInt64{Valid: true},
+		NullableValue:    sql.NullBool{Valid: true},
+		ScanTypeValue:    reflect.TypeOf(customScanType{}),
+	}}
+	if _, ok := col.Snapshot(); ok {
+		t.Fatalf("expected column with unknown scan type not to be snapshotted")
+	}
+	if _, ok := (ColumnSnapshot{Name: "payload", ScanType: "model.customScanType"}).Restore("t"); ok {
+		t.Fatalf("expected snapshot with unknown scan type not to be restored")
+	}
+}
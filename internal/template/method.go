@@ -11,13 +11,15 @@ func ({{.S}} {{.TargetStruct}}Do){{.FuncSign}}{
 	{{range $line:=.Section.Tmpls}}{{$line}}
 	{{end}}
 
+	_do, _done := {{.S}}.Observe("{{.InterfaceName}}", "{{.MethodName}}")
+	{{if .ReturnError}}defer func() { err = _done(err) }(){{else}}defer _done(nil){{end}}
+
 	{{if .HasNeedNewResult}}result ={{if .ResultData.IsMap}}make{{else}}new{{end}}({{if ne .ResultData.Package ""}}{{.ResultData.Package}}.{{end}}{{.ResultData.Type}}){{end}}
-	{{if .ReturnSQLResult}}stmt := {{.S}}.UnderlyingDB().Statement
-	result,{{if .ReturnError}}err{{else}}_{{end}} = stmt.ConnPool.ExecContext(stmt.Context,generateSQL.String(){{if .HasSQLData}},params...{{end}}) // ignore_security_alert
-	{{else if .ReturnSQLRow}}row = {{.S}}.UnderlyingDB().Raw(generateSQL.String(){{if .HasSQLData}},params...{{end}}).Row() // ignore_security_alert
-	{{else if .ReturnSQLRows}}rows,{{if .ReturnError}}err{{else}}_{{end}} = {{.S}}.UnderlyingDB().Raw(generateSQL.String(){{if .HasSQLData}},params...{{end}}).Rows() // ignore_security_alert
+	{{if .ReturnSQLResult}}result,{{if .ReturnError}}err{{else}}_{{end}} = _do.Clauses({{.DBResolver}}).(*gen.DO).ExecResult(generateSQL.String(){{if .HasSQLData}},params...{{end}}) // ignore_security_alert
+	{{else if .ReturnSQLRow}}row = _do.UnderlyingDB().Clauses({{.DBResolver}}).Raw(generateSQL.String(){{if .HasSQLData}},params...{{end}}).Row() // ignore_security_alert
+	{{else if .ReturnSQLRows}}rows,{{if .ReturnError}}err{{else}}_{{end}} = _do.UnderlyingDB().Clauses({{.DBResolver}}).Raw(generateSQL.String(){{if .HasSQLData}},params...{{end}}).Rows() // ignore_security_alert
 	{{else}}var executeSQL *gorm.DB
-	executeSQL = {{.S}}.UnderlyingDB().{{.GormOption}}(generateSQL.String(){{if .HasSQLData}},params...{{end}}){{if not .ResultData.IsNull}}.{{.GormRunMethodName}}({{if .HasGotPoint}}&{{end}}{{.ResultData.Name}}){{end}}  // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses({{.DBResolver}}).{{.GormOption}}(generateSQL.String(){{if .HasSQLData}},params...{{end}}){{if not .ResultData.IsNull}}.{{.GormRunMethodName}}({{if .HasGotPoint}}&{{end}}{{.ResultData.Name}}){{end}}  // ignore_security_alert
 	{{if .ReturnRowsAffected}}rowsAffected = executeSQL.RowsAffected
 	{{end}}{{if .ReturnError}}err = executeSQL.Error
 	{{end}}{{if .ReturnNothing}}_ = executeSQL
@@ -37,6 +39,19 @@ func ({{.S}} *{{.QueryStructName}}Do) withDO(do gen.Dao) {{.ReturnObject}} {
 }
 `
 
+// VersionMethod optimistic locking method of model with version column
+const VersionMethod = `
+{{- if .VersionField}}
+func ({{.S}} {{.QueryStructName}}Do) UpdateWithVersion(value *{{.StructInfo.Package}}.{{.StructInfo.Type}}) (info gen.ResultInfo, err error) {
+	return {{.S}}.DO.UpdateWithVersion("{{.VersionField.ColumnName}}", value)
+}
+
+func ({{.S}} {{.QueryStructName}}Do) UpdatesWithVersion(value *{{.StructInfo.Package}}.{{.StructInfo.Type}}, columns ...field.AssignExpr) (info gen.ResultInfo, err error) {
+	return {{.S}}.DO.UpdatesWithVersion("{{.VersionField.ColumnName}}", value, columns...)
+}
+{{end}}
+`
+
 // CRUDMethod CRUD method
 const CRUDMethod = `
 func ({{.S}} {{.QueryStructName}}Do) Debug() {{.ReturnObject}} {
@@ -107,6 +122,14 @@ func ({{.S}} {{.QueryStructName}}Do) RightJoin(table schema.Tabler, on ...field.
 	return {{.S}}.withDO({{.S}}.DO.RightJoin(table, on...))
 }
 
+func ({{.S}} {{.QueryStructName}}Do) With(name string, q gen.SubQuery) {{.ReturnObject}} {
+	return {{.S}}.withDO({{.S}}.DO.With(name, q))
+}
+
+func ({{.S}} {{.QueryStructName}}Do) WithRecursive(name string, q gen.SubQuery) {{.ReturnObject}} {
+	return {{.S}}.withDO({{.S}}.DO.WithRecursive(name, q))
+}
+
 func ({{.S}} {{.QueryStructName}}Do) Group(cols ...field.Expr) {{.ReturnObject}} {
 	return {{.S}}.withDO({{.S}}.DO.Group(cols...))
 }
@@ -131,6 +154,31 @@ func ({{.S}} {{.QueryStructName}}Do) Unscoped() {{.ReturnObject}} {
 	return {{.S}}.withDO({{.S}}.DO.Unscoped())
 }
 
+func ({{.S}} {{.QueryStructName}}Do) CrossTenant() {{.ReturnObject}} {
+	return {{.S}}.withDO({{.S}}.DO.CrossTenant())
+}
+
+func ({{.S}} {{.QueryStructName}}Do) OnConflict(cols ...field.Expr) gen.Upsert[{{.ReturnObject}}] {
+	return gen.NewUpsert(&{{.S}}.DO, func(do gen.Dao) {{.ReturnObject}} { return {{.S}}.withDO(do) }, cols...)
+}
+
+{{- if .HasSoftDelete}}
+func ({{.S}} {{.QueryStructName}}Do) WithDeleted() {{.ReturnObject}} {
+	return {{.S}}.withDO({{.S}}.DO.WithDeleted())
+}
+
+func ({{.S}} {{.QueryStructName}}Do) OnlyDeleted() {{.ReturnObject}} {
+	return {{.S}}.withDO({{.S}}.DO.OnlyDeleted())
+}
+
+func ({{.S}} {{.QueryStructName}}Do) Restore() (info gen.ResultInfo, err error) {
+	return {{.S}}.DO.Restore()
+}
+
+func ({{.S}} {{.QueryStructName}}Do) ForceDelete(models ...*{{.StructInfo.Package}}.{{.StructInfo.Type}}) (info gen.ResultInfo, err error) {
+	return {{.S}}.DO.ForceDelete(models)
+}
+{{end}}
 func ({{.S}} {{.QueryStructName}}Do) Create(values ...*{{.StructInfo.Package}}.{{.StructInfo.Type}}) error {
 	if len(values) == 0 {
 		return nil
@@ -193,6 +241,10 @@ func ({{.S}} {{.QueryStructName}}Do) FindInBatches(result *[]*{{.StructInfo.Pack
 	return {{.S}}.DO.FindInBatches(result, batchSize, fc)
 }
 
+func ({{.S}} {{.QueryStructName}}Do) Iter() *gen.Iterator[*{{.StructInfo.Package}}.{{.StructInfo.Type}}] {
+	return gen.NewIterator[*{{.StructInfo.Package}}.{{.StructInfo.Type}}](&{{.S}}.DO)
+}
+
 func ({{.S}} {{.QueryStructName}}Do) Attrs(attrs ...field.AssignExpr) {{.ReturnObject}} {
 	return {{.S}}.withDO({{.S}}.DO.Attrs(attrs...))
 }
@@ -246,6 +298,14 @@ func ({{.S}} {{.QueryStructName}}Do) FindByPage(offset int, limit int) (result [
 	return
 }
 
+func ({{.S}} {{.QueryStructName}}Do) FindByCursor(cursor string, limit int, orderCols ...field.OrderExpr) (result []*{{.StructInfo.Package}}.{{.StructInfo.Type}}, next string, prev string, err error) {
+	results, next, prev, err := {{.S}}.DO.FindByCursor(cursor, limit, orderCols...)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return results.([]*{{.StructInfo.Package}}.{{.StructInfo.Type}}), next, prev, nil
+}
+
 func ({{.S}} {{.QueryStructName}}Do) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
 	count, err = {{.S}}.Count()
 	if err != nil {
@@ -308,6 +368,11 @@ func Test_{{.QueryStructName}}Query(t *testing.T) {
 		t.Error("create item in table <{{.TableName}}> fail:", err)
 	}
 
+	err = _do.OnConflict().DoNothing().Create(&{{.StructInfo.Package}}.{{.ModelStructName}}{})
+	if err != nil {
+		t.Error("OnConflict() create item in table <{{.TableName}}> fail:", err)
+	}
+
 {{ if not .HasUniqueIndex }}
 	err = _do.CreateInBatches([]*{{.StructInfo.Package}}.{{.ModelStructName}}{ {}, {} }, 10)
 	if err != nil {
@@ -339,6 +404,13 @@ func Test_{{.QueryStructName}}Query(t *testing.T) {
 		t.Error("FindInBatches() on table <{{.TableName}}> fail:", err)
 	}
 
+	it := _do.Where(primaryKey.IsNotNull()).Iter()
+	for it.Next() {
+	}
+	if err = it.Err(); err != nil {
+		t.Error("Iter() on table <{{.TableName}}> fail:", err)
+	}
+
 	_, err = _do.Select({{.QueryStructName}}.ALL).Where(primaryKey.IsNotNull()).Order(primaryKey.Desc()).Find()
 	if err != nil {
 		t.Error("Find() on table <{{.TableName}}> fail:", err)
@@ -374,6 +446,11 @@ func Test_{{.QueryStructName}}Query(t *testing.T) {
 		t.Error("ScanByPage() on table <{{.TableName}}> fail:", err)
 	}
 
+	_, _, _, err = _do.FindByCursor("", 1, primaryKey)
+	if err != nil {
+		t.Error("FindByCursor() on table <{{.TableName}}> fail:", err)
+	}
+
 	_, err = _do.Attrs(primaryKey).Assign(primaryKey).FirstOrInit()
 	if err != nil {
 		t.Error("FirstOrInit() on table <{{.TableName}}> fail:", err)
@@ -401,6 +478,53 @@ func Test_{{.QueryStructName}}Query(t *testing.T) {
 	if err != nil {
 		t.Error("Not/Or/Clauses on table <{{.TableName}}> fail:", err)
 	}
+{{- if .VersionField}}
+
+	_versioned := &{{.StructInfo.Package}}.{{.ModelStructName}}{}
+	if err = _do.Create(_versioned); err == nil {
+		_stale := *_versioned
+		_, err = _do.UpdateWithVersion(_versioned)
+		if err != nil {
+			t.Error("UpdateWithVersion() on table <{{.TableName}}> fail:", err)
+		}
+		_, err = _do.UpdatesWithVersion(&_stale)
+		if err != gen.ErrStaleObject {
+			t.Error("UpdatesWithVersion() of stale item on table <{{.TableName}}> should fail with ErrStaleObject, got:", err)
+		}
+	}
+{{- end}}
+{{- if .HasSoftDelete}}
+
+	_, err = _do.Where(primaryKey.IsNotNull()).Delete()
+	if err != nil {
+		t.Error("soft Delete() on table <{{.TableName}}> fail:", err)
+	}
+
+	_, err = _do.OnlyDeleted().Take()
+	if err != nil {
+		t.Error("OnlyDeleted() on table <{{.TableName}}> fail:", err)
+	}
+
+	_, err = _do.OnlyDeleted().Where(primaryKey.IsNotNull()).Restore()
+	if err != nil {
+		t.Error("Restore() on table <{{.TableName}}> fail:", err)
+	}
+
+	_, err = _do.Take()
+	if err != nil {
+		t.Error("Take() restored item on table <{{.TableName}}> fail:", err)
+	}
+
+	_, err = _do.Where(primaryKey.IsNotNull()).ForceDelete()
+	if err != nil {
+		t.Error("ForceDelete() on table <{{.TableName}}> fail:", err)
+	}
+
+	_, err = _do.WithDeleted().Take()
+	if err == nil {
+		t.Error("WithDeleted() on table <{{.TableName}}> should find no force deleted item")
+	}
+{{- end}}
 }
 `
 
@@ -5,7 +5,9 @@ const Model = NotEditMark + `
 package {{.StructInfo.Package}}
 
 import (
+	"database/sql/driver"
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"gorm.io/datatypes"
@@ -35,6 +37,56 @@ type {{.ModelStructName}} struct {
 
 `
 
+// ModelEnum enum type of enum column
+const ModelEnum = `
+
+// {{.Name}} {{.Comment}}
+type {{.Name}} string
+
+const (
+	{{range .Values}}{{.Name}} {{$.Name}} = {{printf "%q" .Value}}
+	{{end}}
+)
+
+// {{.Name}}Values all values of {{.Name}}
+var {{.Name}}Values = []string{ {{- range $i, $v := .Values}}{{if $i}}, {{end}}{{printf "%q" $v.Value}}{{end -}} }
+
+// Valid report whether value is one of {{.Name}} constants
+func (e {{.Name}}) Valid() bool {
+	switch e {
+	case {{range $i, $v := .Values}}{{if $i}}, {{end}}{{$v.Name}}{{end}}:
+		return true
+	}
+	return false
+}
+
+// String implements fmt.Stringer interface
+func (e {{.Name}}) String() string { return string(e) }
+
+// Scan implements sql.Scanner interface
+func (e *{{.Name}}) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*e = ""
+	case string:
+		*e = {{.Name}}(v)
+	case []byte:
+		*e = {{.Name}}(v)
+	default:
+		return fmt.Errorf("cannot scan %T into {{.Name}}", value)
+	}
+	return nil
+}
+
+// Value implements driver.Valuer interface, invalid value is refused except zero value which is written as empty string
+func (e {{.Name}}) Value() (driver.Value, error) {
+	if e != "" && !e.Valid() {
+		return nil, fmt.Errorf("invalid {{.Name}} value %q", string(e))
+	}
+	return string(e), nil
+}
+`
+
 // ModelMethod model struct DIY method
 const ModelMethod = `
 
@@ -51,6 +51,7 @@ const (
 		{{end}}
 		_{{.QueryStructName}}.{{.QueryStructName}}Do.UseDB(db,opts...)
 		_{{.QueryStructName}}.{{.QueryStructName}}Do.UseModel(&{{.StructInfo.Package}}.{{.StructInfo.Type}}{})
+		_{{.QueryStructName}}.{{.QueryStructName}}Do.UseQueryName("{{.QueryStructName}}")
 	
 		tableName := _{{.QueryStructName}}.{{.QueryStructName}}Do.TableName()
 		_{{$.QueryStructName}}.ALL = field.NewAsterisk(tableName)
@@ -159,6 +160,16 @@ func ({{.S}} *{{.QueryStructName}}) fillFieldMap() {
 	defineGenericsDoInterface = `
 type I{{.ModelStructName}}Do interface {
 	gen.IGenericsDo[I{{.ModelStructName}}Do, *{{.StructInfo.Package}}.{{.StructInfo.Type}}]
+	{{- if .HasSoftDelete}}
+	WithDeleted() I{{.ModelStructName}}Do
+	OnlyDeleted() I{{.ModelStructName}}Do
+	Restore() (info gen.ResultInfo, err error)
+	ForceDelete(...*{{.StructInfo.Package}}.{{.StructInfo.Type}}) (info gen.ResultInfo, err error)
+	{{- end}}
+	{{- if .VersionField}}
+	UpdateWithVersion(value *{{.StructInfo.Package}}.{{.StructInfo.Type}}) (info gen.ResultInfo, err error)
+	UpdatesWithVersion(value *{{.StructInfo.Package}}.{{.StructInfo.Type}}, columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	{{- end}}
 	{{range .Interfaces -}}
 	{{.FuncSign}}
 	{{end}}
@@ -188,6 +199,8 @@ type I{{.ModelStructName}}Do interface {
 	Join(table schema.Tabler, on ...field.Expr) I{{.ModelStructName}}Do
 	LeftJoin(table schema.Tabler, on ...field.Expr) I{{.ModelStructName}}Do
 	RightJoin(table schema.Tabler, on ...field.Expr) I{{.ModelStructName}}Do
+	With(name string, q gen.SubQuery) I{{.ModelStructName}}Do
+	WithRecursive(name string, q gen.SubQuery) I{{.ModelStructName}}Do
 	Group(cols ...field.Expr) I{{.ModelStructName}}Do
 	Having(conds ...gen.Condition) I{{.ModelStructName}}Do
 	Limit(limit int) I{{.ModelStructName}}Do
@@ -195,6 +208,14 @@ type I{{.ModelStructName}}Do interface {
 	Count() (count int64, err error)
 	Scopes(funcs ...func(gen.Dao) gen.Dao) I{{.ModelStructName}}Do
 	Unscoped() I{{.ModelStructName}}Do
+	CrossTenant() I{{.ModelStructName}}Do
+	{{- if .HasSoftDelete}}
+	WithDeleted() I{{.ModelStructName}}Do
+	OnlyDeleted() I{{.ModelStructName}}Do
+	Restore() (info gen.ResultInfo, err error)
+	ForceDelete(...*{{.StructInfo.Package}}.{{.StructInfo.Type}}) (info gen.ResultInfo, err error)
+	{{- end}}
+	OnConflict(cols ...field.Expr) gen.Upsert[I{{.ModelStructName}}Do]
 	Create(values ...*{{.StructInfo.Package}}.{{.StructInfo.Type}}) error
 	CreateInBatches(values []*{{.StructInfo.Package}}.{{.StructInfo.Type}}, batchSize int) error
 	Save(values ...*{{.StructInfo.Package}}.{{.StructInfo.Type}}) error
@@ -204,6 +225,7 @@ type I{{.ModelStructName}}Do interface {
 	Find() ([]*{{.StructInfo.Package}}.{{.StructInfo.Type}}, error)
 	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*{{.StructInfo.Package}}.{{.StructInfo.Type}}, err error)
 	FindInBatches(result *[]*{{.StructInfo.Package}}.{{.StructInfo.Type}}, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Iter() *gen.Iterator[*{{.StructInfo.Package}}.{{.StructInfo.Type}}]
 	Pluck(column field.Expr, dest interface{}) error
 	Delete(...*{{.StructInfo.Package}}.{{.StructInfo.Type}}) (info gen.ResultInfo, err error)
 	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
@@ -211,6 +233,10 @@ type I{{.ModelStructName}}Do interface {
 	Updates(value interface{}) (info gen.ResultInfo, err error)
 	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
 	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	{{- if .VersionField}}
+	UpdateWithVersion(value *{{.StructInfo.Package}}.{{.StructInfo.Type}}) (info gen.ResultInfo, err error)
+	UpdatesWithVersion(value *{{.StructInfo.Package}}.{{.StructInfo.Type}}, columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	{{- end}}
 	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
 	UpdateFrom(q gen.SubQuery) gen.Dao
 	Attrs(attrs ...field.AssignExpr) I{{.ModelStructName}}Do
@@ -221,6 +247,7 @@ type I{{.ModelStructName}}Do interface {
 	FirstOrCreate() (*{{.StructInfo.Package}}.{{.StructInfo.Type}}, error)
 	FindByPage(offset int, limit int) (result []*{{.StructInfo.Package}}.{{.StructInfo.Type}}, count int64, err error)
 	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	FindByCursor(cursor string, limit int, orderCols ...field.OrderExpr) (result []*{{.StructInfo.Package}}.{{.StructInfo.Type}}, next string, prev string, err error)
 	Rows() (*sql.Rows, error)
 	Row() *sql.Row
 	Scan(result interface{}) (err error)
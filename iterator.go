@@ -0,0 +1,89 @@
+package gen
+
+import (
+	"database/sql"
+	"reflect"
+
+	"gorm.io/gorm"
+)
+
+// Iterator typed cursor iterating query result row by row with constant memory,
+// query is executed at the first call of Next, rows are closed when iteration ends or Close is called.
+// Iteration is observed as one operation from the first Next to Close, see WithObserver
+//
+//	it := u.Where(u.Age.Gt(18)).Iter()
+//	defer it.Close()
+//	for it.Next() {
+//		user := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type Iterator[E any] struct {
+	do    *DO
+	db    *gorm.DB
+	rows  *sql.Rows
+	value E
+	err   error
+	done  bool
+
+	observed func(error) error // end observing of iteration, set when query is executed
+}
+
+// NewIterator create iterator of query, each row is scanned into new instance of E, like *model.User
+func NewIterator[E any](do *DO) *Iterator[E] {
+	return &Iterator[E]{do: do}
+}
+
+// Next scan next row, return false when there are no more rows, error occurs or context is canceled
+func (it *Iterator[E]) Next() bool {
+	if it.done {
+		return false
+	}
+	if it.rows == nil {
+		do, done := it.do.Observe("", "Iter")
+		it.db, it.observed = do.db, done
+		if it.rows, it.err = it.db.Rows(); it.err != nil {
+			_ = it.Close()
+			return false
+		}
+	}
+	if it.err = it.db.Statement.Context.Err(); it.err != nil || !it.rows.Next() {
+		if it.err == nil {
+			it.err = it.rows.Err()
+		}
+		_ = it.Close()
+		return false
+	}
+
+	var value E
+	dest := interface{}(&value)
+	if t := reflect.TypeOf(value); t != nil && t.Kind() == reflect.Ptr {
+		dest = reflect.New(t.Elem()).Interface()
+		value = dest.(E)
+	}
+	if it.err = it.db.ScanRows(it.rows, dest); it.err != nil {
+		_ = it.Close()
+		return false
+	}
+	it.value = value
+	return true
+}
+
+// Value current row scanned by Next
+func (it *Iterator[E]) Value() E { return it.value }
+
+// Err error occurred during iteration
+func (it *Iterator[E]) Err() error { return it.err }
+
+// Close close rows and end observing of iteration, it's safe to call Close multiple times
+func (it *Iterator[E]) Close() (err error) {
+	it.done = true
+	if it.rows != nil {
+		err = it.rows.Close()
+	}
+	if observed := it.observed; observed != nil {
+		it.observed = nil
+		_ = observed(it.err)
+	}
+	return err
+}
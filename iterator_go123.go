@@ -0,0 +1,24 @@
+//go:build go1.23
+
+package gen
+
+import "iter"
+
+// All iterate rows as iter.Seq2, rows are closed when loop ends
+//
+//	for user, err := range u.Where(u.Age.Gt(18)).Iter().All() {
+//	}
+func (it *Iterator[E]) All() iter.Seq2[E, error] {
+	return func(yield func(E, error) bool) {
+		defer it.Close()
+		for it.Next() {
+			if !yield(it.Value(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			var zero E
+			yield(zero, err)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+//go:build go1.23
+
+package gen
+
+import "testing"
+
+func TestIterator_All(t *testing.T) {
+	var ids []uint
+	NewIterator[*iterModel](newIterDO(t)).All()(func(row *iterModel, err error) bool {
+		if err != nil {
+			t.Fatalf("iterate fail: %v", err)
+		}
+		ids = append(ids, row.ID)
+		return len(ids) < 2 // break after the second row
+	})
+	if len(ids) != 2 || ids[1] != 2 {
+		t.Errorf("unexpected rows: %v", ids)
+	}
+}
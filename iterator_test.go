@@ -0,0 +1,123 @@
+package gen
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/utils/tests"
+)
+
+// iterDriver database/sql driver returning rows of iterRows for any query
+type iterDriver struct{}
+
+func (iterDriver) Open(string) (driver.Conn, error) { return iterConn{}, nil }
+
+type iterConn struct{}
+
+func (iterConn) Prepare(string) (driver.Stmt, error) { return iterStmt{}, nil }
+func (iterConn) Close() error                        { return nil }
+func (iterConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+type iterStmt struct{}
+
+func (iterStmt) Close() error                               { return nil }
+func (iterStmt) NumInput() int                              { return -1 }
+func (iterStmt) Exec([]driver.Value) (driver.Result, error) { return nil, driver.ErrSkip }
+func (iterStmt) Query([]driver.Value) (driver.Rows, error)  { return &iterRows{}, nil }
+
+var iterData = [][]driver.Value{{int64(1), "alice"}, {int64(2), "bob"}, {int64(3), "carol"}}
+
+type iterRows struct{ i int }
+
+func (*iterRows) Columns() []string { return []string{"id", "name"} }
+func (*iterRows) Close() error      { return nil }
+func (r *iterRows) Next(dest []driver.Value) error {
+	if r.i >= len(iterData) {
+		return io.EOF
+	}
+	copy(dest, iterData[r.i])
+	r.i++
+	return nil
+}
+
+func init() { sql.Register("gen_iter", iterDriver{}) }
+
+type iterModel struct {
+	ID   uint
+	Name string
+}
+
+func newIterDO(t *testing.T, opts ...DOOption) *DO {
+	sqlDB, err := sql.Open("gen_iter", "")
+	if err != nil {
+		t.Fatalf("open sql db: %v", err)
+	}
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{ConnPool: sqlDB})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	var d DO
+	d.UseDB(db.Session(&gorm.Session{NewDB: true}), opts...)
+	d.UseModel(iterModel{})
+	return &d
+}
+
+func TestIterator(t *testing.T) {
+	it := NewIterator[*iterModel](newIterDO(t))
+	var names []string
+	for it.Next() {
+		names = append(names, it.Value().Name)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("iterate fail: %v", err)
+	}
+	if len(names) != 3 || names[0] != "alice" || names[2] != "carol" {
+		t.Errorf("unexpected rows: %v", names)
+	}
+	if it.Next() {
+		t.Errorf("iterator should be done")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d := newIterDO(t)
+	it = NewIterator[*iterModel](d.WithContext(ctx).underlyingDO())
+	if !it.Next() || it.Value().ID != 1 {
+		t.Fatalf("unexpected first row: %+v, err: %v", it.Value(), it.Err())
+	}
+	cancel()
+	if it.Next() || it.Err() != context.Canceled {
+		t.Errorf("expect context canceled, got %v", it.Err())
+	}
+}
+
+func TestIterator_Observe(t *testing.T) {
+	var started, events []QueryEvent
+	do := newIterDO(t,
+		WithStartObserver(func(ctx context.Context, event QueryEvent) context.Context {
+			started = append(started, event)
+			return ctx
+		}),
+		WithObserver(func(ctx context.Context, event QueryEvent) { events = append(events, event) }),
+	)
+
+	it := NewIterator[*iterModel](do)
+	if len(started) != 0 {
+		t.Fatalf("expect iteration to be observed from first Next, got %+v", started)
+	}
+	if !it.Next() || len(started) != 1 || started[0].Method != "Iter" {
+		t.Fatalf("expect start event of Iter, got %+v", started)
+	}
+	if len(events) != 0 {
+		t.Fatalf("expect observation to end when iterator is closed, got %+v", events)
+	}
+	for it.Next() {
+	}
+	_ = it.Close()
+	if len(events) != 1 || events[0].Method != "Iter" || events[0].SQL != "SELECT * FROM `iter_models`" || events[0].Error != nil {
+		t.Fatalf("expect one event of Iter, got %+v", events)
+	}
+}
@@ -5,23 +5,36 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
+	"sync"
+
+	"gorm.io/gen/internal/diagnostic"
+	"gorm.io/gen/internal/generate"
+	tmpl "gorm.io/gen/internal/template"
 )
 
 const manifestFileName = ".genmanifest.json"
 
 type genManifest struct {
-	Version int `json:"version"`
-	Mode    uint `json:"mode"`
-	Tables  map[string]genManifestTable `json:"tables,omitempty"`
-	Files   map[string]string          `json:"files,omitempty"`
+	Version int                          `json:"version"`
+	Mode    uint                         `json:"mode"`
+	Tables  map[string]genManifestTable  `json:"tables,omitempty"`
+	Files   map[string]string            `json:"files,omitempty"`
+	Schemas map[string]genManifestSchema `json:"schemas,omitempty"` // table schemas keyed by table name, see schemaCache
+
+	generated map[string]struct{} // files generated in current run
 }
 
 type genManifestTable struct {
 	ModelStructName string `json:"model_struct_name"`
 	QueryStructName string `json:"query_struct_name"`
+	TableName       string `json:"table_name,omitempty"`
 	FileName        string `json:"file_name"`
+	Schema          string `json:"schema,omitempty"` // fingerprint of model schema inputs, see modelFingerprint
 }
 
 func loadManifest(dir string) (*genManifest, string, error) {
@@ -49,16 +62,142 @@ func loadManifest(dir string) (*genManifest, string, error) {
 	return &m, path, nil
 }
 
-func saveManifest(path string, m *genManifest) error {
+func (g *Generator) saveManifest(path string, m *genManifest) error {
+	if g.dryRun != nil { // manifest is bookkeeping of generation, not a change of generated code
+		return nil
+	}
 	b, err := json.MarshalIndent(m, "", "  ")
 	if err != nil {
 		return err
 	}
 	b = append(b, '\n')
-	return os.WriteFile(path, b, 0640)
+	return g.writeFile(path, b)
 }
 
 func sha256Hex(b []byte) string {
 	sum := sha256.Sum256(b)
 	return hex.EncodeToString(sum[:])
 }
+
+// fileHash return sha256 of file content, empty if file can not be read
+func fileHash(fileName string) string {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return ""
+	}
+	return sha256Hex(content)
+}
+
+func (m *genManifest) markGenerated(key string) {
+	if m.generated == nil {
+		m.generated = make(map[string]struct{})
+	}
+	m.generated[key] = struct{}{}
+}
+
+// pruneManifestFiles delete files recorded in manifest but not generated in current run,
+// files whose content no longer match the recorded hash were edited by hand and are kept
+func (g *Generator) pruneManifestFiles(dir string, m *genManifest, protected map[string]bool) error {
+	keys := make([]string, 0, len(m.Files))
+	for key := range m.Files {
+		if _, ok := m.generated[key]; ok || protected[key] {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs diagnostic.List
+	for _, key := range keys {
+		fileName := filepath.Join(dir, filepath.FromSlash(key))
+		content, err := os.ReadFile(fileName)
+		if errors.Is(err, os.ErrNotExist) {
+			delete(m.Files, key)
+			continue
+		}
+		if err != nil {
+			errs = errs.Append(genError(err, diagnostic.CodePrune, fileName, ""), diagnostic.CodePrune)
+			continue
+		}
+		if sha256Hex(content) != m.Files[key] {
+			err = fmt.Errorf("refuse to delete stale generated file %s: content was modified after generation", fileName)
+			errs = errs.Append(genError(err, diagnostic.CodePrune, fileName, m.tableOfFile(key)), diagnostic.CodePrune)
+			continue
+		}
+		if err := g.removeFile(fileName); err != nil {
+			errs = errs.Append(genError(err, diagnostic.CodePrune, fileName, ""), diagnostic.CodePrune)
+			continue
+		}
+		delete(m.Files, key)
+		g.info("prune stale generated file: " + fileName)
+	}
+	return errs.Err()
+}
+
+// tableOfFile return table name recorded for generated file
+func (m *genManifest) tableOfFile(key string) string {
+	for _, t := range m.Tables {
+		if name := path.Base(key); name == t.FileName+".gen.go" || name == t.FileName+".gen_test.go" {
+			return t.TableName
+		}
+	}
+	return ""
+}
+
+// editedByHand report whether file on disk no longer match the hash recorded at generation
+func editedByHand(fileName, hash string) bool {
+	current := fileHash(fileName)
+	return current != "" && current != hash
+}
+
+// generatedFileIntact report whether an incremental skip may keep the file on disk,
+// content is only compared when EditProtection asks to watch for hand edits
+func (g *Generator) generatedFileIntact(fileName, hash string) bool {
+	if g.EditProtection == EditOverwrite {
+		_, err := os.Stat(fileName)
+		return err == nil
+	}
+	return fileHash(fileName) == hash
+}
+
+// protectEditedFile handle generated content of a hand-edited file according to EditProtection
+func (g *Generator) protectEditedFile(fileName string, content []byte) error {
+	switch g.EditProtection {
+	case EditWriteNew:
+		newFile := fileName + ".new"
+		if err := g.writeFile(newFile, content); err != nil {
+			return err
+		}
+		g.info(fmt.Sprintf("generated file %s was edited by hand, write new version to %s", fileName, newFile))
+		return nil
+	default:
+		e := diagnostic.New(diagnostic.CodeHandEdited, fmt.Sprintf("generated file %s was edited by hand, refuse to overwrite", fileName))
+		e.Diag.File = fileName
+		return e
+	}
+}
+
+// modelFingerprint digest of model templates and everything the model file is rendered from
+func modelFingerprint(data *generate.QueryStructMeta) string {
+	return sha256Hex([]byte(tmpl.Model + tmpl.ModelMethod + tmpl.ModelEnum + data.ModelFingerprint()))
+}
+
+// modelUnchanged report whether model file was generated from the same schema and options in last run
+func (g *Generator) modelUnchanged(data *generate.QueryStructMeta, fingerprint, modelFile string, m *genManifest, mu *sync.Mutex) bool {
+	if m == nil {
+		return false
+	}
+	key := modelFileKey(data)
+
+	mu.Lock()
+	defer mu.Unlock()
+	prev, ok := m.Tables[data.ModelStructName]
+	if !ok || prev.Schema != fingerprint || prev.FileName != data.FileName {
+		return false
+	}
+	if hash, ok := m.Files[key]; !ok || !g.generatedFileIntact(modelFile, hash) {
+		return false
+	}
+	m.markGenerated(key)
+	return true
+}
@@ -0,0 +1,145 @@
+package gen
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	queryRecordSettingKey = "gen:query_record"
+	observeCallbackName   = "gen:observe"
+)
+
+// QueryEvent telemetry of a terminal operation of DO
+type QueryEvent struct {
+	Model        string // model struct name, which generated query struct is named after
+	Query        string // generated query struct name, like user of model User
+	Table        string
+	Interface    string // interface name of DIY method, empty for CRUD methods
+	Method       string // caller-facing method name, like First, Updates or name of DIY method
+	SQL          string // last SQL built by the operation
+	Vars         []interface{}
+	Duration     time.Duration
+	RowsAffected int64
+	Error        error
+}
+
+// QueryObserver observe terminal operations of DO
+type QueryObserver func(ctx context.Context, event QueryEvent)
+
+// QueryStartObserver observe start of terminal operations of DO, event has no SQL or result yet.
+// Returned context is used by statements of the operation and passed to QueryObserver after it is done,
+// e.g. start a tracing span before the operation and end it after.
+type QueryStartObserver func(ctx context.Context, event QueryEvent) context.Context
+
+type observerOption struct {
+	observer QueryObserver
+	start    QueryStartObserver
+}
+
+func (o observerOption) Apply(cfg *DOConfig) error {
+	if o.observer != nil {
+		cfg.Observers = append(cfg.Observers, o.observer)
+	}
+	if o.start != nil {
+		cfg.StartObservers = append(cfg.StartObservers, o.start)
+	}
+	return nil
+}
+
+func (observerOption) AfterInitialize(do *DO) error { return registerObserveCallbacks(do.db) }
+
+// WithObserver emit QueryEvent to observer after each terminal operation of DO,
+// like First, Find, Iter, Update*, Delete, Count, Scan and DIY methods
+func WithObserver(observer QueryObserver) DOOption {
+	return observerOption{observer: observer}
+}
+
+// WithStartObserver call observer before each terminal operation of DO, see WithObserver
+func WithStartObserver(observer QueryStartObserver) DOOption {
+	return observerOption{start: observer}
+}
+
+// queryRecord statements executed by an observed operation
+type queryRecord struct {
+	sql          string
+	vars         []interface{}
+	rowsAffected int64
+	err          error
+}
+
+// Observe start observing operation method (of DIY interface iface), statements of returned DO are recorded
+// until done is called with error of operation, which emits QueryEvent to observers and returns the error.
+// Operations nested in an observed one are not observed again.
+func (d *DO) Observe(iface, method string) (do *DO, done func(error) error) {
+	if d.DOConfig == nil || len(d.Observers) == 0 && len(d.StartObservers) == 0 {
+		return d, func(err error) error { return err }
+	}
+	if _, ok := d.db.Get(queryRecordSettingKey); ok {
+		return d, func(err error) error { return err }
+	}
+
+	event := QueryEvent{Query: d.queryName, Table: d.TableName(), Interface: iface, Method: method}
+	if d.modelType != nil {
+		event.Model = d.modelType.Name()
+	}
+	ctx := d.db.Statement.Context
+	for _, start := range d.StartObservers {
+		ctx = start(ctx, event)
+	}
+
+	record := &queryRecord{}
+	do = d.getInstance(d.db.Session(&gorm.Session{Context: ctx}).Set(queryRecordSettingKey, record).Session(&gorm.Session{})) // clone statement before setting
+	start := time.Now()
+	return do, func(err error) error {
+		event.SQL = record.sql
+		event.Vars = record.vars
+		event.Duration = time.Since(start)
+		event.RowsAffected = record.rowsAffected
+		event.Error = err
+		if event.Error == nil {
+			event.Error = record.err
+		}
+		for _, observe := range d.Observers {
+			observe(ctx, event)
+		}
+		return err
+	}
+}
+
+// registerObserveCallbacks register callbacks recording statements once for callbacks of db
+func registerObserveCallbacks(db *gorm.DB) error {
+	callback := db.Callback()
+	if callback.Query().Get(observeCallbackName) != nil {
+		return nil
+	}
+	for _, err := range []error{
+		callback.Create().After("*").Register(observeCallbackName, recordQuery),
+		callback.Query().After("*").Register(observeCallbackName, recordQuery),
+		callback.Update().After("*").Register(observeCallbackName, recordQuery),
+		callback.Delete().After("*").Register(observeCallbackName, recordQuery),
+		callback.Row().After("*").Register(observeCallbackName, recordQuery),
+		callback.Raw().After("*").Register(observeCallbackName, recordQuery),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func recordQuery(db *gorm.DB) {
+	value, ok := db.Get(queryRecordSettingKey)
+	if !ok {
+		return
+	}
+	record := value.(*queryRecord)
+	record.sql = db.Statement.SQL.String()
+	record.vars = db.Statement.Vars
+	record.rowsAffected += db.RowsAffected
+	if db.Error != nil {
+		record.err = db.Error
+	}
+}
@@ -0,0 +1,120 @@
+package gen
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/utils/tests"
+
+	"gorm.io/gen/field"
+)
+
+func TestDO_WithObserver(t *testing.T) {
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	var events []QueryEvent
+	var d DO
+	d.UseDB(db.Session(&gorm.Session{DryRun: true, NewDB: true, Logger: &captureLogger{}}), WithObserver(func(ctx context.Context, event QueryEvent) {
+		events = append(events, event)
+	}))
+	d.UseModel(versionModel{})
+	d.UseQueryName("versionModel")
+	name := field.NewString("", "name")
+
+	_, _ = d.Where(name.Eq("a")).Find()
+	_, _ = d.Where(name.Eq("a")).Update(name, "b")
+	_, _ = d.Count()
+	_, _ = d.Where(name.Eq("a")).(*DO).ForceDelete()
+	_, _ = d.Update(name, "b")
+
+	expects := []QueryEvent{
+		{Method: "Find", SQL: "SELECT * FROM `version_models` WHERE `name` = ?"},
+		{Method: "Update", SQL: "UPDATE `version_models` SET `name`=?,`updated_at`=? WHERE `name` = ?"},
+		{Method: "Count", SQL: "SELECT count(*) FROM `version_models`"},
+		{Method: "ForceDelete", SQL: "DELETE FROM `version_models` WHERE `name` = ?"},
+		{Method: "Update", Error: gorm.ErrMissingWhereClause},
+	}
+	if len(events) != len(expects) {
+		t.Fatalf("expect %d events, got %d: %+v", len(expects), len(events), events)
+	}
+	for i, expect := range expects {
+		event := events[i]
+		if event.Model != "versionModel" || event.Query != "versionModel" || event.Table != "version_models" || event.Interface != "" {
+			t.Errorf("event %d expects model versionModel of table version_models, got %+v", i, event)
+		}
+		if event.Method != expect.Method {
+			t.Errorf("event %d expects method %s, got %s", i, expect.Method, event.Method)
+		}
+		if expect.SQL != "" && event.SQL != expect.SQL {
+			t.Errorf("event %d expects SQL %s, got %s", i, expect.SQL, event.SQL)
+		}
+		if !errors.Is(event.Error, expect.Error) {
+			t.Errorf("event %d expects error %v, got %v", i, expect.Error, event.Error)
+		}
+	}
+
+	// observing doesn't leak to query it derives from
+	events = nil
+	q := d.Where(name.Eq("a"))
+	_, _ = q.Find()
+	_, _ = q.Count()
+	_, _ = q.Find()
+	if len(events) != 3 {
+		t.Errorf("expect 3 events of reused query, got %+v", events)
+	}
+
+	// like FindByPage of generated query
+	events = nil
+	_, _ = q.Offset(0).Limit(10).Find()
+	_, _ = q.Offset(-1).Limit(-1).Count()
+	if len(events) != 2 || events[1].Method != "Count" {
+		t.Errorf("expect events of Find and Count, got %+v", events)
+	}
+
+	events = nil
+	do, done := d.Observe("Querier", "FindByName")
+	_ = done(do.UnderlyingDB().Raw("SELECT * FROM version_models WHERE name = ?", "a").Scan(&[]versionModel{}).Error)
+	if len(events) != 1 || events[0].Interface != "Querier" || events[0].Method != "FindByName" ||
+		events[0].SQL != "SELECT * FROM version_models WHERE name = ?" || len(events[0].Vars) != 1 {
+		t.Errorf("expect event of DIY method Querier.FindByName, got %+v", events)
+	}
+}
+
+func TestDO_WithStartObserver(t *testing.T) {
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	type methodKey struct{}
+	var started []QueryEvent
+	var doneCtx context.Context
+	var d DO
+	d.UseDB(db.Session(&gorm.Session{DryRun: true, NewDB: true, Logger: &captureLogger{}}),
+		WithStartObserver(func(ctx context.Context, event QueryEvent) context.Context {
+			started = append(started, event)
+			return context.WithValue(ctx, methodKey{}, event.Method)
+		}),
+		WithObserver(func(ctx context.Context, event QueryEvent) { doneCtx = ctx }),
+	)
+	d.UseModel(versionModel{})
+
+	do, done := d.Observe("Querier", "FindByName")
+	if got := do.UnderlyingDB().Statement.Context.Value(methodKey{}); got != "FindByName" {
+		t.Errorf("statements of operation expect context returned by start observer, got %v", got)
+	}
+	_ = done(nil)
+
+	if len(started) != 1 || started[0].Interface != "Querier" || started[0].Method != "FindByName" || started[0].Model != "versionModel" {
+		t.Errorf("expect start event of Querier.FindByName, got %+v", started)
+	}
+	if doneCtx == nil || doneCtx.Value(methodKey{}) != "FindByName" {
+		t.Errorf("observer expects context returned by start observer")
+	}
+	if d.UnderlyingDB().Statement.Context.Value(methodKey{}) != nil {
+		t.Errorf("context of operation should not leak to DO")
+	}
+}
@@ -0,0 +1,204 @@
+// Package otel trace terminal operations of gen generated query code with OpenTelemetry
+package otel
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+
+	"gorm.io/gen"
+)
+
+const instrumentationName = "gorm.io/gen/otel"
+
+// attribute keys of spans
+const (
+	DBSystemKey     = attribute.Key("db.system")
+	DBOperationKey  = attribute.Key("db.operation")
+	DBSQLTableKey   = attribute.Key("db.sql.table")
+	DBStatementKey  = attribute.Key("db.statement")
+	GenModelKey     = attribute.Key("gen.model")
+	GenMethodKey    = attribute.Key("gen.method")
+	GenInterfaceKey = attribute.Key("gen.interface")
+	RowsAffectedKey = attribute.Key("gen.rows_affected")
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	dbSystem       string
+	withoutSQL     bool
+}
+
+// Option tracing option
+type Option func(*config)
+
+// WithTracerProvider specify tracer provider, global tracer provider is used by default
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) { c.tracerProvider = provider }
+}
+
+// WithDBSystem specify db.system attribute, it's resolved from name of gorm dialector by default
+func WithDBSystem(system string) Option {
+	return func(c *config) { c.dbSystem = system }
+}
+
+// WithoutSQL don't record SQL as db.statement attribute
+func WithoutSQL() Option {
+	return func(c *config) { c.withoutSQL = true }
+}
+
+type tracingOption struct {
+	opts []Option
+}
+
+// WithTracing start a span for each terminal operation of Dao, including DIY methods
+//
+//	query.Use(db, otel.WithTracing())
+func WithTracing(opts ...Option) gen.DOOption {
+	return tracingOption{opts: opts}
+}
+
+func (tracingOption) Apply(*gen.DOConfig) error { return nil }
+
+// AfterInitialize observe DO with tracer, db.system is resolved from db of DO
+func (o tracingOption) AfterInitialize(do *gen.DO) error {
+	cfg := &config{}
+	for _, opt := range o.opts {
+		opt(cfg)
+	}
+	if cfg.tracerProvider == nil {
+		cfg.tracerProvider = otel.GetTracerProvider()
+	}
+	if cfg.dbSystem == "" {
+		if db := do.UnderlyingDB(); db != nil && db.Dialector != nil {
+			cfg.dbSystem = dbSystem(db.Dialector.Name())
+		}
+	}
+
+	t := newTracer(cfg)
+	for _, opt := range []gen.DOOption{gen.WithStartObserver(t.start), gen.WithObserver(t.observe)} {
+		if err := opt.Apply(do.DOConfig); err != nil {
+			return err
+		}
+		if err := opt.AfterInitialize(do); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type tracer struct {
+	tracer trace.Tracer
+	cfg    *config
+}
+
+func newTracer(cfg *config) *tracer {
+	return &tracer{tracer: cfg.tracerProvider.Tracer(instrumentationName), cfg: cfg}
+}
+
+// spanKey context key of span started by tracer
+type spanKey struct{ t *tracer }
+
+// start start span before operation, statements of operation run in its context
+func (t *tracer) start(ctx context.Context, event gen.QueryEvent) context.Context {
+	attrs := []attribute.KeyValue{
+		DBSystemKey.String(t.cfg.dbSystem),
+		DBSQLTableKey.String(event.Table),
+		GenModelKey.String(event.Model),
+		GenMethodKey.String(event.Method),
+	}
+	if event.Interface != "" {
+		attrs = append(attrs, GenInterfaceKey.String(event.Interface))
+	} else if op, ok := methodOperations[event.Method]; ok {
+		attrs = append(attrs, DBOperationKey.String(op))
+	}
+	ctx, span := t.tracer.Start(ctx, spanName(event), trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	return context.WithValue(ctx, spanKey{t}, span)
+}
+
+// observe end span started for operation with its result
+func (t *tracer) observe(ctx context.Context, event gen.QueryEvent) {
+	span, ok := ctx.Value(spanKey{t}).(trace.Span)
+	if !ok {
+		return
+	}
+	if op := operation(event); op != "" {
+		span.SetAttributes(DBOperationKey.String(op))
+	}
+	span.SetAttributes(RowsAffectedKey.Int64(event.RowsAffected))
+	if !t.cfg.withoutSQL && event.SQL != "" {
+		span.SetAttributes(DBStatementKey.String(event.SQL))
+	}
+	if event.Error != nil && !errors.Is(event.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(event.Error)
+		span.SetStatus(codes.Error, event.Error.Error())
+	}
+	span.End()
+}
+
+// spanName Model.Method for CRUD methods, Model.Interface.Method for DIY methods
+func spanName(event gen.QueryEvent) string {
+	name := make([]string, 0, 3)
+	for _, s := range []string{event.Model, event.Interface, event.Method} {
+		if s != "" {
+			name = append(name, s)
+		}
+	}
+	return strings.Join(name, ".")
+}
+
+// dbSystems db.system values of gorm dialectors named differently by OpenTelemetry semantic conventions
+var dbSystems = map[string]string{
+	"postgres":  "postgresql",
+	"sqlserver": "mssql",
+}
+
+// dbSystem db.system of gorm dialector name
+func dbSystem(dialector string) string {
+	if system, ok := dbSystems[dialector]; ok {
+		return system
+	}
+	return dialector
+}
+
+// methodOperations SQL verb of CRUD methods known before running,
+// methods like Save, FirstOrCreate and Delete (of soft delete models) are resolved from SQL
+var methodOperations = map[string]string{
+	"Count":              "SELECT",
+	"Find":               "SELECT",
+	"FindByCursor":       "SELECT",
+	"FindInBatches":      "SELECT",
+	"First":              "SELECT",
+	"FirstOrInit":        "SELECT",
+	"Iter":               "SELECT",
+	"Last":               "SELECT",
+	"Pluck":              "SELECT",
+	"Scan":               "SELECT",
+	"Take":               "SELECT",
+	"Create":             "INSERT",
+	"CreateInBatches":    "INSERT",
+	"Update":             "UPDATE",
+	"UpdateSimple":       "UPDATE",
+	"Updates":            "UPDATE",
+	"UpdateColumn":       "UPDATE",
+	"UpdateColumnSimple": "UPDATE",
+	"UpdateColumns":      "UPDATE",
+	"UpdateWithVersion":  "UPDATE",
+	"UpdatesWithVersion": "UPDATE",
+	"Restore":            "UPDATE",
+	"ForceDelete":        "DELETE",
+}
+
+// operation SQL verb of operation, like SELECT or UPDATE
+func operation(event gen.QueryEvent) string {
+	if fields := strings.Fields(event.SQL); len(fields) > 0 {
+		return strings.ToUpper(fields[0])
+	}
+	return ""
+}
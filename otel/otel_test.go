@@ -0,0 +1,134 @@
+package otel
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+	"gorm.io/gorm/utils/tests"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+type user struct {
+	ID   uint
+	Name string
+}
+
+func newTracedDO(t *testing.T) (*gen.DO, *tracetest.InMemoryExporter) {
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	var do gen.DO
+	do.UseDB(db.Session(&gorm.Session{DryRun: true, NewDB: true}), WithTracing(WithTracerProvider(provider)))
+	do.UseModel(&user{})
+	return &do, exporter
+}
+
+func attrsOf(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value, len(span.Attributes))
+	for _, kv := range span.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestWithTracing(t *testing.T) {
+	do, exporter := newTracedDO(t)
+	name := field.NewString("", "name")
+
+	_, _ = do.WithContext(context.Background()).Where(name.Eq("a")).Find()
+	_, _ = do.WithContext(context.Background()).Update(name, "b")
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("expect 2 spans, got %d", len(spans))
+	}
+
+	find := spans[0]
+	if find.Name != "user.Find" {
+		t.Errorf("span name expects user.Find, got %s", find.Name)
+	}
+	for key, expect := range map[attribute.Key]string{
+		DBSystemKey:    "dummy",
+		DBOperationKey: "SELECT",
+		DBSQLTableKey:  "users",
+		GenMethodKey:   "Find",
+		DBStatementKey: "SELECT * FROM `users` WHERE `name` = ?",
+	} {
+		if got := attrsOf(find)[key].AsString(); got != expect {
+			t.Errorf("attribute %s expects %q, got %q", key, expect, got)
+		}
+	}
+	if _, ok := attrsOf(find)[GenInterfaceKey]; ok {
+		t.Errorf("CRUD method should not have %s attribute", GenInterfaceKey)
+	}
+
+	update := spans[1]
+	if update.Status.Code != codes.Error || len(update.Events) == 0 {
+		t.Errorf("update without condition expects error status, got %+v", update.Status)
+	}
+	if got := attrsOf(update)[GenMethodKey].AsString(); got != "Update" {
+		t.Errorf("attribute %s expects Update, got %q", GenMethodKey, got)
+	}
+	// known from method even if no SQL is built
+	if got := attrsOf(update)[DBOperationKey].AsString(); got != "UPDATE" {
+		t.Errorf("attribute %s expects UPDATE, got %q", DBOperationKey, got)
+	}
+}
+
+func TestDBSystem(t *testing.T) {
+	for dialector, expect := range map[string]string{
+		"postgres":  "postgresql",
+		"sqlserver": "mssql",
+		"mysql":     "mysql",
+		"sqlite":    "sqlite",
+	} {
+		if got := dbSystem(dialector); got != expect {
+			t.Errorf("db.system of %s expects %s, got %s", dialector, expect, got)
+		}
+	}
+}
+
+func TestWithTracing_DIY(t *testing.T) {
+	do, exporter := newTracedDO(t)
+
+	// generated DIY methods observe themselves like this
+	diy, done := do.Observe("Querier", "FindByName")
+	running := trace.SpanFromContext(diy.UnderlyingDB().Statement.Context)
+	if !running.IsRecording() {
+		t.Errorf("span expects to be started before statements and carried by their context")
+	}
+	_ = done(diy.UnderlyingDB().Raw("SELECT * FROM users WHERE name = ?", "a").Scan(&[]user{}).Error)
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expect 1 span, got %d", len(spans))
+	}
+	if !spans[0].SpanContext.Equal(running.SpanContext()) {
+		t.Errorf("span of operation expects to be the one in context of statements")
+	}
+	if spans[0].Name != "user.Querier.FindByName" {
+		t.Errorf("span name expects user.Querier.FindByName, got %s", spans[0].Name)
+	}
+	attrs := attrsOf(spans[0])
+	if got := attrs[GenInterfaceKey].AsString(); got != "Querier" {
+		t.Errorf("attribute %s expects Querier, got %q", GenInterfaceKey, got)
+	}
+	if got := attrs[GenMethodKey].AsString(); got != "FindByName" {
+		t.Errorf("attribute %s expects FindByName, got %q", GenMethodKey, got)
+	}
+	if got := attrs[DBOperationKey].AsString(); got != "SELECT" {
+		t.Errorf("attribute %s expects SELECT, got %q", DBOperationKey, got)
+	}
+}
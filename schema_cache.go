@@ -0,0 +1,117 @@
+package gen
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"gorm.io/gen/internal/generate"
+	"gorm.io/gen/internal/model"
+)
+
+// genManifestSchema table schema read from database in last run
+type genManifestSchema struct {
+	Checksum  string                 `json:"checksum"` // cheap change indicator, see generate.GetTableChecksums
+	WithIndex bool                   `json:"with_index,omitempty"`
+	Comment   string                 `json:"comment,omitempty"`
+	Columns   []model.ColumnSnapshot `json:"columns"`
+}
+
+// schemaCache reuse table schema recorded in manifest when table's checksum is unchanged
+type schemaCache struct {
+	mu        sync.Mutex
+	checksums map[string]string            // checksums read from database in current run
+	prev      map[string]genManifestSchema // schemas recorded in last run
+	current   map[string]genManifestSchema // schemas read or reused in current run
+}
+
+var _ model.SchemaCache = (*schemaCache)(nil)
+
+func (c *schemaCache) Load(tableName string, withIndex bool) (columns []*model.Column, comment string, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	checksum, ok := c.checksums[tableName]
+	if !ok {
+		return nil, "", false
+	}
+	s, ok := c.prev[tableName]
+	if !ok || s.Checksum != checksum || s.WithIndex != withIndex {
+		return nil, "", false
+	}
+	columns = make([]*model.Column, 0, len(s.Columns))
+	for _, cs := range s.Columns {
+		col, ok := cs.Restore(tableName)
+		if !ok {
+			return nil, "", false
+		}
+		columns = append(columns, col)
+	}
+	c.current[tableName] = s
+	return columns, s.Comment, true
+}
+
+func (c *schemaCache) Store(tableName string, withIndex bool, columns []*model.Column, comment string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	checksum, ok := c.checksums[tableName]
+	if !ok {
+		return
+	}
+	s := genManifestSchema{Checksum: checksum, WithIndex: withIndex, Comment: comment, Columns: make([]model.ColumnSnapshot, 0, len(columns))}
+	for _, col := range columns {
+		cs, ok := col.Snapshot()
+		if !ok {
+			return
+		}
+		s.Columns = append(s.Columns, cs)
+	}
+	c.current[tableName] = s
+}
+
+func (c *schemaCache) schemas() map[string]genManifestSchema {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.current
+}
+
+// getSchemaCache return schema cache in incremental mode, it's created at first call with checksums of all tables
+func (g *Generator) getSchemaCache() model.SchemaCache {
+	if !g.Incremental || g.schemaSource != nil {
+		return nil
+	}
+	if !g.schemaCacheInit {
+		g.schemaCacheInit = true
+		g.initSchemaCache()
+	}
+	if g.schemaCache == nil {
+		return nil
+	}
+	return g.schemaCache
+}
+
+// initSchemaCache read checksums of all tables and schemas recorded in manifest
+func (g *Generator) initSchemaCache() {
+	checksums, ok, err := generate.GetTableChecksums(g.db)
+	if err != nil {
+		g.db.Logger.Warn(context.Background(), "schema cache disabled: %s", err)
+		return
+	}
+	if !ok {
+		g.info(fmt.Sprintf("schema cache is not supported by dialect %s, introspect all tables", g.db.Dialector.Name()))
+		return
+	}
+
+	modelOutPath, err := g.getModelOutputPath()
+	if err != nil {
+		g.db.Logger.Warn(context.Background(), "schema cache disabled: %s", err)
+		return
+	}
+	m, _, err := loadManifest(modelOutPath)
+	if err != nil {
+		g.db.Logger.Warn(context.Background(), "schema cache disabled: load manifest fail: %s", err)
+		return
+	}
+	g.schemaCache = &schemaCache{checksums: checksums, prev: m.Schemas, current: make(map[string]genManifestSchema)}
+}
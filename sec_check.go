@@ -54,7 +54,7 @@ var banClauses = map[string]bool{
 // CheckClause check security of Expression
 func CheckClause(cond clause.Expression) error {
 	switch cond := cond.(type) {
-	case hints.Hints, hints.IndexHint, dbresolver.Operation:
+	case hints.Hints, hints.IndexHint, dbresolver.Operation, withClause:
 		return nil
 	case clause.OnConflict:
 		return checkOnConflict(cond)
@@ -74,6 +74,7 @@ func CheckClause(cond clause.Expression) error {
 func checkOnConflict(c clause.OnConflict) error {
 	for _, item := range c.DoUpdates {
 		switch item.Value.(type) {
+		case assignValue: // built by Upsert from field.AssignExpr
 		case clause.Expr, *clause.Expr:
 			return errors.New("OnConflict clause assignment with gorm.Expr is banned for security reasons for now")
 		}
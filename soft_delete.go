@@ -0,0 +1,110 @@
+package gen
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WithDeleted query rows including soft deleted ones.
+// Delete is still soft delete (rows soft deleted before are kept), use ForceDelete to delete permanently
+func (d *DO) WithDeleted() Dao {
+	do := d.getInstance(d.db.Unscoped())
+	do.withDeleted = true
+	return do
+}
+
+// OnlyDeleted query soft deleted rows only.
+// Delete is still soft delete (rows soft deleted before are kept), use ForceDelete to delete permanently
+func (d *DO) OnlyDeleted() Dao {
+	notDeleted, err := d.softDeleteCondition()
+	if err != nil {
+		_ = d.db.AddError(err)
+		return d
+	}
+	do := d.getInstance(d.db.Unscoped().Where(clause.Not(notDeleted)))
+	do.withDeleted = true
+	return do
+}
+
+// Restore restore soft deleted rows matching conditions by resetting soft delete column,
+// it fails with gorm.ErrMissingWhereClause without conditions unless AllowGlobalUpdate is enabled
+func (d *DO) Restore() (info ResultInfo, err error) {
+	d, done := d.Observe("", "Restore")
+	notDeleted, err := d.softDeleteCondition()
+	if err != nil {
+		return ResultInfo{Error: err}, done(err)
+	}
+	if !d.db.AllowGlobalUpdate && !hasConditionBesides(d.db.Statement, clause.Not(notDeleted)) {
+		return ResultInfo{Error: gorm.ErrMissingWhereClause}, done(gorm.ErrMissingWhereClause)
+	}
+	result := d.prepareTx().Unscoped().Where(clause.Not(notDeleted)).UpdateColumn(notDeleted.Column.(clause.Column).Name, notDeleted.Value)
+	return ResultInfo{RowsAffected: result.RowsAffected, Error: result.Error}, done(result.Error)
+}
+
+// ForceDelete delete rows permanently even if model is soft deleted
+func (d *DO) ForceDelete(models ...interface{}) (info ResultInfo, err error) {
+	d, done := d.Observe("", "ForceDelete")
+	info, err = d.Unscoped().(*DO).Delete(models...)
+	return info, done(err)
+}
+
+// scopedTx statement of tx deleting soft deletable rows queried by WithDeleted/OnlyDeleted,
+// which is scoped again so that Delete is soft delete
+func (d *DO) scopedTx(tx *gorm.DB) *gorm.DB {
+	if !d.withDeleted {
+		return tx
+	}
+	ctx := tx.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	tx = tx.Session(&gorm.Session{Context: ctx}) // clone statement before changing it
+	tx.Statement.Unscoped = false
+	return tx
+}
+
+// hasConditionBesides whether WHERE clause of stmt has conditions other than expr, like filter added by OnlyDeleted
+func hasConditionBesides(stmt *gorm.Statement, expr clause.Expression) bool {
+	where, ok := stmt.Clauses["WHERE"].Expression.(clause.Where)
+	if !ok {
+		return false
+	}
+	for _, e := range where.Exprs {
+		if !reflect.DeepEqual(e, expr) {
+			return true
+		}
+	}
+	return false
+}
+
+// softDeleteCondition condition of rows not soft deleted, like `deleted_at` IS NULL,
+// which is built by query clauses of soft delete field (gorm.DeletedAt or soft_delete plugin) of model
+func (d *DO) softDeleteCondition() (clause.Eq, error) {
+	if d.modelType == nil {
+		return clause.Eq{}, errors.New("gen: soft delete needs model")
+	}
+	stmt := &gorm.Statement{Clauses: map[string]clause.Clause{}}
+	stmt.DB = &gorm.DB{Config: d.db.Config, Statement: stmt}
+	if err := stmt.Parse(reflect.New(d.modelType).Interface()); err != nil {
+		return clause.Eq{}, err
+	}
+	for _, c := range stmt.Schema.QueryClauses {
+		if modifier, ok := c.(gorm.StatementModifier); ok {
+			modifier.ModifyStatement(stmt)
+		}
+	}
+	if where, ok := stmt.Clauses["WHERE"].Expression.(clause.Where); ok {
+		for _, expr := range where.Exprs {
+			if eq, ok := expr.(clause.Eq); ok {
+				if _, ok := eq.Column.(clause.Column); ok {
+					return eq, nil
+				}
+			}
+		}
+	}
+	return clause.Eq{}, errors.New("gen: model " + stmt.Schema.Name + " has no soft delete field")
+}
@@ -0,0 +1,99 @@
+package gen
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/utils/tests"
+
+	"gorm.io/gen/field"
+)
+
+type softDeleteModel struct {
+	ID        uint
+	Name      string
+	DeletedAt gorm.DeletedAt
+}
+
+func TestDO_SoftDelete(t *testing.T) {
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	l := &captureLogger{}
+	var d DO
+	d.UseDB(db.Session(&gorm.Session{DryRun: true, NewDB: true, Logger: l}))
+	d.UseModel(softDeleteModel{})
+	name := field.NewString("", "name")
+
+	for _, testcase := range []struct {
+		exec   func() error
+		expect string
+	}{
+		{
+			exec:   func() error { _, err := d.Where(name.Eq("a")).Find(); return err },
+			expect: "SELECT * FROM `soft_delete_models` WHERE `name` = \"a\" AND `soft_delete_models`.`deleted_at` IS NULL",
+		},
+		{
+			exec:   func() error { _, err := d.WithDeleted().Where(name.Eq("a")).Find(); return err },
+			expect: "SELECT * FROM `soft_delete_models` WHERE `name` = \"a\"",
+		},
+		{
+			exec:   func() error { _, err := d.OnlyDeleted().Where(name.Eq("a")).Find(); return err },
+			expect: "SELECT * FROM `soft_delete_models` WHERE `soft_delete_models`.`deleted_at` IS NOT NULL AND `name` = \"a\"",
+		},
+		{
+			exec:   func() error { _, err := d.Where(name.Eq("a")).(*DO).Restore(); return err },
+			expect: "UPDATE `soft_delete_models` SET `deleted_at`=NULL WHERE `name` = \"a\" AND `soft_delete_models`.`deleted_at` IS NOT NULL",
+		},
+		{
+			exec:   func() error { _, err := d.Where(name.Eq("a")).(*DO).ForceDelete(); return err },
+			expect: "DELETE FROM `soft_delete_models` WHERE `name` = \"a\"",
+		},
+		{
+			exec:   func() error { _, err := d.WithDeleted().Where(name.Eq("a")).Count(); return err },
+			expect: "SELECT count(*) FROM `soft_delete_models` WHERE `name` = \"a\"",
+		},
+	} {
+		if err := testcase.exec(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if l.lastSQL != testcase.expect {
+			t.Errorf("SQL expects %s, got %s", testcase.expect, l.lastSQL)
+		}
+	}
+
+	// Delete of rows queried with deleted ones is still soft delete
+	for _, dao := range []Dao{d.WithDeleted(), d.OnlyDeleted()} {
+		if _, err := dao.Where(name.Eq("a")).Delete(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if !strings.HasPrefix(l.lastSQL, "UPDATE `soft_delete_models` SET `deleted_at`=") {
+			t.Errorf("expect soft delete, got %s", l.lastSQL)
+		}
+	}
+
+	// Restore without conditions doesn't restore all rows
+	for _, dao := range []*DO{&d, d.OnlyDeleted().(*DO)} {
+		if _, err := dao.Restore(); !errors.Is(err, gorm.ErrMissingWhereClause) {
+			t.Errorf("expect ErrMissingWhereClause of Restore without conditions, got %v", err)
+		}
+	}
+	global := d
+	global.ReplaceDB(d.db.Session(&gorm.Session{AllowGlobalUpdate: true}))
+	if _, err := global.Restore(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if l.lastSQL != "UPDATE `soft_delete_models` SET `deleted_at`=NULL WHERE `soft_delete_models`.`deleted_at` IS NOT NULL" {
+		t.Errorf("expect restore of all rows with AllowGlobalUpdate, got %s", l.lastSQL)
+	}
+
+	var plain DO
+	plain.UseDB(db.Session(&gorm.Session{DryRun: true, NewDB: true}))
+	plain.UseModel(cursorModel{})
+	if _, err := plain.Restore(); err == nil {
+		t.Errorf("expect error of Restore on model without soft delete field")
+	}
+}
@@ -0,0 +1,92 @@
+package gen
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+
+	"gorm.io/gen/internal/diagnostic"
+)
+
+// tableMatcher match table name
+type tableMatcher func(tableName string) bool
+
+// globMatchers build matchers of glob patterns, invalid patterns are skipped and reported in errs
+func globMatchers(patterns []string) (matchers []tableMatcher, errs []error) {
+	for _, pattern := range patterns {
+		pattern := pattern
+		if _, err := path.Match(pattern, ""); err != nil {
+			errs = append(errs, fmt.Errorf("invalid table pattern %q: %w", pattern, err))
+			continue
+		}
+		matchers = append(matchers, func(tableName string) bool {
+			ok, _ := path.Match(pattern, tableName)
+			return ok
+		})
+	}
+	return matchers, errs
+}
+
+// regMatchers build matchers of RegExps, invalid RegExps are skipped and reported in errs
+func regMatchers(regs []string) (matchers []tableMatcher, errs []error) {
+	for _, reg := range regs {
+		r, err := regexp.Compile(reg)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid table RegExp %q: %w", reg, err))
+			continue
+		}
+		matchers = append(matchers, r.MatchString)
+	}
+	return matchers, errs
+}
+
+// addTableFilters add include (or exclude) matchers, keep errors of invalid filters which are reported by Execute
+func (cfg *Config) addTableFilters(include bool, matchers []tableMatcher, errs []error) {
+	if include {
+		cfg.tableIncludes = append(cfg.tableIncludes, matchers...)
+	} else {
+		cfg.tableExcludes = append(cfg.tableExcludes, matchers...)
+	}
+	cfg.tableFilterErrs = append(cfg.tableFilterErrs, errs...)
+}
+
+// tableFilterError return invalid table filters as diagnostics
+func (cfg *Config) tableFilterError() error {
+	var errs diagnostic.List
+	for _, err := range cfg.tableFilterErrs {
+		errs = errs.Append(err, diagnostic.CodeTableFilter)
+	}
+	return errs.Err()
+}
+
+// MatchTable report whether table pass include and exclude filters.
+// table is included when no include filter is set or it matches any include filter, and it matches no exclude filter
+func (cfg *Config) MatchTable(tableName string) bool {
+	included := len(cfg.tableIncludes) == 0
+	for _, match := range cfg.tableIncludes {
+		if match(tableName) {
+			included = true
+			break
+		}
+	}
+	if !included {
+		return false
+	}
+	for _, match := range cfg.tableExcludes {
+		if match(tableName) {
+			return false
+		}
+	}
+	return true
+}
+
+// filterTables return tables pass include and exclude filters
+func (cfg *Config) filterTables(tables []string) []string {
+	result := make([]string, 0, len(tables))
+	for _, tableName := range tables {
+		if cfg.MatchTable(tableName) {
+			result = append(result, tableName)
+		}
+	}
+	return result
+}
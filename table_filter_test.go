@@ -0,0 +1,64 @@
+package gen
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gorm.io/gen/internal/diagnostic"
+)
+
+func TestConfig_FilterTables(t *testing.T) {
+	tables := []string{"users", "orders", "orders_p2024", "orders_p2025", "flyway_schema_history", "goose_db_version", "tmp_import"}
+
+	var cfg Config
+	if got := cfg.filterTables(tables); !reflect.DeepEqual(got, tables) {
+		t.Fatalf("expected all tables without filters, got %v", got)
+	}
+
+	cfg.WithTableExcludes("flyway_*", "goose_db_version", "tmp_*")
+	cfg.WithTableExcludesReg(`_p\d+$`)
+	if got, want := cfg.filterTables(tables), []string{"users", "orders"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("exclude: got %v, want %v", got, want)
+	}
+
+	cfg.WithTableIncludes("order*")
+	if got, want := cfg.filterTables(tables), []string{"orders"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("include and exclude: got %v, want %v", got, want)
+	}
+
+	cfg.WithTableIncludesReg("^user")
+	if got, want := cfg.filterTables(tables), []string{"users", "orders"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("include any: got %v, want %v", got, want)
+	}
+}
+
+func TestGenerator_InvalidTableFilter(t *testing.T) {
+	g := NewGenerator(Config{OutPath: filepath.Join(t.TempDir(), "query")})
+	g.WithTableIncludes("[")
+	g.WithTableExcludesReg(`_p(\d+$`)
+	g.WithTableExcludes("tmp_*")
+
+	if !g.MatchTable("users") || g.MatchTable("tmp_import") {
+		t.Fatalf("expected valid filters to keep working")
+	}
+
+	err := g.ExecuteE()
+	var diags Diagnostics
+	if !errors.As(err, &diags) || len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics of invalid filters, got %v", err)
+	}
+	for _, d := range diags {
+		if d.Diag.Code != diagnostic.CodeTableFilter {
+			t.Errorf("expected diagnostic code %s, got %s", diagnostic.CodeTableFilter, d.Diag.Code)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected Execute to panic on invalid table filter")
+		}
+	}()
+	g.Execute()
+}
@@ -0,0 +1,260 @@
+package gen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+const tenantCallbackName = "gen:tenant"
+
+// TenantExtractor extract tenant from context, ok is false if context carries no tenant
+type TenantExtractor func(ctx context.Context) (tenant interface{}, ok bool)
+
+type tenantOption struct {
+	column    string
+	extractor TenantExtractor
+}
+
+func (o tenantOption) Apply(cfg *DOConfig) error {
+	if o.column == "" || o.extractor == nil {
+		return errors.New("gen: tenant option needs column and extractor")
+	}
+	cfg.TenantColumn = o.column
+	cfg.TenantExtractor = o.extractor
+	return nil
+}
+
+func (tenantOption) AfterInitialize(do *DO) error {
+	if err := registerTenantCallbacks(do.db); err != nil {
+		return err
+	}
+	do.useTenantScope()
+	return nil
+}
+
+// WithTenant scope rows of tables owning tenant column to tenant extracted from context:
+// SELECT/UPDATE/DELETE are filtered by `column = tenant`, Create fills column with tenant,
+// and statements on tenant tables without tenant in context fail with ErrMissingTenant.
+// Raw SQL (including DIY methods) and conflict updates (Save, OnConflict with updates) can not be scoped,
+// they fail with ErrRawSQLOnTenantTable and ErrUpsertOnTenantTable. Use CrossTenant to opt out explicitly.
+// Tenant scope is carried in statement context, so Preload and association statements are scoped too,
+// saving has-many associations upserts their foreign keys and fails with ErrUpsertOnTenantTable as well.
+func WithTenant(column string, extractor TenantExtractor) DOOption {
+	return tenantOption{column: column, extractor: extractor}
+}
+
+// CrossTenant opt out tenant scoping, statements run across all tenants
+func (d *DO) CrossTenant() Dao {
+	scope := tenantScopeOf(d.db.Statement.Context)
+	if scope == nil {
+		return d
+	}
+	return d.getInstance(d.db.WithContext(context.WithValue(d.db.Statement.Context, tenantScopeKey{}, &tenantScope{cfg: scope.cfg, cross: true})))
+}
+
+type tenantScopeKey struct{}
+
+// tenantScope tenant config of DO read by tenant callbacks. It's carried in statement context
+// instead of settings, so that statements of Preload and associations are scoped too
+type tenantScope struct {
+	cfg   *DOConfig
+	cross bool // set by CrossTenant
+}
+
+func tenantScopeOf(ctx context.Context) *tenantScope {
+	if ctx == nil {
+		return nil
+	}
+	scope, _ := ctx.Value(tenantScopeKey{}).(*tenantScope)
+	return scope
+}
+
+// useTenantScope carry tenant config in statement context of db
+func (d *DO) useTenantScope() {
+	if d.DOConfig == nil || d.TenantColumn == "" {
+		return
+	}
+	ctx := d.db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	d.db = d.db.WithContext(context.WithValue(ctx, tenantScopeKey{}, &tenantScope{cfg: d.DOConfig}))
+}
+
+// keepTenantScope carry tenant scope of d to ctx which replaces context of its statement
+func (d *DO) keepTenantScope(ctx context.Context) context.Context {
+	if scope := tenantScopeOf(d.db.Statement.Context); scope != nil && ctx != nil && tenantScopeOf(ctx) != scope {
+		return context.WithValue(ctx, tenantScopeKey{}, scope)
+	}
+	return ctx
+}
+
+// registerTenantCallbacks register tenant callbacks once for callbacks of db,
+// which only take effect on statements created by DO with tenant option
+func registerTenantCallbacks(db *gorm.DB) error {
+	callback := db.Callback()
+	if callback.Query().Get(tenantCallbackName) != nil {
+		return nil
+	}
+	for _, err := range []error{
+		callback.Create().Before("gorm:create").Register(tenantCallbackName, tenantCreateCallback),
+		callback.Query().Before("gorm:query").Register(tenantCallbackName, tenantScopeCallback(false)),
+		callback.Update().Before("gorm:update").Register(tenantCallbackName, tenantScopeCallback(true)),
+		callback.Delete().Before("gorm:delete").Register(tenantCallbackName, tenantScopeCallback(true)),
+		callback.Row().Before("gorm:row").Register(tenantCallbackName, tenantScopeCallback(false)),
+		callback.Raw().Before("gorm:raw").Register(tenantCallbackName, tenantScopeCallback(false)),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tenantOf return tenant field of statement and tenant in its context,
+// field is nil if statement is not scoped
+func tenantOf(db *gorm.DB) (f *schema.Field, tenant interface{}) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return nil, nil
+	}
+	scope := tenantScopeOf(db.Statement.Context)
+	if scope == nil {
+		return nil, nil
+	}
+	cfg := scope.cfg
+	if f = db.Statement.Schema.LookUpField(cfg.TenantColumn); f == nil {
+		return nil, nil
+	}
+	if scope.cross {
+		db.Logger.Info(db.Statement.Context, "gen: cross tenant statement on table %s", db.Statement.Schema.Table)
+		return nil, nil
+	}
+	tenant, ok := cfg.TenantExtractor(db.Statement.Context)
+	if !ok {
+		_ = db.AddError(fmt.Errorf("gen: table %s: %w", db.Statement.Schema.Table, ErrMissingTenant))
+		return nil, nil
+	}
+	return f, tenant
+}
+
+// tenantScopeCallback add tenant condition to statement, mutating is true for UPDATE/DELETE
+func tenantScopeCallback(mutating bool) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		f, tenant := tenantOf(db)
+		if f == nil {
+			return
+		}
+		stmt := db.Statement
+		if stmt.SQL.Len() > 0 {
+			_ = db.AddError(fmt.Errorf("gen: table %s: %w", stmt.Schema.Table, ErrRawSQLOnTenantTable))
+			return
+		}
+		// tenant condition alone doesn't make UPDATE/DELETE conditional, keep gorm's protection from global update
+		if _, ok := stmt.Clauses["WHERE"]; mutating && !ok && !db.AllowGlobalUpdate && !hasPrimaryValue(stmt) {
+			_ = db.AddError(gorm.ErrMissingWhereClause)
+			return
+		}
+		stmt.AddClause(clause.Where{Exprs: []clause.Expression{
+			clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: f.DBName}, Value: tenant},
+		}})
+	}
+}
+
+// hasPrimaryValue whether gorm will add primary key conditions for values of statement
+func hasPrimaryValue(stmt *gorm.Statement) bool {
+	if len(stmt.Schema.PrimaryFields) == 0 {
+		return false
+	}
+	for _, value := range []reflect.Value{stmt.ReflectValue, reflect.Indirect(reflect.ValueOf(stmt.Dest))} {
+		if !value.IsValid() {
+			continue
+		}
+		typ := value.Type()
+		if kind := typ.Kind(); kind == reflect.Slice || kind == reflect.Array {
+			typ = typ.Elem()
+		}
+		if typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		if typ != stmt.Schema.ModelType {
+			continue
+		}
+		if _, values := schema.GetIdentityFieldValuesMap(stmt.Context, value, stmt.Schema.PrimaryFields); len(values) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func tenantCreateCallback(db *gorm.DB) {
+	f, tenant := tenantOf(db)
+	if f == nil {
+		return
+	}
+	stmt := db.Statement
+	if stmt.SQL.Len() > 0 {
+		_ = db.AddError(fmt.Errorf("gen: table %s: %w", stmt.Schema.Table, ErrRawSQLOnTenantTable))
+		return
+	}
+	// conflict row may belong to another tenant, which can not be filtered out portably (mysql has no WHERE in upsert)
+	if c, ok := stmt.Clauses["ON CONFLICT"]; ok {
+		if onConflict, ok := c.Expression.(clause.OnConflict); ok && (onConflict.UpdateAll || len(onConflict.DoUpdates) > 0) {
+			_ = db.AddError(fmt.Errorf("gen: table %s: %w", stmt.Schema.Table, ErrUpsertOnTenantTable))
+			return
+		}
+	}
+	switch value := stmt.ReflectValue; value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if elem := reflect.Indirect(value.Index(i)); elem.Kind() == reflect.Map {
+				setMapTenant(db, f, elem, tenant)
+			} else {
+				setTenant(db, f, elem, tenant)
+			}
+		}
+	case reflect.Struct:
+		setTenant(db, f, value, tenant)
+	case reflect.Map:
+		setMapTenant(db, f, value, tenant)
+	}
+}
+
+// setTenant fill tenant field of value, refuse value already belongs to another tenant
+func setTenant(db *gorm.DB, f *schema.Field, value reflect.Value, tenant interface{}) {
+	if v, isZero := f.ValueOf(db.Statement.Context, value); !isZero && fmt.Sprint(v) != fmt.Sprint(tenant) {
+		_ = db.AddError(fmt.Errorf("gen: table %s: create row of tenant %v in tenant %v", db.Statement.Schema.Table, v, tenant))
+		return
+	}
+	if err := f.Set(db.Statement.Context, value, tenant); err != nil {
+		_ = db.AddError(err)
+	}
+}
+
+// setMapTenant fill tenant of map[string]interface{} row, which is keyed by field name or column like gorm does,
+// refuse row already belongs to another tenant and maps of other types
+func setMapTenant(db *gorm.DB, f *schema.Field, value reflect.Value, tenant interface{}) {
+	m, ok := value.Interface().(map[string]interface{})
+	if !ok {
+		_ = db.AddError(fmt.Errorf("gen: table %s: unsupported create value %s", db.Statement.Schema.Table, value.Type()))
+		return
+	}
+	key := f.DBName
+	for _, k := range []string{f.Name, f.DBName} {
+		v, ok := m[k]
+		if !ok {
+			continue
+		}
+		if v != nil && !reflect.ValueOf(v).IsZero() && fmt.Sprint(v) != fmt.Sprint(tenant) {
+			_ = db.AddError(fmt.Errorf("gen: table %s: create row of tenant %v in tenant %v", db.Statement.Schema.Table, v, tenant))
+			return
+		}
+		key = k
+	}
+	m[key] = tenant
+}
@@ -0,0 +1,278 @@
+package gen
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/utils/tests"
+
+	"gorm.io/gen/field"
+)
+
+type tenantModel struct {
+	ID       uint
+	Name     string
+	TenantID int
+}
+
+type tenantCtxKey struct{}
+
+func tenantFromContext(ctx context.Context) (interface{}, bool) {
+	tenant, ok := ctx.Value(tenantCtxKey{}).(int)
+	return tenant, ok
+}
+
+func newTenantDO(t *testing.T, model interface{}) (*DO, *captureLogger) {
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	l := &captureLogger{}
+	var d DO
+	d.UseDB(db.Session(&gorm.Session{DryRun: true, NewDB: true, Logger: l}), WithTenant("tenant_id", tenantFromContext))
+	d.UseModel(model)
+	return &d, l
+}
+
+func TestDO_Tenant(t *testing.T) {
+	d, l := newTenantDO(t, tenantModel{})
+	ctx := context.WithValue(context.Background(), tenantCtxKey{}, 7)
+	name := field.NewString("", "name")
+
+	if _, err := d.WithContext(ctx).Where(name.Eq("a")).Find(); err != nil {
+		t.Fatalf("Find fail: %v", err)
+	}
+	if expect := "SELECT * FROM `tenant_models` WHERE `name` = \"a\" AND `tenant_models`.`tenant_id` = 7"; l.lastSQL != expect {
+		t.Errorf("SQL expects %s, got %s", expect, l.lastSQL)
+	}
+
+	if _, err := d.WithContext(ctx).Where(name.Eq("a")).Update(name, "b"); err != nil {
+		t.Fatalf("Update fail: %v", err)
+	}
+	if expect := "UPDATE `tenant_models` SET `name`=\"b\" WHERE `name` = \"a\" AND `tenant_models`.`tenant_id` = 7"; l.lastSQL != expect {
+		t.Errorf("SQL expects %s, got %s", expect, l.lastSQL)
+	}
+
+	if _, err := d.WithContext(ctx).Delete([]*tenantModel{{ID: 1}}); err != nil {
+		t.Fatalf("Delete fail: %v", err)
+	}
+	if expect := "DELETE FROM `tenant_models` WHERE `tenant_models`.`tenant_id` = 7 AND `tenant_models`.`id` = 1"; l.lastSQL != expect {
+		t.Errorf("SQL expects %s, got %s", expect, l.lastSQL)
+	}
+
+	user := &tenantModel{Name: "a"}
+	if err := d.WithContext(ctx).Create(user); err != nil {
+		t.Fatalf("Create fail: %v", err)
+	}
+	if user.TenantID != 7 {
+		t.Errorf("Create should set tenant 7, got %d", user.TenantID)
+	}
+	if err := d.WithContext(ctx).Create(&tenantModel{Name: "a", TenantID: 8}); err == nil {
+		t.Errorf("Create row of another tenant should fail")
+	}
+
+	if _, err := d.WithContext(ctx).Update(name, "b"); !errors.Is(err, gorm.ErrMissingWhereClause) {
+		t.Errorf("Update without condition expects ErrMissingWhereClause, got %v", err)
+	}
+	if err := d.WithContext(ctx).(*DO).UnderlyingDB().Exec("DELETE FROM tenant_models").Error; !errors.Is(err, ErrRawSQLOnTenantTable) {
+		t.Errorf("raw SQL expects ErrRawSQLOnTenantTable, got %v", err)
+	}
+	if _, err := d.WithContext(ctx).(*DO).ExecResult("DELETE FROM tenant_models"); !errors.Is(err, ErrRawSQLOnTenantTable) {
+		t.Errorf("raw SQL of DIY method expects ErrRawSQLOnTenantTable, got %v", err)
+	}
+
+	if err := d.WithContext(ctx).Save(&tenantModel{ID: 1, Name: "a"}); !errors.Is(err, ErrUpsertOnTenantTable) {
+		t.Errorf("Save expects ErrUpsertOnTenantTable, got %v", err)
+	}
+	if err := d.WithContext(ctx).(*DO).OnConflict(field.NewUint("", "id")).DoUpdates(name.Value("b")).Create(&tenantModel{ID: 1}); !errors.Is(err, ErrUpsertOnTenantTable) {
+		t.Errorf("OnConflict update expects ErrUpsertOnTenantTable, got %v", err)
+	}
+	if err := d.WithContext(ctx).(*DO).OnConflict(field.NewUint("", "id")).DoNothing().Create(&tenantModel{ID: 1}); err != nil {
+		t.Errorf("OnConflict do nothing fail: %v", err)
+	}
+	if err := d.WithContext(ctx).(*DO).CrossTenant().Save(&tenantModel{ID: 1, Name: "a", TenantID: 7}); err != nil {
+		t.Errorf("CrossTenant Save fail: %v", err)
+	}
+}
+
+func TestDO_TenantCreateMap(t *testing.T) {
+	d, l := newTenantDO(t, tenantModel{})
+	ctx := context.WithValue(context.Background(), tenantCtxKey{}, 7)
+	create := func(value interface{}) error {
+		return d.WithContext(ctx).(*DO).UnderlyingDB().Model(&tenantModel{}).Create(value).Error
+	}
+
+	row := map[string]interface{}{"name": "a"}
+	if err := create(&row); err != nil {
+		t.Fatalf("Create *map fail: %v", err)
+	}
+	if row["tenant_id"] != 7 {
+		t.Errorf("Create *map should set tenant 7, got %v", row["tenant_id"])
+	}
+
+	rows := []map[string]interface{}{{"name": "a"}, {"Name": "b", "TenantID": 7}}
+	if err := create(rows); err != nil {
+		t.Fatalf("Create []map fail: %v", err)
+	}
+	if expect := "INSERT INTO `tenant_models` (`name`,`tenant_id`) VALUES (\"a\",7),(\"b\",7) RETURNING `id`"; l.lastSQL != expect {
+		t.Errorf("SQL expects %s, got %s", expect, l.lastSQL)
+	}
+
+	if err := create([]map[string]interface{}{{"name": "a", "tenant_id": 8}}); err == nil {
+		t.Errorf("Create map row of another tenant should fail")
+	}
+	if err := create(map[string]string{"name": "a"}); err == nil {
+		t.Errorf("Create map of unsupported type should fail")
+	}
+}
+
+func TestDO_TenantMissing(t *testing.T) {
+	d, _ := newTenantDO(t, tenantModel{})
+	name := field.NewString("", "name")
+
+	if _, err := d.WithContext(context.Background()).Find(); !errors.Is(err, ErrMissingTenant) {
+		t.Errorf("Find expects ErrMissingTenant, got %v", err)
+	}
+	if _, err := d.WithContext(context.Background()).Where(name.Eq("a")).Delete(); !errors.Is(err, ErrMissingTenant) {
+		t.Errorf("Delete expects ErrMissingTenant, got %v", err)
+	}
+	if err := d.WithContext(context.Background()).Create(&tenantModel{}); !errors.Is(err, ErrMissingTenant) {
+		t.Errorf("Create expects ErrMissingTenant, got %v", err)
+	}
+}
+
+func TestDO_CrossTenant(t *testing.T) {
+	d, l := newTenantDO(t, tenantModel{})
+
+	if _, err := d.WithContext(context.Background()).(*DO).CrossTenant().Find(); err != nil {
+		t.Fatalf("CrossTenant Find fail: %v", err)
+	}
+	if expect := "SELECT * FROM `tenant_models`"; l.lastSQL != expect {
+		t.Errorf("SQL expects %s, got %s", expect, l.lastSQL)
+	}
+
+	// CrossTenant doesn't leak to query it derives from
+	ctx := context.WithValue(context.Background(), tenantCtxKey{}, 7)
+	q := d.WithContext(ctx).Where(field.NewString("", "name").Eq("a"))
+	if _, err := q.(*DO).CrossTenant().Find(); err != nil {
+		t.Fatalf("CrossTenant Find fail: %v", err)
+	}
+	if _, err := q.Find(); err != nil {
+		t.Fatalf("Find fail: %v", err)
+	}
+	if expect := "SELECT * FROM `tenant_models` WHERE `name` = \"a\" AND `tenant_models`.`tenant_id` = 7"; l.lastSQL != expect {
+		t.Errorf("SQL expects %s, got %s", expect, l.lastSQL)
+	}
+
+	// tables without tenant column are not scoped
+	d, l = newTenantDO(t, versionModel{})
+	if _, err := d.WithContext(context.Background()).Find(); err != nil {
+		t.Fatalf("Find fail: %v", err)
+	}
+	if expect := "SELECT * FROM `version_models`"; l.lastSQL != expect {
+		t.Errorf("SQL expects %s, got %s", expect, l.lastSQL)
+	}
+}
+
+type tenantOwner struct {
+	ID       uint
+	Name     string
+	TenantID int
+	Items    []tenantItem `gorm:"foreignKey:OwnerID"`
+}
+
+// tenantItem share the id column with its owner, as the test driver return only id and name
+type tenantItem struct {
+	OwnerID  uint `gorm:"column:id"`
+	Name     string
+	TenantID int
+}
+
+// sqlsLogger record all SQL traced
+type sqlsLogger struct {
+	captureLogger
+	sqls []string
+}
+
+func (l *sqlsLogger) LogMode(logger.LogLevel) logger.Interface { return l }
+func (l *sqlsLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	sql, _ := fc()
+	l.sqls = append(l.sqls, sql)
+}
+
+func TestDO_TenantPreload(t *testing.T) {
+	sqlDB, err := sql.Open("gen_iter", "")
+	if err != nil {
+		t.Fatalf("open sql db: %v", err)
+	}
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{ConnPool: sqlDB})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	l := &sqlsLogger{}
+	var d DO
+	d.UseDB(db.Session(&gorm.Session{NewDB: true, Logger: l}), WithTenant("tenant_id", tenantFromContext))
+	d.UseModel(tenantOwner{})
+	ctx := context.WithValue(context.Background(), tenantCtxKey{}, 7)
+
+	var owners []tenantOwner
+	if err := d.WithContext(ctx).(*DO).UnderlyingDB().Preload("Items").Find(&owners).Error; err != nil {
+		t.Fatalf("Preload fail: %v", err)
+	}
+	// rows of other tenants are excluded from preloaded associations
+	expects := []string{
+		"SELECT * FROM `tenant_items` WHERE `tenant_items`.`id` IN (1,2,3) AND `tenant_items`.`tenant_id` = 7",
+		"SELECT * FROM `tenant_owners` WHERE `tenant_owners`.`tenant_id` = 7",
+	}
+	if len(l.sqls) != len(expects) {
+		t.Fatalf("expect SQL %v, got %v", expects, l.sqls)
+	}
+	for i, expect := range expects {
+		if l.sqls[i] != expect {
+			t.Errorf("SQL expects %s, got %s", expect, l.sqls[i])
+		}
+	}
+
+	// new statements like those of many2many join tables keep context only
+	l.sqls = nil
+	if err := d.WithContext(ctx).(*DO).UnderlyingDB().Session(&gorm.Session{NewDB: true}).Find(&[]tenantItem{}).Error; err != nil {
+		t.Fatalf("Find fail: %v", err)
+	}
+	if expect := "SELECT * FROM `tenant_items` WHERE `tenant_items`.`tenant_id` = 7"; len(l.sqls) != 1 || l.sqls[0] != expect {
+		t.Errorf("SQL expects %s, got %v", expect, l.sqls)
+	}
+}
+
+type failedInitOption struct{ err error }
+
+func (failedInitOption) Apply(*DOConfig) error       { return nil }
+func (o failedInitOption) AfterInitialize(*DO) error { return o.err }
+
+func TestDO_TenantOption(t *testing.T) {
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{DryRun: true})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	var d DO
+	d.UseDB(db)
+	d.UseModel(tenantModel{})
+	if db.Callback().Query().Get(tenantCallbackName) != nil {
+		t.Errorf("tenant callbacks should be registered by WithTenant only")
+	}
+	if _, err := d.WithContext(context.Background()).Find(); err != nil {
+		t.Errorf("Find without WithTenant fail: %v", err)
+	}
+
+	// errors of options are returned by statements instead of panic
+	initErr := errors.New("init fail")
+	d.UseDB(db, failedInitOption{err: initErr})
+	d.UseModel(tenantModel{})
+	if _, err := d.Find(); !errors.Is(err, initErr) {
+		t.Errorf("Find expects error of option, got %v", err)
+	}
+}
@@ -50,6 +50,11 @@ func Test_bankQuery(t *testing.T) {
 		t.Error("create item in table <banks> fail:", err)
 	}
 
+	err = _do.OnConflict().DoNothing().Create(&model.Bank{})
+	if err != nil {
+		t.Error("OnConflict() create item in table <banks> fail:", err)
+	}
+
 	err = _do.CreateInBatches([]*model.Bank{{}, {}}, 10)
 	if err != nil {
 		t.Error("create item in table <banks> fail:", err)
@@ -80,6 +85,13 @@ func Test_bankQuery(t *testing.T) {
 		t.Error("FindInBatches() on table <banks> fail:", err)
 	}
 
+	it := _do.Where(primaryKey.IsNotNull()).Iter()
+	for it.Next() {
+	}
+	if err = it.Err(); err != nil {
+		t.Error("Iter() on table <banks> fail:", err)
+	}
+
 	_, err = _do.Select(bank.ALL).Where(primaryKey.IsNotNull()).Order(primaryKey.Desc()).Find()
 	if err != nil {
 		t.Error("Find() on table <banks> fail:", err)
@@ -115,6 +127,11 @@ func Test_bankQuery(t *testing.T) {
 		t.Error("ScanByPage() on table <banks> fail:", err)
 	}
 
+	_, _, _, err = _do.FindByCursor("", 1, primaryKey)
+	if err != nil {
+		t.Error("FindByCursor() on table <banks> fail:", err)
+	}
+
 	_, err = _do.Attrs(primaryKey).Assign(primaryKey).FirstOrInit()
 	if err != nil {
 		t.Error("FirstOrInit() on table <banks> fail:", err)
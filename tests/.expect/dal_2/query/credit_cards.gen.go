@@ -24,6 +24,7 @@ func newCreditCard(db *gorm.DB, opts ...gen.DOOption) creditCard {
 
 	_creditCard.creditCardDo.UseDB(db, opts...)
 	_creditCard.creditCardDo.UseModel(&model.CreditCard{})
+	_creditCard.creditCardDo.UseQueryName("creditCard")
 
 	tableName := _creditCard.creditCardDo.TableName()
 	_creditCard.ALL = field.NewAsterisk(tableName)
@@ -190,6 +191,14 @@ func (c creditCardDo) RightJoin(table schema.Tabler, on ...field.Expr) *creditCa
 	return c.withDO(c.DO.RightJoin(table, on...))
 }
 
+func (c creditCardDo) With(name string, q gen.SubQuery) *creditCardDo {
+	return c.withDO(c.DO.With(name, q))
+}
+
+func (c creditCardDo) WithRecursive(name string, q gen.SubQuery) *creditCardDo {
+	return c.withDO(c.DO.WithRecursive(name, q))
+}
+
 func (c creditCardDo) Group(cols ...field.Expr) *creditCardDo {
 	return c.withDO(c.DO.Group(cols...))
 }
@@ -214,6 +223,29 @@ func (c creditCardDo) Unscoped() *creditCardDo {
 	return c.withDO(c.DO.Unscoped())
 }
 
+func (c creditCardDo) CrossTenant() *creditCardDo {
+	return c.withDO(c.DO.CrossTenant())
+}
+
+func (c creditCardDo) OnConflict(cols ...field.Expr) gen.Upsert[*creditCardDo] {
+	return gen.NewUpsert(&c.DO, func(do gen.Dao) *creditCardDo { return c.withDO(do) }, cols...)
+}
+func (c creditCardDo) WithDeleted() *creditCardDo {
+	return c.withDO(c.DO.WithDeleted())
+}
+
+func (c creditCardDo) OnlyDeleted() *creditCardDo {
+	return c.withDO(c.DO.OnlyDeleted())
+}
+
+func (c creditCardDo) Restore() (info gen.ResultInfo, err error) {
+	return c.DO.Restore()
+}
+
+func (c creditCardDo) ForceDelete(models ...*model.CreditCard) (info gen.ResultInfo, err error) {
+	return c.DO.ForceDelete(models)
+}
+
 func (c creditCardDo) Create(values ...*model.CreditCard) error {
 	if len(values) == 0 {
 		return nil
@@ -276,6 +308,10 @@ func (c creditCardDo) FindInBatches(result *[]*model.CreditCard, batchSize int,
 	return c.DO.FindInBatches(result, batchSize, fc)
 }
 
+func (c creditCardDo) Iter() *gen.Iterator[*model.CreditCard] {
+	return gen.NewIterator[*model.CreditCard](&c.DO)
+}
+
 func (c creditCardDo) Attrs(attrs ...field.AssignExpr) *creditCardDo {
 	return c.withDO(c.DO.Attrs(attrs...))
 }
@@ -329,6 +365,14 @@ func (c creditCardDo) FindByPage(offset int, limit int) (result []*model.CreditC
 	return
 }
 
+func (c creditCardDo) FindByCursor(cursor string, limit int, orderCols ...field.OrderExpr) (result []*model.CreditCard, next string, prev string, err error) {
+	results, next, prev, err := c.DO.FindByCursor(cursor, limit, orderCols...)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return results.([]*model.CreditCard), next, prev, nil
+}
+
 func (c creditCardDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
 	count, err = c.Count()
 	if err != nil {
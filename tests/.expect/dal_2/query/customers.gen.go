@@ -24,6 +24,7 @@ func newCustomer(db *gorm.DB, opts ...gen.DOOption) customer {
 
 	_customer.customerDo.UseDB(db, opts...)
 	_customer.customerDo.UseModel(&model.Customer{})
+	_customer.customerDo.UseQueryName("customer")
 
 	tableName := _customer.customerDo.TableName()
 	_customer.ALL = field.NewAsterisk(tableName)
@@ -180,6 +181,14 @@ func (c customerDo) RightJoin(table schema.Tabler, on ...field.Expr) *customerDo
 	return c.withDO(c.DO.RightJoin(table, on...))
 }
 
+func (c customerDo) With(name string, q gen.SubQuery) *customerDo {
+	return c.withDO(c.DO.With(name, q))
+}
+
+func (c customerDo) WithRecursive(name string, q gen.SubQuery) *customerDo {
+	return c.withDO(c.DO.WithRecursive(name, q))
+}
+
 func (c customerDo) Group(cols ...field.Expr) *customerDo {
 	return c.withDO(c.DO.Group(cols...))
 }
@@ -204,6 +213,29 @@ func (c customerDo) Unscoped() *customerDo {
 	return c.withDO(c.DO.Unscoped())
 }
 
+func (c customerDo) CrossTenant() *customerDo {
+	return c.withDO(c.DO.CrossTenant())
+}
+
+func (c customerDo) OnConflict(cols ...field.Expr) gen.Upsert[*customerDo] {
+	return gen.NewUpsert(&c.DO, func(do gen.Dao) *customerDo { return c.withDO(do) }, cols...)
+}
+func (c customerDo) WithDeleted() *customerDo {
+	return c.withDO(c.DO.WithDeleted())
+}
+
+func (c customerDo) OnlyDeleted() *customerDo {
+	return c.withDO(c.DO.OnlyDeleted())
+}
+
+func (c customerDo) Restore() (info gen.ResultInfo, err error) {
+	return c.DO.Restore()
+}
+
+func (c customerDo) ForceDelete(models ...*model.Customer) (info gen.ResultInfo, err error) {
+	return c.DO.ForceDelete(models)
+}
+
 func (c customerDo) Create(values ...*model.Customer) error {
 	if len(values) == 0 {
 		return nil
@@ -266,6 +298,10 @@ func (c customerDo) FindInBatches(result *[]*model.Customer, batchSize int, fc f
 	return c.DO.FindInBatches(result, batchSize, fc)
 }
 
+func (c customerDo) Iter() *gen.Iterator[*model.Customer] {
+	return gen.NewIterator[*model.Customer](&c.DO)
+}
+
 func (c customerDo) Attrs(attrs ...field.AssignExpr) *customerDo {
 	return c.withDO(c.DO.Attrs(attrs...))
 }
@@ -319,6 +355,14 @@ func (c customerDo) FindByPage(offset int, limit int) (result []*model.Customer,
 	return
 }
 
+func (c customerDo) FindByCursor(cursor string, limit int, orderCols ...field.OrderExpr) (result []*model.Customer, next string, prev string, err error) {
+	results, next, prev, err := c.DO.FindByCursor(cursor, limit, orderCols...)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return results.([]*model.Customer), next, prev, nil
+}
+
 func (c customerDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
 	count, err = c.Count()
 	if err != nil {
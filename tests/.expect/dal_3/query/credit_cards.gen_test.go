@@ -50,6 +50,11 @@ func Test_creditCardQuery(t *testing.T) {
 		t.Error("create item in table <credit_cards> fail:", err)
 	}
 
+	err = _do.OnConflict().DoNothing().Create(&model.CreditCard{})
+	if err != nil {
+		t.Error("OnConflict() create item in table <credit_cards> fail:", err)
+	}
+
 	err = _do.CreateInBatches([]*model.CreditCard{{}, {}}, 10)
 	if err != nil {
 		t.Error("create item in table <credit_cards> fail:", err)
@@ -80,6 +85,13 @@ func Test_creditCardQuery(t *testing.T) {
 		t.Error("FindInBatches() on table <credit_cards> fail:", err)
 	}
 
+	it := _do.Where(primaryKey.IsNotNull()).Iter()
+	for it.Next() {
+	}
+	if err = it.Err(); err != nil {
+		t.Error("Iter() on table <credit_cards> fail:", err)
+	}
+
 	_, err = _do.Select(creditCard.ALL).Where(primaryKey.IsNotNull()).Order(primaryKey.Desc()).Find()
 	if err != nil {
 		t.Error("Find() on table <credit_cards> fail:", err)
@@ -115,6 +127,11 @@ func Test_creditCardQuery(t *testing.T) {
 		t.Error("ScanByPage() on table <credit_cards> fail:", err)
 	}
 
+	_, _, _, err = _do.FindByCursor("", 1, primaryKey)
+	if err != nil {
+		t.Error("FindByCursor() on table <credit_cards> fail:", err)
+	}
+
 	_, err = _do.Attrs(primaryKey).Assign(primaryKey).FirstOrInit()
 	if err != nil {
 		t.Error("FirstOrInit() on table <credit_cards> fail:", err)
@@ -142,4 +159,34 @@ func Test_creditCardQuery(t *testing.T) {
 	if err != nil {
 		t.Error("Not/Or/Clauses on table <credit_cards> fail:", err)
 	}
+
+	_, err = _do.Where(primaryKey.IsNotNull()).Delete()
+	if err != nil {
+		t.Error("soft Delete() on table <credit_cards> fail:", err)
+	}
+
+	_, err = _do.OnlyDeleted().Take()
+	if err != nil {
+		t.Error("OnlyDeleted() on table <credit_cards> fail:", err)
+	}
+
+	_, err = _do.OnlyDeleted().Where(primaryKey.IsNotNull()).Restore()
+	if err != nil {
+		t.Error("Restore() on table <credit_cards> fail:", err)
+	}
+
+	_, err = _do.Take()
+	if err != nil {
+		t.Error("Take() restored item on table <credit_cards> fail:", err)
+	}
+
+	_, err = _do.Where(primaryKey.IsNotNull()).ForceDelete()
+	if err != nil {
+		t.Error("ForceDelete() on table <credit_cards> fail:", err)
+	}
+
+	_, err = _do.WithDeleted().Take()
+	if err == nil {
+		t.Error("WithDeleted() on table <credit_cards> should find no force deleted item")
+	}
 }
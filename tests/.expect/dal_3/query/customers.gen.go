@@ -25,6 +25,7 @@ func newCustomer(db *gorm.DB, opts ...gen.DOOption) customer {
 
 	_customer.customerDo.UseDB(db, opts...)
 	_customer.customerDo.UseModel(&model.Customer{})
+	_customer.customerDo.UseQueryName("customer")
 
 	tableName := _customer.customerDo.TableName()
 	_customer.ALL = field.NewAsterisk(tableName)
@@ -135,6 +136,8 @@ type ICustomerDo interface {
 	Join(table schema.Tabler, on ...field.Expr) ICustomerDo
 	LeftJoin(table schema.Tabler, on ...field.Expr) ICustomerDo
 	RightJoin(table schema.Tabler, on ...field.Expr) ICustomerDo
+	With(name string, q gen.SubQuery) ICustomerDo
+	WithRecursive(name string, q gen.SubQuery) ICustomerDo
 	Group(cols ...field.Expr) ICustomerDo
 	Having(conds ...gen.Condition) ICustomerDo
 	Limit(limit int) ICustomerDo
@@ -142,6 +145,12 @@ type ICustomerDo interface {
 	Count() (count int64, err error)
 	Scopes(funcs ...func(gen.Dao) gen.Dao) ICustomerDo
 	Unscoped() ICustomerDo
+	CrossTenant() ICustomerDo
+	WithDeleted() ICustomerDo
+	OnlyDeleted() ICustomerDo
+	Restore() (info gen.ResultInfo, err error)
+	ForceDelete(...*model.Customer) (info gen.ResultInfo, err error)
+	OnConflict(cols ...field.Expr) gen.Upsert[ICustomerDo]
 	Create(values ...*model.Customer) error
 	CreateInBatches(values []*model.Customer, batchSize int) error
 	Save(values ...*model.Customer) error
@@ -151,6 +160,7 @@ type ICustomerDo interface {
 	Find() ([]*model.Customer, error)
 	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.Customer, err error)
 	FindInBatches(result *[]*model.Customer, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Iter() *gen.Iterator[*model.Customer]
 	Pluck(column field.Expr, dest interface{}) error
 	Delete(...*model.Customer) (info gen.ResultInfo, err error)
 	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
@@ -168,6 +178,7 @@ type ICustomerDo interface {
 	FirstOrCreate() (*model.Customer, error)
 	FindByPage(offset int, limit int) (result []*model.Customer, count int64, err error)
 	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	FindByCursor(cursor string, limit int, orderCols ...field.OrderExpr) (result []*model.Customer, next string, prev string, err error)
 	Rows() (*sql.Rows, error)
 	Row() *sql.Row
 	Scan(result interface{}) (err error)
@@ -244,6 +255,14 @@ func (c customerDo) RightJoin(table schema.Tabler, on ...field.Expr) ICustomerDo
 	return c.withDO(c.DO.RightJoin(table, on...))
 }
 
+func (c customerDo) With(name string, q gen.SubQuery) ICustomerDo {
+	return c.withDO(c.DO.With(name, q))
+}
+
+func (c customerDo) WithRecursive(name string, q gen.SubQuery) ICustomerDo {
+	return c.withDO(c.DO.WithRecursive(name, q))
+}
+
 func (c customerDo) Group(cols ...field.Expr) ICustomerDo {
 	return c.withDO(c.DO.Group(cols...))
 }
@@ -268,6 +287,29 @@ func (c customerDo) Unscoped() ICustomerDo {
 	return c.withDO(c.DO.Unscoped())
 }
 
+func (c customerDo) CrossTenant() ICustomerDo {
+	return c.withDO(c.DO.CrossTenant())
+}
+
+func (c customerDo) OnConflict(cols ...field.Expr) gen.Upsert[ICustomerDo] {
+	return gen.NewUpsert(&c.DO, func(do gen.Dao) ICustomerDo { return c.withDO(do) }, cols...)
+}
+func (c customerDo) WithDeleted() ICustomerDo {
+	return c.withDO(c.DO.WithDeleted())
+}
+
+func (c customerDo) OnlyDeleted() ICustomerDo {
+	return c.withDO(c.DO.OnlyDeleted())
+}
+
+func (c customerDo) Restore() (info gen.ResultInfo, err error) {
+	return c.DO.Restore()
+}
+
+func (c customerDo) ForceDelete(models ...*model.Customer) (info gen.ResultInfo, err error) {
+	return c.DO.ForceDelete(models)
+}
+
 func (c customerDo) Create(values ...*model.Customer) error {
 	if len(values) == 0 {
 		return nil
@@ -330,6 +372,10 @@ func (c customerDo) FindInBatches(result *[]*model.Customer, batchSize int, fc f
 	return c.DO.FindInBatches(result, batchSize, fc)
 }
 
+func (c customerDo) Iter() *gen.Iterator[*model.Customer] {
+	return gen.NewIterator[*model.Customer](&c.DO)
+}
+
 func (c customerDo) Attrs(attrs ...field.AssignExpr) ICustomerDo {
 	return c.withDO(c.DO.Attrs(attrs...))
 }
@@ -383,6 +429,14 @@ func (c customerDo) FindByPage(offset int, limit int) (result []*model.Customer,
 	return
 }
 
+func (c customerDo) FindByCursor(cursor string, limit int, orderCols ...field.OrderExpr) (result []*model.Customer, next string, prev string, err error) {
+	results, next, prev, err := c.DO.FindByCursor(cursor, limit, orderCols...)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return results.([]*model.Customer), next, prev, nil
+}
+
 func (c customerDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
 	count, err = c.Count()
 	if err != nil {
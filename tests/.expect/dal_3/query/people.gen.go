@@ -25,6 +25,7 @@ func newPerson(db *gorm.DB, opts ...gen.DOOption) person {
 
 	_person.personDo.UseDB(db, opts...)
 	_person.personDo.UseModel(&model.Person{})
+	_person.personDo.UseQueryName("person")
 
 	tableName := _person.personDo.TableName()
 	_person.ALL = field.NewAsterisk(tableName)
@@ -43,7 +44,7 @@ func newPerson(db *gorm.DB, opts ...gen.DOOption) person {
 	_person.Number = field.NewInt32(tableName, "number")
 	_person.Birth = field.NewTime(tableName, "birth")
 	_person.XMLHTTPRequest = field.NewString(tableName, "xmlHTTPRequest")
-	_person.JStr = field.NewString(tableName, "jStr")
+	_person.JStr = field.NewJSON(tableName, "jStr")
 	_person.Geo = field.NewString(tableName, "geo")
 	_person.Mint = field.NewInt32(tableName, "mint")
 	_person.Blank = field.NewString(tableName, "blank")
@@ -74,7 +75,7 @@ type person struct {
 	Number         field.Int32
 	Birth          field.Time
 	XMLHTTPRequest field.String
-	JStr           field.String
+	JStr           field.JSON
 	Geo            field.String
 	Mint           field.Int32
 	Blank          field.String
@@ -111,7 +112,7 @@ func (p *person) updateTableName(table string) *person {
 	p.Number = field.NewInt32(table, "number")
 	p.Birth = field.NewTime(table, "birth")
 	p.XMLHTTPRequest = field.NewString(table, "xmlHTTPRequest")
-	p.JStr = field.NewString(table, "jStr")
+	p.JStr = field.NewJSON(table, "jStr")
 	p.Geo = field.NewString(table, "geo")
 	p.Mint = field.NewInt32(table, "mint")
 	p.Blank = field.NewString(table, "blank")
@@ -199,6 +200,8 @@ type IPersonDo interface {
 	Join(table schema.Tabler, on ...field.Expr) IPersonDo
 	LeftJoin(table schema.Tabler, on ...field.Expr) IPersonDo
 	RightJoin(table schema.Tabler, on ...field.Expr) IPersonDo
+	With(name string, q gen.SubQuery) IPersonDo
+	WithRecursive(name string, q gen.SubQuery) IPersonDo
 	Group(cols ...field.Expr) IPersonDo
 	Having(conds ...gen.Condition) IPersonDo
 	Limit(limit int) IPersonDo
@@ -206,6 +209,12 @@ type IPersonDo interface {
 	Count() (count int64, err error)
 	Scopes(funcs ...func(gen.Dao) gen.Dao) IPersonDo
 	Unscoped() IPersonDo
+	CrossTenant() IPersonDo
+	WithDeleted() IPersonDo
+	OnlyDeleted() IPersonDo
+	Restore() (info gen.ResultInfo, err error)
+	ForceDelete(...*model.Person) (info gen.ResultInfo, err error)
+	OnConflict(cols ...field.Expr) gen.Upsert[IPersonDo]
 	Create(values ...*model.Person) error
 	CreateInBatches(values []*model.Person, batchSize int) error
 	Save(values ...*model.Person) error
@@ -215,6 +224,7 @@ type IPersonDo interface {
 	Find() ([]*model.Person, error)
 	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.Person, err error)
 	FindInBatches(result *[]*model.Person, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Iter() *gen.Iterator[*model.Person]
 	Pluck(column field.Expr, dest interface{}) error
 	Delete(...*model.Person) (info gen.ResultInfo, err error)
 	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
@@ -232,6 +242,7 @@ type IPersonDo interface {
 	FirstOrCreate() (*model.Person, error)
 	FindByPage(offset int, limit int) (result []*model.Person, count int64, err error)
 	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	FindByCursor(cursor string, limit int, orderCols ...field.OrderExpr) (result []*model.Person, next string, prev string, err error)
 	Rows() (*sql.Rows, error)
 	Row() *sql.Row
 	Scan(result interface{}) (err error)
@@ -308,6 +319,14 @@ func (p personDo) RightJoin(table schema.Tabler, on ...field.Expr) IPersonDo {
 	return p.withDO(p.DO.RightJoin(table, on...))
 }
 
+func (p personDo) With(name string, q gen.SubQuery) IPersonDo {
+	return p.withDO(p.DO.With(name, q))
+}
+
+func (p personDo) WithRecursive(name string, q gen.SubQuery) IPersonDo {
+	return p.withDO(p.DO.WithRecursive(name, q))
+}
+
 func (p personDo) Group(cols ...field.Expr) IPersonDo {
 	return p.withDO(p.DO.Group(cols...))
 }
@@ -332,6 +351,29 @@ func (p personDo) Unscoped() IPersonDo {
 	return p.withDO(p.DO.Unscoped())
 }
 
+func (p personDo) CrossTenant() IPersonDo {
+	return p.withDO(p.DO.CrossTenant())
+}
+
+func (p personDo) OnConflict(cols ...field.Expr) gen.Upsert[IPersonDo] {
+	return gen.NewUpsert(&p.DO, func(do gen.Dao) IPersonDo { return p.withDO(do) }, cols...)
+}
+func (p personDo) WithDeleted() IPersonDo {
+	return p.withDO(p.DO.WithDeleted())
+}
+
+func (p personDo) OnlyDeleted() IPersonDo {
+	return p.withDO(p.DO.OnlyDeleted())
+}
+
+func (p personDo) Restore() (info gen.ResultInfo, err error) {
+	return p.DO.Restore()
+}
+
+func (p personDo) ForceDelete(models ...*model.Person) (info gen.ResultInfo, err error) {
+	return p.DO.ForceDelete(models)
+}
+
 func (p personDo) Create(values ...*model.Person) error {
 	if len(values) == 0 {
 		return nil
@@ -394,6 +436,10 @@ func (p personDo) FindInBatches(result *[]*model.Person, batchSize int, fc func(
 	return p.DO.FindInBatches(result, batchSize, fc)
 }
 
+func (p personDo) Iter() *gen.Iterator[*model.Person] {
+	return gen.NewIterator[*model.Person](&p.DO)
+}
+
 func (p personDo) Attrs(attrs ...field.AssignExpr) IPersonDo {
 	return p.withDO(p.DO.Attrs(attrs...))
 }
@@ -447,6 +493,14 @@ func (p personDo) FindByPage(offset int, limit int) (result []*model.Person, cou
 	return
 }
 
+func (p personDo) FindByCursor(cursor string, limit int, orderCols ...field.OrderExpr) (result []*model.Person, next string, prev string, err error) {
+	results, next, prev, err := p.DO.FindByCursor(cursor, limit, orderCols...)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return results.([]*model.Person), next, prev, nil
+}
+
 func (p personDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
 	count, err = p.Count()
 	if err != nil {
@@ -50,6 +50,11 @@ func Test_personQuery(t *testing.T) {
 		t.Error("create item in table <people> fail:", err)
 	}
 
+	err = _do.OnConflict().DoNothing().Create(&model.Person{})
+	if err != nil {
+		t.Error("OnConflict() create item in table <people> fail:", err)
+	}
+
 	err = _do.CreateInBatches([]*model.Person{{}, {}}, 10)
 	if err != nil {
 		t.Error("create item in table <people> fail:", err)
@@ -80,6 +85,13 @@ func Test_personQuery(t *testing.T) {
 		t.Error("FindInBatches() on table <people> fail:", err)
 	}
 
+	it := _do.Where(primaryKey.IsNotNull()).Iter()
+	for it.Next() {
+	}
+	if err = it.Err(); err != nil {
+		t.Error("Iter() on table <people> fail:", err)
+	}
+
 	_, err = _do.Select(person.ALL).Where(primaryKey.IsNotNull()).Order(primaryKey.Desc()).Find()
 	if err != nil {
 		t.Error("Find() on table <people> fail:", err)
@@ -115,6 +127,11 @@ func Test_personQuery(t *testing.T) {
 		t.Error("ScanByPage() on table <people> fail:", err)
 	}
 
+	_, _, _, err = _do.FindByCursor("", 1, primaryKey)
+	if err != nil {
+		t.Error("FindByCursor() on table <people> fail:", err)
+	}
+
 	_, err = _do.Attrs(primaryKey).Assign(primaryKey).FirstOrInit()
 	if err != nil {
 		t.Error("FirstOrInit() on table <people> fail:", err)
@@ -142,4 +159,34 @@ func Test_personQuery(t *testing.T) {
 	if err != nil {
 		t.Error("Not/Or/Clauses on table <people> fail:", err)
 	}
+
+	_, err = _do.Where(primaryKey.IsNotNull()).Delete()
+	if err != nil {
+		t.Error("soft Delete() on table <people> fail:", err)
+	}
+
+	_, err = _do.OnlyDeleted().Take()
+	if err != nil {
+		t.Error("OnlyDeleted() on table <people> fail:", err)
+	}
+
+	_, err = _do.OnlyDeleted().Where(primaryKey.IsNotNull()).Restore()
+	if err != nil {
+		t.Error("Restore() on table <people> fail:", err)
+	}
+
+	_, err = _do.Take()
+	if err != nil {
+		t.Error("Take() restored item on table <people> fail:", err)
+	}
+
+	_, err = _do.Where(primaryKey.IsNotNull()).ForceDelete()
+	if err != nil {
+		t.Error("ForceDelete() on table <people> fail:", err)
+	}
+
+	_, err = _do.WithDeleted().Take()
+	if err == nil {
+		t.Error("WithDeleted() on table <people> should find no force deleted item")
+	}
 }
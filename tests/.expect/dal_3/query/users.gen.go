@@ -25,6 +25,7 @@ func newUser(db *gorm.DB, opts ...gen.DOOption) user {
 
 	_user.userDo.UseDB(db, opts...)
 	_user.userDo.UseModel(&model.User{})
+	_user.userDo.UseQueryName("user")
 
 	tableName := _user.userDo.TableName()
 	_user.ALL = field.NewAsterisk(tableName)
@@ -161,6 +162,8 @@ type IUserDo interface {
 	Join(table schema.Tabler, on ...field.Expr) IUserDo
 	LeftJoin(table schema.Tabler, on ...field.Expr) IUserDo
 	RightJoin(table schema.Tabler, on ...field.Expr) IUserDo
+	With(name string, q gen.SubQuery) IUserDo
+	WithRecursive(name string, q gen.SubQuery) IUserDo
 	Group(cols ...field.Expr) IUserDo
 	Having(conds ...gen.Condition) IUserDo
 	Limit(limit int) IUserDo
@@ -168,6 +171,8 @@ type IUserDo interface {
 	Count() (count int64, err error)
 	Scopes(funcs ...func(gen.Dao) gen.Dao) IUserDo
 	Unscoped() IUserDo
+	CrossTenant() IUserDo
+	OnConflict(cols ...field.Expr) gen.Upsert[IUserDo]
 	Create(values ...*model.User) error
 	CreateInBatches(values []*model.User, batchSize int) error
 	Save(values ...*model.User) error
@@ -177,6 +182,7 @@ type IUserDo interface {
 	Find() ([]*model.User, error)
 	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.User, err error)
 	FindInBatches(result *[]*model.User, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Iter() *gen.Iterator[*model.User]
 	Pluck(column field.Expr, dest interface{}) error
 	Delete(...*model.User) (info gen.ResultInfo, err error)
 	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
@@ -194,6 +200,7 @@ type IUserDo interface {
 	FirstOrCreate() (*model.User, error)
 	FindByPage(offset int, limit int) (result []*model.User, count int64, err error)
 	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	FindByCursor(cursor string, limit int, orderCols ...field.OrderExpr) (result []*model.User, next string, prev string, err error)
 	Rows() (*sql.Rows, error)
 	Row() *sql.Row
 	Scan(result interface{}) (err error)
@@ -270,6 +277,14 @@ func (u userDo) RightJoin(table schema.Tabler, on ...field.Expr) IUserDo {
 	return u.withDO(u.DO.RightJoin(table, on...))
 }
 
+func (u userDo) With(name string, q gen.SubQuery) IUserDo {
+	return u.withDO(u.DO.With(name, q))
+}
+
+func (u userDo) WithRecursive(name string, q gen.SubQuery) IUserDo {
+	return u.withDO(u.DO.WithRecursive(name, q))
+}
+
 func (u userDo) Group(cols ...field.Expr) IUserDo {
 	return u.withDO(u.DO.Group(cols...))
 }
@@ -294,6 +309,13 @@ func (u userDo) Unscoped() IUserDo {
 	return u.withDO(u.DO.Unscoped())
 }
 
+func (u userDo) CrossTenant() IUserDo {
+	return u.withDO(u.DO.CrossTenant())
+}
+
+func (u userDo) OnConflict(cols ...field.Expr) gen.Upsert[IUserDo] {
+	return gen.NewUpsert(&u.DO, func(do gen.Dao) IUserDo { return u.withDO(do) }, cols...)
+}
 func (u userDo) Create(values ...*model.User) error {
 	if len(values) == 0 {
 		return nil
@@ -356,6 +378,10 @@ func (u userDo) FindInBatches(result *[]*model.User, batchSize int, fc func(tx g
 	return u.DO.FindInBatches(result, batchSize, fc)
 }
 
+func (u userDo) Iter() *gen.Iterator[*model.User] {
+	return gen.NewIterator[*model.User](&u.DO)
+}
+
 func (u userDo) Attrs(attrs ...field.AssignExpr) IUserDo {
 	return u.withDO(u.DO.Attrs(attrs...))
 }
@@ -409,6 +435,14 @@ func (u userDo) FindByPage(offset int, limit int) (result []*model.User, count i
 	return
 }
 
+func (u userDo) FindByCursor(cursor string, limit int, orderCols ...field.OrderExpr) (result []*model.User, next string, prev string, err error) {
+	results, next, prev, err := u.DO.FindByCursor(cursor, limit, orderCols...)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return results.([]*model.User), next, prev, nil
+}
+
 func (u userDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
 	count, err = u.Count()
 	if err != nil {
@@ -50,6 +50,11 @@ func Test_userQuery(t *testing.T) {
 		t.Error("create item in table <users> fail:", err)
 	}
 
+	err = _do.OnConflict().DoNothing().Create(&model.User{})
+	if err != nil {
+		t.Error("OnConflict() create item in table <users> fail:", err)
+	}
+
 	err = _do.CreateInBatches([]*model.User{{}, {}}, 10)
 	if err != nil {
 		t.Error("create item in table <users> fail:", err)
@@ -80,6 +85,13 @@ func Test_userQuery(t *testing.T) {
 		t.Error("FindInBatches() on table <users> fail:", err)
 	}
 
+	it := _do.Where(primaryKey.IsNotNull()).Iter()
+	for it.Next() {
+	}
+	if err = it.Err(); err != nil {
+		t.Error("Iter() on table <users> fail:", err)
+	}
+
 	_, err = _do.Select(user.ALL).Where(primaryKey.IsNotNull()).Order(primaryKey.Desc()).Find()
 	if err != nil {
 		t.Error("Find() on table <users> fail:", err)
@@ -115,6 +127,11 @@ func Test_userQuery(t *testing.T) {
 		t.Error("ScanByPage() on table <users> fail:", err)
 	}
 
+	_, _, _, err = _do.FindByCursor("", 1, primaryKey)
+	if err != nil {
+		t.Error("FindByCursor() on table <users> fail:", err)
+	}
+
 	_, err = _do.Attrs(primaryKey).Assign(primaryKey).FirstOrInit()
 	if err != nil {
 		t.Error("FirstOrInit() on table <users> fail:", err)
@@ -25,6 +25,7 @@ func newBank(db *gorm.DB, opts ...gen.DOOption) bank {
 
 	_bank.bankDo.UseDB(db, opts...)
 	_bank.bankDo.UseModel(&model.Bank{})
+	_bank.bankDo.UseQueryName("bank")
 
 	tableName := _bank.bankDo.TableName()
 	_bank.ALL = field.NewAsterisk(tableName)
@@ -131,6 +132,8 @@ type IBankDo interface {
 	Join(table schema.Tabler, on ...field.Expr) IBankDo
 	LeftJoin(table schema.Tabler, on ...field.Expr) IBankDo
 	RightJoin(table schema.Tabler, on ...field.Expr) IBankDo
+	With(name string, q gen.SubQuery) IBankDo
+	WithRecursive(name string, q gen.SubQuery) IBankDo
 	Group(cols ...field.Expr) IBankDo
 	Having(conds ...gen.Condition) IBankDo
 	Limit(limit int) IBankDo
@@ -138,6 +141,8 @@ type IBankDo interface {
 	Count() (count int64, err error)
 	Scopes(funcs ...func(gen.Dao) gen.Dao) IBankDo
 	Unscoped() IBankDo
+	CrossTenant() IBankDo
+	OnConflict(cols ...field.Expr) gen.Upsert[IBankDo]
 	Create(values ...*model.Bank) error
 	CreateInBatches(values []*model.Bank, batchSize int) error
 	Save(values ...*model.Bank) error
@@ -147,6 +152,7 @@ type IBankDo interface {
 	Find() ([]*model.Bank, error)
 	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.Bank, err error)
 	FindInBatches(result *[]*model.Bank, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Iter() *gen.Iterator[*model.Bank]
 	Pluck(column field.Expr, dest interface{}) error
 	Delete(...*model.Bank) (info gen.ResultInfo, err error)
 	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
@@ -164,6 +170,7 @@ type IBankDo interface {
 	FirstOrCreate() (*model.Bank, error)
 	FindByPage(offset int, limit int) (result []*model.Bank, count int64, err error)
 	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	FindByCursor(cursor string, limit int, orderCols ...field.OrderExpr) (result []*model.Bank, next string, prev string, err error)
 	Rows() (*sql.Rows, error)
 	Row() *sql.Row
 	Scan(result interface{}) (err error)
@@ -240,6 +247,14 @@ func (b bankDo) RightJoin(table schema.Tabler, on ...field.Expr) IBankDo {
 	return b.withDO(b.DO.RightJoin(table, on...))
 }
 
+func (b bankDo) With(name string, q gen.SubQuery) IBankDo {
+	return b.withDO(b.DO.With(name, q))
+}
+
+func (b bankDo) WithRecursive(name string, q gen.SubQuery) IBankDo {
+	return b.withDO(b.DO.WithRecursive(name, q))
+}
+
 func (b bankDo) Group(cols ...field.Expr) IBankDo {
 	return b.withDO(b.DO.Group(cols...))
 }
@@ -264,6 +279,13 @@ func (b bankDo) Unscoped() IBankDo {
 	return b.withDO(b.DO.Unscoped())
 }
 
+func (b bankDo) CrossTenant() IBankDo {
+	return b.withDO(b.DO.CrossTenant())
+}
+
+func (b bankDo) OnConflict(cols ...field.Expr) gen.Upsert[IBankDo] {
+	return gen.NewUpsert(&b.DO, func(do gen.Dao) IBankDo { return b.withDO(do) }, cols...)
+}
 func (b bankDo) Create(values ...*model.Bank) error {
 	if len(values) == 0 {
 		return nil
@@ -326,6 +348,10 @@ func (b bankDo) FindInBatches(result *[]*model.Bank, batchSize int, fc func(tx g
 	return b.DO.FindInBatches(result, batchSize, fc)
 }
 
+func (b bankDo) Iter() *gen.Iterator[*model.Bank] {
+	return gen.NewIterator[*model.Bank](&b.DO)
+}
+
 func (b bankDo) Attrs(attrs ...field.AssignExpr) IBankDo {
 	return b.withDO(b.DO.Attrs(attrs...))
 }
@@ -379,6 +405,14 @@ func (b bankDo) FindByPage(offset int, limit int) (result []*model.Bank, count i
 	return
 }
 
+func (b bankDo) FindByCursor(cursor string, limit int, orderCols ...field.OrderExpr) (result []*model.Bank, next string, prev string, err error) {
+	results, next, prev, err := b.DO.FindByCursor(cursor, limit, orderCols...)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return results.([]*model.Bank), next, prev, nil
+}
+
 func (b bankDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
 	count, err = b.Count()
 	if err != nil {
@@ -25,6 +25,7 @@ func newCreditCard(db *gorm.DB, opts ...gen.DOOption) creditCard {
 
 	_creditCard.creditCardDo.UseDB(db, opts...)
 	_creditCard.creditCardDo.UseModel(&model.CreditCard{})
+	_creditCard.creditCardDo.UseQueryName("creditCard")
 
 	tableName := _creditCard.creditCardDo.TableName()
 	_creditCard.ALL = field.NewAsterisk(tableName)
@@ -145,6 +146,8 @@ type ICreditCardDo interface {
 	Join(table schema.Tabler, on ...field.Expr) ICreditCardDo
 	LeftJoin(table schema.Tabler, on ...field.Expr) ICreditCardDo
 	RightJoin(table schema.Tabler, on ...field.Expr) ICreditCardDo
+	With(name string, q gen.SubQuery) ICreditCardDo
+	WithRecursive(name string, q gen.SubQuery) ICreditCardDo
 	Group(cols ...field.Expr) ICreditCardDo
 	Having(conds ...gen.Condition) ICreditCardDo
 	Limit(limit int) ICreditCardDo
@@ -152,6 +155,12 @@ type ICreditCardDo interface {
 	Count() (count int64, err error)
 	Scopes(funcs ...func(gen.Dao) gen.Dao) ICreditCardDo
 	Unscoped() ICreditCardDo
+	CrossTenant() ICreditCardDo
+	WithDeleted() ICreditCardDo
+	OnlyDeleted() ICreditCardDo
+	Restore() (info gen.ResultInfo, err error)
+	ForceDelete(...*model.CreditCard) (info gen.ResultInfo, err error)
+	OnConflict(cols ...field.Expr) gen.Upsert[ICreditCardDo]
 	Create(values ...*model.CreditCard) error
 	CreateInBatches(values []*model.CreditCard, batchSize int) error
 	Save(values ...*model.CreditCard) error
@@ -161,6 +170,7 @@ type ICreditCardDo interface {
 	Find() ([]*model.CreditCard, error)
 	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.CreditCard, err error)
 	FindInBatches(result *[]*model.CreditCard, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Iter() *gen.Iterator[*model.CreditCard]
 	Pluck(column field.Expr, dest interface{}) error
 	Delete(...*model.CreditCard) (info gen.ResultInfo, err error)
 	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
@@ -178,6 +188,7 @@ type ICreditCardDo interface {
 	FirstOrCreate() (*model.CreditCard, error)
 	FindByPage(offset int, limit int) (result []*model.CreditCard, count int64, err error)
 	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	FindByCursor(cursor string, limit int, orderCols ...field.OrderExpr) (result []*model.CreditCard, next string, prev string, err error)
 	Rows() (*sql.Rows, error)
 	Row() *sql.Row
 	Scan(result interface{}) (err error)
@@ -254,6 +265,14 @@ func (c creditCardDo) RightJoin(table schema.Tabler, on ...field.Expr) ICreditCa
 	return c.withDO(c.DO.RightJoin(table, on...))
 }
 
+func (c creditCardDo) With(name string, q gen.SubQuery) ICreditCardDo {
+	return c.withDO(c.DO.With(name, q))
+}
+
+func (c creditCardDo) WithRecursive(name string, q gen.SubQuery) ICreditCardDo {
+	return c.withDO(c.DO.WithRecursive(name, q))
+}
+
 func (c creditCardDo) Group(cols ...field.Expr) ICreditCardDo {
 	return c.withDO(c.DO.Group(cols...))
 }
@@ -278,6 +297,29 @@ func (c creditCardDo) Unscoped() ICreditCardDo {
 	return c.withDO(c.DO.Unscoped())
 }
 
+func (c creditCardDo) CrossTenant() ICreditCardDo {
+	return c.withDO(c.DO.CrossTenant())
+}
+
+func (c creditCardDo) OnConflict(cols ...field.Expr) gen.Upsert[ICreditCardDo] {
+	return gen.NewUpsert(&c.DO, func(do gen.Dao) ICreditCardDo { return c.withDO(do) }, cols...)
+}
+func (c creditCardDo) WithDeleted() ICreditCardDo {
+	return c.withDO(c.DO.WithDeleted())
+}
+
+func (c creditCardDo) OnlyDeleted() ICreditCardDo {
+	return c.withDO(c.DO.OnlyDeleted())
+}
+
+func (c creditCardDo) Restore() (info gen.ResultInfo, err error) {
+	return c.DO.Restore()
+}
+
+func (c creditCardDo) ForceDelete(models ...*model.CreditCard) (info gen.ResultInfo, err error) {
+	return c.DO.ForceDelete(models)
+}
+
 func (c creditCardDo) Create(values ...*model.CreditCard) error {
 	if len(values) == 0 {
 		return nil
@@ -340,6 +382,10 @@ func (c creditCardDo) FindInBatches(result *[]*model.CreditCard, batchSize int,
 	return c.DO.FindInBatches(result, batchSize, fc)
 }
 
+func (c creditCardDo) Iter() *gen.Iterator[*model.CreditCard] {
+	return gen.NewIterator[*model.CreditCard](&c.DO)
+}
+
 func (c creditCardDo) Attrs(attrs ...field.AssignExpr) ICreditCardDo {
 	return c.withDO(c.DO.Attrs(attrs...))
 }
@@ -393,6 +439,14 @@ func (c creditCardDo) FindByPage(offset int, limit int) (result []*model.CreditC
 	return
 }
 
+func (c creditCardDo) FindByCursor(cursor string, limit int, orderCols ...field.OrderExpr) (result []*model.CreditCard, next string, prev string, err error) {
+	results, next, prev, err := c.DO.FindByCursor(cursor, limit, orderCols...)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return results.([]*model.CreditCard), next, prev, nil
+}
+
 func (c creditCardDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
 	count, err = c.Count()
 	if err != nil {
@@ -29,6 +29,7 @@ func newUser(db *gorm.DB, opts ...gen.DOOption) user {
 
 	_user.userDo.UseDB(db, opts...)
 	_user.userDo.UseModel(&model.User{})
+	_user.userDo.UseQueryName("user")
 
 	tableName := _user.userDo.TableName()
 	_user.ALL = field.NewAsterisk(tableName)
@@ -165,6 +166,8 @@ type IUserDo interface {
 	Join(table schema.Tabler, on ...field.Expr) IUserDo
 	LeftJoin(table schema.Tabler, on ...field.Expr) IUserDo
 	RightJoin(table schema.Tabler, on ...field.Expr) IUserDo
+	With(name string, q gen.SubQuery) IUserDo
+	WithRecursive(name string, q gen.SubQuery) IUserDo
 	Group(cols ...field.Expr) IUserDo
 	Having(conds ...gen.Condition) IUserDo
 	Limit(limit int) IUserDo
@@ -172,6 +175,8 @@ type IUserDo interface {
 	Count() (count int64, err error)
 	Scopes(funcs ...func(gen.Dao) gen.Dao) IUserDo
 	Unscoped() IUserDo
+	CrossTenant() IUserDo
+	OnConflict(cols ...field.Expr) gen.Upsert[IUserDo]
 	Create(values ...*model.User) error
 	CreateInBatches(values []*model.User, batchSize int) error
 	Save(values ...*model.User) error
@@ -181,6 +186,7 @@ type IUserDo interface {
 	Find() ([]*model.User, error)
 	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*model.User, err error)
 	FindInBatches(result *[]*model.User, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Iter() *gen.Iterator[*model.User]
 	Pluck(column field.Expr, dest interface{}) error
 	Delete(...*model.User) (info gen.ResultInfo, err error)
 	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
@@ -198,6 +204,7 @@ type IUserDo interface {
 	FirstOrCreate() (*model.User, error)
 	FindByPage(offset int, limit int) (result []*model.User, count int64, err error)
 	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	FindByCursor(cursor string, limit int, orderCols ...field.OrderExpr) (result []*model.User, next string, prev string, err error)
 	Rows() (*sql.Rows, error)
 	Row() *sql.Row
 	Scan(result interface{}) (err error)
@@ -227,6 +234,7 @@ type IUserDo interface {
 	AddUser1(name string, age int) (rowsAffected int64, err error)
 	AddUser2(name string, age int) (rowsAffected int64)
 	AddUser3(name string, age int) (result sql.Result)
+	AnalyzeUsers() (result sql.Result, err error)
 	AddUser4(name string, age int) (row *sql.Row)
 	AddUser5(name string, age int) (rows *sql.Rows)
 	AddUser6(name string, age int) (rows *sql.Rows, err error)
@@ -256,8 +264,11 @@ func (u userDo) FindByUsers(user model.User) (result []model.User) {
 	}
 	helper.JoinWhereBuilder(&generateSQL, whereSQL0)
 
+	_do, _done := u.Observe("TestIF", "FindByUsers")
+	defer _done(nil)
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Find(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Find(&result) // ignore_security_alert
 	_ = executeSQL
 
 	return
@@ -283,8 +294,11 @@ func (u userDo) FindByComplexIf(user *model.User) (result []model.User) {
 	}
 	helper.JoinWhereBuilder(&generateSQL, whereSQL0)
 
+	_do, _done := u.Observe("TestIF", "FindByComplexIf")
+	defer _done(nil)
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Find(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Find(&result) // ignore_security_alert
 	_ = executeSQL
 
 	return
@@ -303,8 +317,11 @@ func (u userDo) FindByIfTime(start time.Time) (result []model.User) {
 		generateSQL.WriteString("created_at > start ")
 	}
 
+	_do, _done := u.Observe("TestIF", "FindByIfTime")
+	defer _done(nil)
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String()).Find(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String()).Find(&result) // ignore_security_alert
 	_ = executeSQL
 
 	return
@@ -328,8 +345,11 @@ func (u userDo) TestFor(names []string) (result model.User, err error) {
 	}
 	generateSQL.WriteString("1=1 ")
 
+	_do, _done := u.Observe("TestFor", "TestFor")
+	defer func() { err = _done(err) }()
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
 	err = executeSQL.Error
 
 	return
@@ -353,8 +373,11 @@ func (u userDo) TestForKey(names []string, name string, value string) (result mo
 	}
 	generateSQL.WriteString("and 1=1 ")
 
+	_do, _done := u.Observe("TestFor", "TestForKey")
+	defer func() { err = _done(err) }()
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
 	err = executeSQL.Error
 
 	return
@@ -384,8 +407,11 @@ func (u userDo) TestForOr(names []string) (result model.User, err error) {
 	helper.JoinWhereBuilder(&generateSQL, whereSQL0)
 	generateSQL.WriteString(") ")
 
+	_do, _done := u.Observe("TestFor", "TestForOr")
+	defer func() { err = _done(err) }()
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
 	err = executeSQL.Error
 
 	return
@@ -413,8 +439,11 @@ func (u userDo) TestIfInFor(names []string, name string) (result model.User, err
 	}
 	generateSQL.WriteString("1=2 ")
 
+	_do, _done := u.Observe("TestFor", "TestIfInFor")
+	defer func() { err = _done(err) }()
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
 	err = executeSQL.Error
 
 	return
@@ -442,8 +471,11 @@ func (u userDo) TestForInIf(names []string, name string) (result model.User, err
 	}
 	generateSQL.WriteString("1=2 ")
 
+	_do, _done := u.Observe("TestFor", "TestForInIf")
+	defer func() { err = _done(err) }()
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
 	err = executeSQL.Error
 
 	return
@@ -469,8 +501,11 @@ func (u userDo) TestForInWhere(names []string, name string, forName string) (res
 	}
 	helper.JoinWhereBuilder(&generateSQL, whereSQL0)
 
+	_do, _done := u.Observe("TestFor", "TestForInWhere")
+	defer func() { err = _done(err) }()
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
 	err = executeSQL.Error
 
 	return
@@ -496,8 +531,11 @@ func (u userDo) TestForUserList(users []*model.User, name string) (result model.
 	}
 	helper.JoinWhereBuilder(&generateSQL, whereSQL0)
 
+	_do, _done := u.Observe("TestFor", "TestForUserList")
+	defer func() { err = _done(err) }()
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
 	err = executeSQL.Error
 
 	return
@@ -523,8 +561,11 @@ func (u userDo) TestForMap(param map[string]string, name string) (result model.U
 	}
 	helper.JoinWhereBuilder(&generateSQL, whereSQL0)
 
+	_do, _done := u.Observe("TestFor", "TestForMap")
+	defer func() { err = _done(err) }()
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
 	err = executeSQL.Error
 
 	return
@@ -554,8 +595,11 @@ func (u userDo) TestIfInIf(name string) (result model.User) {
 	}
 	helper.JoinWhereBuilder(&generateSQL, whereSQL0)
 
+	_do, _done := u.Observe("TestFor", "TestIfInIf")
+	defer _done(nil)
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
 	_ = executeSQL
 
 	return
@@ -588,8 +632,11 @@ func (u userDo) TestMoreFor(names []string, ids []int) (result []model.User) {
 	}
 	helper.JoinWhereBuilder(&generateSQL, whereSQL0)
 
+	_do, _done := u.Observe("TestFor", "TestMoreFor")
+	defer _done(nil)
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Find(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Find(&result) // ignore_security_alert
 	_ = executeSQL
 
 	return
@@ -625,8 +672,11 @@ func (u userDo) TestMoreFor2(names []string, ids []int) (result []model.User) {
 	}
 	helper.JoinWhereBuilder(&generateSQL, whereSQL0)
 
+	_do, _done := u.Observe("TestFor", "TestMoreFor2")
+	defer _done(nil)
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Find(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Find(&result) // ignore_security_alert
 	_ = executeSQL
 
 	return
@@ -653,8 +703,11 @@ func (u userDo) TestForInSet(users []model.User) (err error) {
 	helper.JoinSetBuilder(&generateSQL, setSQL0)
 	generateSQL.WriteString("where ")
 
+	_do, _done := u.Observe("TestFor", "TestForInSet")
+	defer func() { err = _done(err) }()
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Exec(generateSQL.String(), params...) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Write).Exec(generateSQL.String(), params...) // ignore_security_alert
 	err = executeSQL.Error
 
 	return
@@ -683,8 +736,11 @@ func (u userDo) TestInsertMoreInfo(users []model.User) (err error) {
 		generateSQL.WriteString("(?,?) ")
 	}
 
+	_do, _done := u.Observe("TestFor", "TestInsertMoreInfo")
+	defer func() { err = _done(err) }()
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Exec(generateSQL.String(), params...) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Write).Exec(generateSQL.String(), params...) // ignore_security_alert
 	err = executeSQL.Error
 
 	return
@@ -749,8 +805,11 @@ func (u userDo) TestIfElseFor(name string, users []model.User) (err error) {
 	}
 	helper.JoinWhereBuilder(&generateSQL, whereSQL0)
 
+	_do, _done := u.Observe("TestFor", "TestIfElseFor")
+	defer func() { err = _done(err) }()
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Exec(generateSQL.String(), params...) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Write).Exec(generateSQL.String(), params...) // ignore_security_alert
 	err = executeSQL.Error
 
 	return
@@ -776,8 +835,11 @@ func (u userDo) TestForLike(names []string) (result []model.User) {
 	}
 	helper.JoinWhereBuilder(&generateSQL, whereSQL0)
 
+	_do, _done := u.Observe("TestFor", "TestForLike")
+	defer _done(nil)
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Find(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Find(&result) // ignore_security_alert
 	_ = executeSQL
 
 	return
@@ -794,8 +856,10 @@ func (u userDo) AddUser(name string, age int) (result sql.Result, err error) {
 	params = append(params, age)
 	generateSQL.WriteString("INSERT INTO users (name,age) VALUES (?,?) ON DUPLICATE KEY UPDATE age=VALUES(age) ")
 
-	stmt := u.UnderlyingDB().Statement
-	result, err = stmt.ConnPool.ExecContext(stmt.Context, generateSQL.String(), params...) // ignore_security_alert
+	_do, _done := u.Observe("InsertMethod", "AddUser")
+	defer func() { err = _done(err) }()
+
+	result, err = _do.Clauses(dbresolver.Write).(*gen.DO).ExecResult(generateSQL.String(), params...) // ignore_security_alert
 
 	return
 }
@@ -811,8 +875,11 @@ func (u userDo) AddUser1(name string, age int) (rowsAffected int64, err error) {
 	params = append(params, age)
 	generateSQL.WriteString("INSERT INTO users (name,age) VALUES (?,?) ON DUPLICATE KEY UPDATE age=VALUES(age) ")
 
+	_do, _done := u.Observe("InsertMethod", "AddUser1")
+	defer func() { err = _done(err) }()
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Exec(generateSQL.String(), params...) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Write).Exec(generateSQL.String(), params...) // ignore_security_alert
 	rowsAffected = executeSQL.RowsAffected
 	err = executeSQL.Error
 
@@ -830,8 +897,11 @@ func (u userDo) AddUser2(name string, age int) (rowsAffected int64) {
 	params = append(params, age)
 	generateSQL.WriteString("INSERT INTO users (name,age) VALUES (?,?) ON DUPLICATE KEY UPDATE age=VALUES(age) ")
 
+	_do, _done := u.Observe("InsertMethod", "AddUser2")
+	defer _done(nil)
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Exec(generateSQL.String(), params...) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Write).Exec(generateSQL.String(), params...) // ignore_security_alert
 	rowsAffected = executeSQL.RowsAffected
 
 	return
@@ -848,8 +918,26 @@ func (u userDo) AddUser3(name string, age int) (result sql.Result) {
 	params = append(params, age)
 	generateSQL.WriteString("INSERT INTO users (name,age) VALUES (?,?) ON DUPLICATE KEY UPDATE age=VALUES(age) ")
 
-	stmt := u.UnderlyingDB().Statement
-	result, _ = stmt.ConnPool.ExecContext(stmt.Context, generateSQL.String(), params...) // ignore_security_alert
+	_do, _done := u.Observe("InsertMethod", "AddUser3")
+	defer _done(nil)
+
+	result, _ = _do.Clauses(dbresolver.Write).(*gen.DO).ExecResult(generateSQL.String(), params...) // ignore_security_alert
+
+	return
+}
+
+// AnalyzeUsers analyze users table on replica
+// @read
+//
+// ANALYZE TABLE users
+func (u userDo) AnalyzeUsers() (result sql.Result, err error) {
+	var generateSQL strings.Builder
+	generateSQL.WriteString("ANALYZE TABLE users ")
+
+	_do, _done := u.Observe("InsertMethod", "AnalyzeUsers")
+	defer func() { err = _done(err) }()
+
+	result, err = _do.Clauses(dbresolver.Read).(*gen.DO).ExecResult(generateSQL.String()) // ignore_security_alert
 
 	return
 }
@@ -865,7 +953,10 @@ func (u userDo) AddUser4(name string, age int) (row *sql.Row) {
 	params = append(params, age)
 	generateSQL.WriteString("INSERT INTO users (name,age) VALUES (?,?) ON DUPLICATE KEY UPDATE age=VALUES(age) ")
 
-	row = u.UnderlyingDB().Raw(generateSQL.String(), params...).Row() // ignore_security_alert
+	_do, _done := u.Observe("InsertMethod", "AddUser4")
+	defer _done(nil)
+
+	row = _do.UnderlyingDB().Clauses(dbresolver.Write).Raw(generateSQL.String(), params...).Row() // ignore_security_alert
 
 	return
 }
@@ -881,7 +972,10 @@ func (u userDo) AddUser5(name string, age int) (rows *sql.Rows) {
 	params = append(params, age)
 	generateSQL.WriteString("INSERT INTO users (name,age) VALUES (?,?) ON DUPLICATE KEY UPDATE age=VALUES(age) ")
 
-	rows, _ = u.UnderlyingDB().Raw(generateSQL.String(), params...).Rows() // ignore_security_alert
+	_do, _done := u.Observe("InsertMethod", "AddUser5")
+	defer _done(nil)
+
+	rows, _ = _do.UnderlyingDB().Clauses(dbresolver.Write).Raw(generateSQL.String(), params...).Rows() // ignore_security_alert
 
 	return
 }
@@ -897,7 +991,10 @@ func (u userDo) AddUser6(name string, age int) (rows *sql.Rows, err error) {
 	params = append(params, age)
 	generateSQL.WriteString("INSERT INTO users (name,age) VALUES (?,?) ON DUPLICATE KEY UPDATE age=VALUES(age) ")
 
-	rows, err = u.UnderlyingDB().Raw(generateSQL.String(), params...).Rows() // ignore_security_alert
+	_do, _done := u.Observe("InsertMethod", "AddUser6")
+	defer func() { err = _done(err) }()
+
+	rows, err = _do.UnderlyingDB().Clauses(dbresolver.Write).Raw(generateSQL.String(), params...).Rows() // ignore_security_alert
 
 	return
 }
@@ -912,8 +1009,11 @@ func (u userDo) FindByID(id int) (result model.User) {
 	params = append(params, id)
 	generateSQL.WriteString("select * from users where id=? ")
 
+	_do, _done := u.Observe("SelectMethod", "FindByID")
+	defer _done(nil)
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
 	_ = executeSQL
 
 	return
@@ -929,8 +1029,11 @@ func (u userDo) LikeSearch(name string) (result *model.User) {
 	params = append(params, name)
 	generateSQL.WriteString("SELECT * FROM users where name LIKE concat('%',?,'%') ")
 
+	_do, _done := u.Observe("SelectMethod", "LikeSearch")
+	defer _done(nil)
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
 	_ = executeSQL
 
 	return
@@ -946,8 +1049,11 @@ func (u userDo) InSearch(names []string) (result []*model.User) {
 	params = append(params, names)
 	generateSQL.WriteString("select * from users where name in ? ")
 
+	_do, _done := u.Observe("SelectMethod", "InSearch")
+	defer _done(nil)
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Find(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Find(&result) // ignore_security_alert
 	_ = executeSQL
 
 	return
@@ -963,8 +1069,11 @@ func (u userDo) ColumnSearch(name string, names []string) (result []*model.User)
 	params = append(params, names)
 	generateSQL.WriteString("select * from users where " + u.Quote(name) + " in ? ")
 
+	_do, _done := u.Observe("SelectMethod", "ColumnSearch")
+	defer _done(nil)
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Find(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Find(&result) // ignore_security_alert
 	_ = executeSQL
 
 	return
@@ -1038,6 +1147,14 @@ func (u userDo) RightJoin(table schema.Tabler, on ...field.Expr) IUserDo {
 	return u.withDO(u.DO.RightJoin(table, on...))
 }
 
+func (u userDo) With(name string, q gen.SubQuery) IUserDo {
+	return u.withDO(u.DO.With(name, q))
+}
+
+func (u userDo) WithRecursive(name string, q gen.SubQuery) IUserDo {
+	return u.withDO(u.DO.WithRecursive(name, q))
+}
+
 func (u userDo) Group(cols ...field.Expr) IUserDo {
 	return u.withDO(u.DO.Group(cols...))
 }
@@ -1062,6 +1179,13 @@ func (u userDo) Unscoped() IUserDo {
 	return u.withDO(u.DO.Unscoped())
 }
 
+func (u userDo) CrossTenant() IUserDo {
+	return u.withDO(u.DO.CrossTenant())
+}
+
+func (u userDo) OnConflict(cols ...field.Expr) gen.Upsert[IUserDo] {
+	return gen.NewUpsert(&u.DO, func(do gen.Dao) IUserDo { return u.withDO(do) }, cols...)
+}
 func (u userDo) Create(values ...*model.User) error {
 	if len(values) == 0 {
 		return nil
@@ -1124,6 +1248,10 @@ func (u userDo) FindInBatches(result *[]*model.User, batchSize int, fc func(tx g
 	return u.DO.FindInBatches(result, batchSize, fc)
 }
 
+func (u userDo) Iter() *gen.Iterator[*model.User] {
+	return gen.NewIterator[*model.User](&u.DO)
+}
+
 func (u userDo) Attrs(attrs ...field.AssignExpr) IUserDo {
 	return u.withDO(u.DO.Attrs(attrs...))
 }
@@ -1177,6 +1305,14 @@ func (u userDo) FindByPage(offset int, limit int) (result []*model.User, count i
 	return
 }
 
+func (u userDo) FindByCursor(cursor string, limit int, orderCols ...field.OrderExpr) (result []*model.User, next string, prev string, err error) {
+	results, next, prev, err := u.DO.FindByCursor(cursor, limit, orderCols...)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return results.([]*model.User), next, prev, nil
+}
+
 func (u userDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
 	count, err = u.Count()
 	if err != nil {
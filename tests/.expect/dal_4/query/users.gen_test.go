@@ -52,6 +52,11 @@ func Test_userQuery(t *testing.T) {
 		t.Error("create item in table <users> fail:", err)
 	}
 
+	err = _do.OnConflict().DoNothing().Create(&model.User{})
+	if err != nil {
+		t.Error("OnConflict() create item in table <users> fail:", err)
+	}
+
 	err = _do.CreateInBatches([]*model.User{{}, {}}, 10)
 	if err != nil {
 		t.Error("create item in table <users> fail:", err)
@@ -82,6 +87,13 @@ func Test_userQuery(t *testing.T) {
 		t.Error("FindInBatches() on table <users> fail:", err)
 	}
 
+	it := _do.Where(primaryKey.IsNotNull()).Iter()
+	for it.Next() {
+	}
+	if err = it.Err(); err != nil {
+		t.Error("Iter() on table <users> fail:", err)
+	}
+
 	_, err = _do.Select(user.ALL).Where(primaryKey.IsNotNull()).Order(primaryKey.Desc()).Find()
 	if err != nil {
 		t.Error("Find() on table <users> fail:", err)
@@ -117,6 +129,11 @@ func Test_userQuery(t *testing.T) {
 		t.Error("ScanByPage() on table <users> fail:", err)
 	}
 
+	_, _, _, err = _do.FindByCursor("", 1, primaryKey)
+	if err != nil {
+		t.Error("FindByCursor() on table <users> fail:", err)
+	}
+
 	_, err = _do.Attrs(primaryKey).Assign(primaryKey).FirstOrInit()
 	if err != nil {
 		t.Error("FirstOrInit() on table <users> fail:", err)
@@ -464,6 +481,21 @@ func Test_user_AddUser3(t *testing.T) {
 	}
 }
 
+var UserAnalyzeUsersTestCase = []TestCase{}
+
+func Test_user_AnalyzeUsers(t *testing.T) {
+	user := newUser(_gen_test_db)
+	do := user.WithContext(context.Background()).Debug()
+
+	for i, tt := range UserAnalyzeUsersTestCase {
+		t.Run("AnalyzeUsers_"+strconv.Itoa(i), func(t *testing.T) {
+			res1, res2 := do.AnalyzeUsers()
+			assert(t, "AnalyzeUsers", res1, tt.Expectation.Ret[0])
+			assert(t, "AnalyzeUsers", res2, tt.Expectation.Ret[1])
+		})
+	}
+}
+
 var UserAddUser4TestCase = []TestCase{}
 
 func Test_user_AddUser4(t *testing.T) {
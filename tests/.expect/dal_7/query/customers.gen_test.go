@@ -50,6 +50,11 @@ func Test_customerQuery(t *testing.T) {
 		t.Error("create item in table <customers> fail:", err)
 	}
 
+	err = _do.OnConflict().DoNothing().Create(&model.Customer{})
+	if err != nil {
+		t.Error("OnConflict() create item in table <customers> fail:", err)
+	}
+
 	err = _do.CreateInBatches([]*model.Customer{{}, {}}, 10)
 	if err != nil {
 		t.Error("create item in table <customers> fail:", err)
@@ -80,6 +85,13 @@ func Test_customerQuery(t *testing.T) {
 		t.Error("FindInBatches() on table <customers> fail:", err)
 	}
 
+	it := _do.Where(primaryKey.IsNotNull()).Iter()
+	for it.Next() {
+	}
+	if err = it.Err(); err != nil {
+		t.Error("Iter() on table <customers> fail:", err)
+	}
+
 	_, err = _do.Select(customer.ALL).Where(primaryKey.IsNotNull()).Order(primaryKey.Desc()).Find()
 	if err != nil {
 		t.Error("Find() on table <customers> fail:", err)
@@ -115,6 +127,11 @@ func Test_customerQuery(t *testing.T) {
 		t.Error("ScanByPage() on table <customers> fail:", err)
 	}
 
+	_, _, _, err = _do.FindByCursor("", 1, primaryKey)
+	if err != nil {
+		t.Error("FindByCursor() on table <customers> fail:", err)
+	}
+
 	_, err = _do.Attrs(primaryKey).Assign(primaryKey).FirstOrInit()
 	if err != nil {
 		t.Error("FirstOrInit() on table <customers> fail:", err)
@@ -142,4 +159,34 @@ func Test_customerQuery(t *testing.T) {
 	if err != nil {
 		t.Error("Not/Or/Clauses on table <customers> fail:", err)
 	}
+
+	_, err = _do.Where(primaryKey.IsNotNull()).Delete()
+	if err != nil {
+		t.Error("soft Delete() on table <customers> fail:", err)
+	}
+
+	_, err = _do.OnlyDeleted().Take()
+	if err != nil {
+		t.Error("OnlyDeleted() on table <customers> fail:", err)
+	}
+
+	_, err = _do.OnlyDeleted().Where(primaryKey.IsNotNull()).Restore()
+	if err != nil {
+		t.Error("Restore() on table <customers> fail:", err)
+	}
+
+	_, err = _do.Take()
+	if err != nil {
+		t.Error("Take() restored item on table <customers> fail:", err)
+	}
+
+	_, err = _do.Where(primaryKey.IsNotNull()).ForceDelete()
+	if err != nil {
+		t.Error("ForceDelete() on table <customers> fail:", err)
+	}
+
+	_, err = _do.WithDeleted().Take()
+	if err == nil {
+		t.Error("WithDeleted() on table <customers> should find no force deleted item")
+	}
 }
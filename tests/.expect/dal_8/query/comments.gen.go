@@ -25,6 +25,7 @@ func newComment(db *gorm.DB, opts ...gen.DOOption) comment {
 
 	_comment.commentDo.UseDB(db, opts...)
 	_comment.commentDo.UseModel(&tests_test.Comment{})
+	_comment.commentDo.UseQueryName("comment")
 
 	tableName := _comment.commentDo.TableName()
 	_comment.ALL = field.NewAsterisk(tableName)
@@ -438,6 +439,8 @@ type ICommentDo interface {
 	Join(table schema.Tabler, on ...field.Expr) ICommentDo
 	LeftJoin(table schema.Tabler, on ...field.Expr) ICommentDo
 	RightJoin(table schema.Tabler, on ...field.Expr) ICommentDo
+	With(name string, q gen.SubQuery) ICommentDo
+	WithRecursive(name string, q gen.SubQuery) ICommentDo
 	Group(cols ...field.Expr) ICommentDo
 	Having(conds ...gen.Condition) ICommentDo
 	Limit(limit int) ICommentDo
@@ -445,6 +448,8 @@ type ICommentDo interface {
 	Count() (count int64, err error)
 	Scopes(funcs ...func(gen.Dao) gen.Dao) ICommentDo
 	Unscoped() ICommentDo
+	CrossTenant() ICommentDo
+	OnConflict(cols ...field.Expr) gen.Upsert[ICommentDo]
 	Create(values ...*tests_test.Comment) error
 	CreateInBatches(values []*tests_test.Comment, batchSize int) error
 	Save(values ...*tests_test.Comment) error
@@ -454,6 +459,7 @@ type ICommentDo interface {
 	Find() ([]*tests_test.Comment, error)
 	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*tests_test.Comment, err error)
 	FindInBatches(result *[]*tests_test.Comment, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Iter() *gen.Iterator[*tests_test.Comment]
 	Pluck(column field.Expr, dest interface{}) error
 	Delete(...*tests_test.Comment) (info gen.ResultInfo, err error)
 	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
@@ -471,6 +477,7 @@ type ICommentDo interface {
 	FirstOrCreate() (*tests_test.Comment, error)
 	FindByPage(offset int, limit int) (result []*tests_test.Comment, count int64, err error)
 	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	FindByCursor(cursor string, limit int, orderCols ...field.OrderExpr) (result []*tests_test.Comment, next string, prev string, err error)
 	Rows() (*sql.Rows, error)
 	Row() *sql.Row
 	Scan(result interface{}) (err error)
@@ -547,6 +554,14 @@ func (c commentDo) RightJoin(table schema.Tabler, on ...field.Expr) ICommentDo {
 	return c.withDO(c.DO.RightJoin(table, on...))
 }
 
+func (c commentDo) With(name string, q gen.SubQuery) ICommentDo {
+	return c.withDO(c.DO.With(name, q))
+}
+
+func (c commentDo) WithRecursive(name string, q gen.SubQuery) ICommentDo {
+	return c.withDO(c.DO.WithRecursive(name, q))
+}
+
 func (c commentDo) Group(cols ...field.Expr) ICommentDo {
 	return c.withDO(c.DO.Group(cols...))
 }
@@ -571,6 +586,13 @@ func (c commentDo) Unscoped() ICommentDo {
 	return c.withDO(c.DO.Unscoped())
 }
 
+func (c commentDo) CrossTenant() ICommentDo {
+	return c.withDO(c.DO.CrossTenant())
+}
+
+func (c commentDo) OnConflict(cols ...field.Expr) gen.Upsert[ICommentDo] {
+	return gen.NewUpsert(&c.DO, func(do gen.Dao) ICommentDo { return c.withDO(do) }, cols...)
+}
 func (c commentDo) Create(values ...*tests_test.Comment) error {
 	if len(values) == 0 {
 		return nil
@@ -633,6 +655,10 @@ func (c commentDo) FindInBatches(result *[]*tests_test.Comment, batchSize int, f
 	return c.DO.FindInBatches(result, batchSize, fc)
 }
 
+func (c commentDo) Iter() *gen.Iterator[*tests_test.Comment] {
+	return gen.NewIterator[*tests_test.Comment](&c.DO)
+}
+
 func (c commentDo) Attrs(attrs ...field.AssignExpr) ICommentDo {
 	return c.withDO(c.DO.Attrs(attrs...))
 }
@@ -686,6 +712,14 @@ func (c commentDo) FindByPage(offset int, limit int) (result []*tests_test.Comme
 	return
 }
 
+func (c commentDo) FindByCursor(cursor string, limit int, orderCols ...field.OrderExpr) (result []*tests_test.Comment, next string, prev string, err error) {
+	results, next, prev, err := c.DO.FindByCursor(cursor, limit, orderCols...)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return results.([]*tests_test.Comment), next, prev, nil
+}
+
 func (c commentDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
 	count, err = c.Count()
 	if err != nil {
@@ -25,6 +25,7 @@ func newPost(db *gorm.DB, opts ...gen.DOOption) post {
 
 	_post.postDo.UseDB(db, opts...)
 	_post.postDo.UseModel(&tests_test.Post{})
+	_post.postDo.UseQueryName("post")
 
 	tableName := _post.postDo.TableName()
 	_post.ALL = field.NewAsterisk(tableName)
@@ -412,6 +413,8 @@ type IPostDo interface {
 	Join(table schema.Tabler, on ...field.Expr) IPostDo
 	LeftJoin(table schema.Tabler, on ...field.Expr) IPostDo
 	RightJoin(table schema.Tabler, on ...field.Expr) IPostDo
+	With(name string, q gen.SubQuery) IPostDo
+	WithRecursive(name string, q gen.SubQuery) IPostDo
 	Group(cols ...field.Expr) IPostDo
 	Having(conds ...gen.Condition) IPostDo
 	Limit(limit int) IPostDo
@@ -419,6 +422,8 @@ type IPostDo interface {
 	Count() (count int64, err error)
 	Scopes(funcs ...func(gen.Dao) gen.Dao) IPostDo
 	Unscoped() IPostDo
+	CrossTenant() IPostDo
+	OnConflict(cols ...field.Expr) gen.Upsert[IPostDo]
 	Create(values ...*tests_test.Post) error
 	CreateInBatches(values []*tests_test.Post, batchSize int) error
 	Save(values ...*tests_test.Post) error
@@ -428,6 +433,7 @@ type IPostDo interface {
 	Find() ([]*tests_test.Post, error)
 	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*tests_test.Post, err error)
 	FindInBatches(result *[]*tests_test.Post, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Iter() *gen.Iterator[*tests_test.Post]
 	Pluck(column field.Expr, dest interface{}) error
 	Delete(...*tests_test.Post) (info gen.ResultInfo, err error)
 	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
@@ -445,6 +451,7 @@ type IPostDo interface {
 	FirstOrCreate() (*tests_test.Post, error)
 	FindByPage(offset int, limit int) (result []*tests_test.Post, count int64, err error)
 	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	FindByCursor(cursor string, limit int, orderCols ...field.OrderExpr) (result []*tests_test.Post, next string, prev string, err error)
 	Rows() (*sql.Rows, error)
 	Row() *sql.Row
 	Scan(result interface{}) (err error)
@@ -521,6 +528,14 @@ func (p postDo) RightJoin(table schema.Tabler, on ...field.Expr) IPostDo {
 	return p.withDO(p.DO.RightJoin(table, on...))
 }
 
+func (p postDo) With(name string, q gen.SubQuery) IPostDo {
+	return p.withDO(p.DO.With(name, q))
+}
+
+func (p postDo) WithRecursive(name string, q gen.SubQuery) IPostDo {
+	return p.withDO(p.DO.WithRecursive(name, q))
+}
+
 func (p postDo) Group(cols ...field.Expr) IPostDo {
 	return p.withDO(p.DO.Group(cols...))
 }
@@ -545,6 +560,13 @@ func (p postDo) Unscoped() IPostDo {
 	return p.withDO(p.DO.Unscoped())
 }
 
+func (p postDo) CrossTenant() IPostDo {
+	return p.withDO(p.DO.CrossTenant())
+}
+
+func (p postDo) OnConflict(cols ...field.Expr) gen.Upsert[IPostDo] {
+	return gen.NewUpsert(&p.DO, func(do gen.Dao) IPostDo { return p.withDO(do) }, cols...)
+}
 func (p postDo) Create(values ...*tests_test.Post) error {
 	if len(values) == 0 {
 		return nil
@@ -607,6 +629,10 @@ func (p postDo) FindInBatches(result *[]*tests_test.Post, batchSize int, fc func
 	return p.DO.FindInBatches(result, batchSize, fc)
 }
 
+func (p postDo) Iter() *gen.Iterator[*tests_test.Post] {
+	return gen.NewIterator[*tests_test.Post](&p.DO)
+}
+
 func (p postDo) Attrs(attrs ...field.AssignExpr) IPostDo {
 	return p.withDO(p.DO.Attrs(attrs...))
 }
@@ -660,6 +686,14 @@ func (p postDo) FindByPage(offset int, limit int) (result []*tests_test.Post, co
 	return
 }
 
+func (p postDo) FindByCursor(cursor string, limit int, orderCols ...field.OrderExpr) (result []*tests_test.Post, next string, prev string, err error) {
+	results, next, prev, err := p.DO.FindByCursor(cursor, limit, orderCols...)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return results.([]*tests_test.Post), next, prev, nil
+}
+
 func (p postDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
 	count, err = p.Count()
 	if err != nil {
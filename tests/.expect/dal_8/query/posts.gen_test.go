@@ -50,6 +50,11 @@ func Test_postQuery(t *testing.T) {
 		t.Error("create item in table <posts> fail:", err)
 	}
 
+	err = _do.OnConflict().DoNothing().Create(&tests_test.Post{})
+	if err != nil {
+		t.Error("OnConflict() create item in table <posts> fail:", err)
+	}
+
 	err = _do.CreateInBatches([]*tests_test.Post{{}, {}}, 10)
 	if err != nil {
 		t.Error("create item in table <posts> fail:", err)
@@ -80,6 +85,13 @@ func Test_postQuery(t *testing.T) {
 		t.Error("FindInBatches() on table <posts> fail:", err)
 	}
 
+	it := _do.Where(primaryKey.IsNotNull()).Iter()
+	for it.Next() {
+	}
+	if err = it.Err(); err != nil {
+		t.Error("Iter() on table <posts> fail:", err)
+	}
+
 	_, err = _do.Select(post.ALL).Where(primaryKey.IsNotNull()).Order(primaryKey.Desc()).Find()
 	if err != nil {
 		t.Error("Find() on table <posts> fail:", err)
@@ -115,6 +127,11 @@ func Test_postQuery(t *testing.T) {
 		t.Error("ScanByPage() on table <posts> fail:", err)
 	}
 
+	_, _, _, err = _do.FindByCursor("", 1, primaryKey)
+	if err != nil {
+		t.Error("FindByCursor() on table <posts> fail:", err)
+	}
+
 	_, err = _do.Attrs(primaryKey).Assign(primaryKey).FirstOrInit()
 	if err != nil {
 		t.Error("FirstOrInit() on table <posts> fail:", err)
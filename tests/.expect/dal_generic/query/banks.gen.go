@@ -22,6 +22,7 @@ func newBank(db *gorm.DB, opts ...gen.DOOption) bank {
 
 	_bank.bankDo.UseDB(db, opts...)
 	_bank.bankDo.UseModel(&model.Bank{})
+	_bank.bankDo.UseQueryName("bank")
 
 	tableName := _bank.bankDo.TableName()
 	_bank.ALL = field.NewAsterisk(tableName)
@@ -15,6 +15,8 @@ import (
 	"gorm.io/gen/field"
 	"gorm.io/gen/helper"
 
+	"gorm.io/plugin/dbresolver"
+
 	"gorm.io/gen/tests/.gen/dal_generic/model"
 
 	"time"
@@ -27,6 +29,7 @@ func newUser(db *gorm.DB, opts ...gen.DOOption) user {
 
 	_user.userDo.UseDB(db, opts...)
 	_user.userDo.UseModel(&model.User{})
+	_user.userDo.UseQueryName("user")
 
 	tableName := _user.userDo.TableName()
 	_user.ALL = field.NewAsterisk(tableName)
@@ -166,6 +169,7 @@ type IUserDo interface {
 	AddUser1(name string, age int) (rowsAffected int64, err error)
 	AddUser2(name string, age int) (rowsAffected int64)
 	AddUser3(name string, age int) (result sql.Result)
+	AnalyzeUsers() (result sql.Result, err error)
 	AddUser4(name string, age int) (row *sql.Row)
 	AddUser5(name string, age int) (rows *sql.Rows)
 	AddUser6(name string, age int) (rows *sql.Rows, err error)
@@ -195,8 +199,11 @@ func (u userDo) FindByUsers(user model.User) (result []model.User) {
 	}
 	helper.JoinWhereBuilder(&generateSQL, whereSQL0)
 
+	_do, _done := u.Observe("TestIF", "FindByUsers")
+	defer _done(nil)
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Find(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Find(&result) // ignore_security_alert
 	_ = executeSQL
 
 	return
@@ -222,8 +229,11 @@ func (u userDo) FindByComplexIf(user *model.User) (result []model.User) {
 	}
 	helper.JoinWhereBuilder(&generateSQL, whereSQL0)
 
+	_do, _done := u.Observe("TestIF", "FindByComplexIf")
+	defer _done(nil)
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Find(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Find(&result) // ignore_security_alert
 	_ = executeSQL
 
 	return
@@ -242,8 +252,11 @@ func (u userDo) FindByIfTime(start time.Time) (result []model.User) {
 		generateSQL.WriteString("created_at > start ")
 	}
 
+	_do, _done := u.Observe("TestIF", "FindByIfTime")
+	defer _done(nil)
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String()).Find(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String()).Find(&result) // ignore_security_alert
 	_ = executeSQL
 
 	return
@@ -267,8 +280,11 @@ func (u userDo) TestFor(names []string) (result model.User, err error) {
 	}
 	generateSQL.WriteString("1=1 ")
 
+	_do, _done := u.Observe("TestFor", "TestFor")
+	defer func() { err = _done(err) }()
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
 	err = executeSQL.Error
 
 	return
@@ -292,8 +308,11 @@ func (u userDo) TestForKey(names []string, name string, value string) (result mo
 	}
 	generateSQL.WriteString("and 1=1 ")
 
+	_do, _done := u.Observe("TestFor", "TestForKey")
+	defer func() { err = _done(err) }()
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
 	err = executeSQL.Error
 
 	return
@@ -323,8 +342,11 @@ func (u userDo) TestForOr(names []string) (result model.User, err error) {
 	helper.JoinWhereBuilder(&generateSQL, whereSQL0)
 	generateSQL.WriteString(") ")
 
+	_do, _done := u.Observe("TestFor", "TestForOr")
+	defer func() { err = _done(err) }()
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
 	err = executeSQL.Error
 
 	return
@@ -352,8 +374,11 @@ func (u userDo) TestIfInFor(names []string, name string) (result model.User, err
 	}
 	generateSQL.WriteString("1=2 ")
 
+	_do, _done := u.Observe("TestFor", "TestIfInFor")
+	defer func() { err = _done(err) }()
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
 	err = executeSQL.Error
 
 	return
@@ -381,8 +406,11 @@ func (u userDo) TestForInIf(names []string, name string) (result model.User, err
 	}
 	generateSQL.WriteString("1=2 ")
 
+	_do, _done := u.Observe("TestFor", "TestForInIf")
+	defer func() { err = _done(err) }()
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
 	err = executeSQL.Error
 
 	return
@@ -408,8 +436,11 @@ func (u userDo) TestForInWhere(names []string, name string, forName string) (res
 	}
 	helper.JoinWhereBuilder(&generateSQL, whereSQL0)
 
+	_do, _done := u.Observe("TestFor", "TestForInWhere")
+	defer func() { err = _done(err) }()
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
 	err = executeSQL.Error
 
 	return
@@ -435,8 +466,11 @@ func (u userDo) TestForUserList(users []*model.User, name string) (result model.
 	}
 	helper.JoinWhereBuilder(&generateSQL, whereSQL0)
 
+	_do, _done := u.Observe("TestFor", "TestForUserList")
+	defer func() { err = _done(err) }()
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
 	err = executeSQL.Error
 
 	return
@@ -462,8 +496,11 @@ func (u userDo) TestForMap(param map[string]string, name string) (result model.U
 	}
 	helper.JoinWhereBuilder(&generateSQL, whereSQL0)
 
+	_do, _done := u.Observe("TestFor", "TestForMap")
+	defer func() { err = _done(err) }()
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
 	err = executeSQL.Error
 
 	return
@@ -493,8 +530,11 @@ func (u userDo) TestIfInIf(name string) (result model.User) {
 	}
 	helper.JoinWhereBuilder(&generateSQL, whereSQL0)
 
+	_do, _done := u.Observe("TestFor", "TestIfInIf")
+	defer _done(nil)
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
 	_ = executeSQL
 
 	return
@@ -527,8 +567,11 @@ func (u userDo) TestMoreFor(names []string, ids []int) (result []model.User) {
 	}
 	helper.JoinWhereBuilder(&generateSQL, whereSQL0)
 
+	_do, _done := u.Observe("TestFor", "TestMoreFor")
+	defer _done(nil)
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Find(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Find(&result) // ignore_security_alert
 	_ = executeSQL
 
 	return
@@ -564,8 +607,11 @@ func (u userDo) TestMoreFor2(names []string, ids []int) (result []model.User) {
 	}
 	helper.JoinWhereBuilder(&generateSQL, whereSQL0)
 
+	_do, _done := u.Observe("TestFor", "TestMoreFor2")
+	defer _done(nil)
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Find(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Find(&result) // ignore_security_alert
 	_ = executeSQL
 
 	return
@@ -592,8 +638,11 @@ func (u userDo) TestForInSet(users []model.User) (err error) {
 	helper.JoinSetBuilder(&generateSQL, setSQL0)
 	generateSQL.WriteString("where ")
 
+	_do, _done := u.Observe("TestFor", "TestForInSet")
+	defer func() { err = _done(err) }()
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Exec(generateSQL.String(), params...) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Write).Exec(generateSQL.String(), params...) // ignore_security_alert
 	err = executeSQL.Error
 
 	return
@@ -622,8 +671,11 @@ func (u userDo) TestInsertMoreInfo(users []model.User) (err error) {
 		generateSQL.WriteString("(?,?) ")
 	}
 
+	_do, _done := u.Observe("TestFor", "TestInsertMoreInfo")
+	defer func() { err = _done(err) }()
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Exec(generateSQL.String(), params...) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Write).Exec(generateSQL.String(), params...) // ignore_security_alert
 	err = executeSQL.Error
 
 	return
@@ -688,8 +740,11 @@ func (u userDo) TestIfElseFor(name string, users []model.User) (err error) {
 	}
 	helper.JoinWhereBuilder(&generateSQL, whereSQL0)
 
+	_do, _done := u.Observe("TestFor", "TestIfElseFor")
+	defer func() { err = _done(err) }()
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Exec(generateSQL.String(), params...) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Write).Exec(generateSQL.String(), params...) // ignore_security_alert
 	err = executeSQL.Error
 
 	return
@@ -715,8 +770,11 @@ func (u userDo) TestForLike(names []string) (result []model.User) {
 	}
 	helper.JoinWhereBuilder(&generateSQL, whereSQL0)
 
+	_do, _done := u.Observe("TestFor", "TestForLike")
+	defer _done(nil)
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Find(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Find(&result) // ignore_security_alert
 	_ = executeSQL
 
 	return
@@ -733,8 +791,10 @@ func (u userDo) AddUser(name string, age int) (result sql.Result, err error) {
 	params = append(params, age)
 	generateSQL.WriteString("INSERT INTO users (name,age) VALUES (?,?) ON DUPLICATE KEY UPDATE age=VALUES(age) ")
 
-	stmt := u.UnderlyingDB().Statement
-	result, err = stmt.ConnPool.ExecContext(stmt.Context, generateSQL.String(), params...) // ignore_security_alert
+	_do, _done := u.Observe("InsertMethod", "AddUser")
+	defer func() { err = _done(err) }()
+
+	result, err = _do.Clauses(dbresolver.Write).(*gen.DO).ExecResult(generateSQL.String(), params...) // ignore_security_alert
 
 	return
 }
@@ -750,8 +810,11 @@ func (u userDo) AddUser1(name string, age int) (rowsAffected int64, err error) {
 	params = append(params, age)
 	generateSQL.WriteString("INSERT INTO users (name,age) VALUES (?,?) ON DUPLICATE KEY UPDATE age=VALUES(age) ")
 
+	_do, _done := u.Observe("InsertMethod", "AddUser1")
+	defer func() { err = _done(err) }()
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Exec(generateSQL.String(), params...) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Write).Exec(generateSQL.String(), params...) // ignore_security_alert
 	rowsAffected = executeSQL.RowsAffected
 	err = executeSQL.Error
 
@@ -769,8 +832,11 @@ func (u userDo) AddUser2(name string, age int) (rowsAffected int64) {
 	params = append(params, age)
 	generateSQL.WriteString("INSERT INTO users (name,age) VALUES (?,?) ON DUPLICATE KEY UPDATE age=VALUES(age) ")
 
+	_do, _done := u.Observe("InsertMethod", "AddUser2")
+	defer _done(nil)
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Exec(generateSQL.String(), params...) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Write).Exec(generateSQL.String(), params...) // ignore_security_alert
 	rowsAffected = executeSQL.RowsAffected
 
 	return
@@ -787,8 +853,26 @@ func (u userDo) AddUser3(name string, age int) (result sql.Result) {
 	params = append(params, age)
 	generateSQL.WriteString("INSERT INTO users (name,age) VALUES (?,?) ON DUPLICATE KEY UPDATE age=VALUES(age) ")
 
-	stmt := u.UnderlyingDB().Statement
-	result, _ = stmt.ConnPool.ExecContext(stmt.Context, generateSQL.String(), params...) // ignore_security_alert
+	_do, _done := u.Observe("InsertMethod", "AddUser3")
+	defer _done(nil)
+
+	result, _ = _do.Clauses(dbresolver.Write).(*gen.DO).ExecResult(generateSQL.String(), params...) // ignore_security_alert
+
+	return
+}
+
+// AnalyzeUsers analyze users table on replica
+// @read
+//
+// ANALYZE TABLE users
+func (u userDo) AnalyzeUsers() (result sql.Result, err error) {
+	var generateSQL strings.Builder
+	generateSQL.WriteString("ANALYZE TABLE users ")
+
+	_do, _done := u.Observe("InsertMethod", "AnalyzeUsers")
+	defer func() { err = _done(err) }()
+
+	result, err = _do.Clauses(dbresolver.Read).(*gen.DO).ExecResult(generateSQL.String()) // ignore_security_alert
 
 	return
 }
@@ -804,7 +888,10 @@ func (u userDo) AddUser4(name string, age int) (row *sql.Row) {
 	params = append(params, age)
 	generateSQL.WriteString("INSERT INTO users (name,age) VALUES (?,?) ON DUPLICATE KEY UPDATE age=VALUES(age) ")
 
-	row = u.UnderlyingDB().Raw(generateSQL.String(), params...).Row() // ignore_security_alert
+	_do, _done := u.Observe("InsertMethod", "AddUser4")
+	defer _done(nil)
+
+	row = _do.UnderlyingDB().Clauses(dbresolver.Write).Raw(generateSQL.String(), params...).Row() // ignore_security_alert
 
 	return
 }
@@ -820,7 +907,10 @@ func (u userDo) AddUser5(name string, age int) (rows *sql.Rows) {
 	params = append(params, age)
 	generateSQL.WriteString("INSERT INTO users (name,age) VALUES (?,?) ON DUPLICATE KEY UPDATE age=VALUES(age) ")
 
-	rows, _ = u.UnderlyingDB().Raw(generateSQL.String(), params...).Rows() // ignore_security_alert
+	_do, _done := u.Observe("InsertMethod", "AddUser5")
+	defer _done(nil)
+
+	rows, _ = _do.UnderlyingDB().Clauses(dbresolver.Write).Raw(generateSQL.String(), params...).Rows() // ignore_security_alert
 
 	return
 }
@@ -836,7 +926,10 @@ func (u userDo) AddUser6(name string, age int) (rows *sql.Rows, err error) {
 	params = append(params, age)
 	generateSQL.WriteString("INSERT INTO users (name,age) VALUES (?,?) ON DUPLICATE KEY UPDATE age=VALUES(age) ")
 
-	rows, err = u.UnderlyingDB().Raw(generateSQL.String(), params...).Rows() // ignore_security_alert
+	_do, _done := u.Observe("InsertMethod", "AddUser6")
+	defer func() { err = _done(err) }()
+
+	rows, err = _do.UnderlyingDB().Clauses(dbresolver.Write).Raw(generateSQL.String(), params...).Rows() // ignore_security_alert
 
 	return
 }
@@ -851,8 +944,11 @@ func (u userDo) FindByID(id int) (result model.User) {
 	params = append(params, id)
 	generateSQL.WriteString("select * from users where id=? ")
 
+	_do, _done := u.Observe("SelectMethod", "FindByID")
+	defer _done(nil)
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
 	_ = executeSQL
 
 	return
@@ -868,8 +964,11 @@ func (u userDo) LikeSearch(name string) (result *model.User) {
 	params = append(params, name)
 	generateSQL.WriteString("SELECT * FROM users where name LIKE concat('%',?,'%') ")
 
+	_do, _done := u.Observe("SelectMethod", "LikeSearch")
+	defer _done(nil)
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Take(&result) // ignore_security_alert
 	_ = executeSQL
 
 	return
@@ -885,8 +984,11 @@ func (u userDo) InSearch(names []string) (result []*model.User) {
 	params = append(params, names)
 	generateSQL.WriteString("select * from users where name in ? ")
 
+	_do, _done := u.Observe("SelectMethod", "InSearch")
+	defer _done(nil)
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Find(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Find(&result) // ignore_security_alert
 	_ = executeSQL
 
 	return
@@ -902,8 +1004,11 @@ func (u userDo) ColumnSearch(name string, names []string) (result []*model.User)
 	params = append(params, names)
 	generateSQL.WriteString("select * from users where " + u.Quote(name) + " in ? ")
 
+	_do, _done := u.Observe("SelectMethod", "ColumnSearch")
+	defer _done(nil)
+
 	var executeSQL *gorm.DB
-	executeSQL = u.UnderlyingDB().Raw(generateSQL.String(), params...).Find(&result) // ignore_security_alert
+	executeSQL = _do.UnderlyingDB().Clauses(dbresolver.Read).Raw(generateSQL.String(), params...).Find(&result) // ignore_security_alert
 	_ = executeSQL
 
 	return
@@ -24,6 +24,7 @@ func newPerson(db *gorm.DB, opts ...gen.DOOption) person {
 
 	_person.personDo.UseDB(db, opts...)
 	_person.personDo.UseModel(&model.Person{})
+	_person.personDo.UseQueryName("person")
 
 	tableName := _person.personDo.TableName()
 	_person.ALL = field.NewAsterisk(tableName)
@@ -42,7 +43,7 @@ func newPerson(db *gorm.DB, opts ...gen.DOOption) person {
 	_person.Number = field.NewInt32(tableName, "number")
 	_person.Birth = field.NewTime(tableName, "birth")
 	_person.XMLHTTPRequest = field.NewString(tableName, "xmlHTTPRequest")
-	_person.JStr = field.NewString(tableName, "jStr")
+	_person.JStr = field.NewJSON(tableName, "jStr")
 	_person.Geo = field.NewString(tableName, "geo")
 	_person.Mint = field.NewInt32(tableName, "mint")
 	_person.Blank = field.NewString(tableName, "blank")
@@ -73,7 +74,7 @@ type person struct {
 	Number         field.Int32
 	Birth          field.Time
 	XMLHTTPRequest field.String
-	JStr           field.String
+	JStr           field.JSON
 	Geo            field.String
 	Mint           field.Int32
 	Blank          field.String
@@ -110,7 +111,7 @@ func (p *person) updateTableName(table string) *person {
 	p.Number = field.NewInt32(table, "number")
 	p.Birth = field.NewTime(table, "birth")
 	p.XMLHTTPRequest = field.NewString(table, "xmlHTTPRequest")
-	p.JStr = field.NewString(table, "jStr")
+	p.JStr = field.NewJSON(table, "jStr")
 	p.Geo = field.NewString(table, "geo")
 	p.Mint = field.NewInt32(table, "mint")
 	p.Blank = field.NewString(table, "blank")
@@ -244,6 +245,14 @@ func (p personDo) RightJoin(table schema.Tabler, on ...field.Expr) *personDo {
 	return p.withDO(p.DO.RightJoin(table, on...))
 }
 
+func (p personDo) With(name string, q gen.SubQuery) *personDo {
+	return p.withDO(p.DO.With(name, q))
+}
+
+func (p personDo) WithRecursive(name string, q gen.SubQuery) *personDo {
+	return p.withDO(p.DO.WithRecursive(name, q))
+}
+
 func (p personDo) Group(cols ...field.Expr) *personDo {
 	return p.withDO(p.DO.Group(cols...))
 }
@@ -268,6 +277,29 @@ func (p personDo) Unscoped() *personDo {
 	return p.withDO(p.DO.Unscoped())
 }
 
+func (p personDo) CrossTenant() *personDo {
+	return p.withDO(p.DO.CrossTenant())
+}
+
+func (p personDo) OnConflict(cols ...field.Expr) gen.Upsert[*personDo] {
+	return gen.NewUpsert(&p.DO, func(do gen.Dao) *personDo { return p.withDO(do) }, cols...)
+}
+func (p personDo) WithDeleted() *personDo {
+	return p.withDO(p.DO.WithDeleted())
+}
+
+func (p personDo) OnlyDeleted() *personDo {
+	return p.withDO(p.DO.OnlyDeleted())
+}
+
+func (p personDo) Restore() (info gen.ResultInfo, err error) {
+	return p.DO.Restore()
+}
+
+func (p personDo) ForceDelete(models ...*model.Person) (info gen.ResultInfo, err error) {
+	return p.DO.ForceDelete(models)
+}
+
 func (p personDo) Create(values ...*model.Person) error {
 	if len(values) == 0 {
 		return nil
@@ -330,6 +362,10 @@ func (p personDo) FindInBatches(result *[]*model.Person, batchSize int, fc func(
 	return p.DO.FindInBatches(result, batchSize, fc)
 }
 
+func (p personDo) Iter() *gen.Iterator[*model.Person] {
+	return gen.NewIterator[*model.Person](&p.DO)
+}
+
 func (p personDo) Attrs(attrs ...field.AssignExpr) *personDo {
 	return p.withDO(p.DO.Attrs(attrs...))
 }
@@ -383,6 +419,14 @@ func (p personDo) FindByPage(offset int, limit int) (result []*model.Person, cou
 	return
 }
 
+func (p personDo) FindByCursor(cursor string, limit int, orderCols ...field.OrderExpr) (result []*model.Person, next string, prev string, err error) {
+	results, next, prev, err := p.DO.FindByCursor(cursor, limit, orderCols...)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return results.([]*model.Person), next, prev, nil
+}
+
 func (p personDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
 	count, err = p.Count()
 	if err != nil {
@@ -24,6 +24,7 @@ func newUser(db *gorm.DB, opts ...gen.DOOption) user {
 
 	_user.userDo.UseDB(db, opts...)
 	_user.userDo.UseModel(&model.User{})
+	_user.userDo.UseQueryName("user")
 
 	tableName := _user.userDo.TableName()
 	_user.ALL = field.NewAsterisk(tableName)
@@ -197,6 +198,14 @@ func (u userDo) RightJoin(table schema.Tabler, on ...field.Expr) *userDo {
 	return u.withDO(u.DO.RightJoin(table, on...))
 }
 
+func (u userDo) With(name string, q gen.SubQuery) *userDo {
+	return u.withDO(u.DO.With(name, q))
+}
+
+func (u userDo) WithRecursive(name string, q gen.SubQuery) *userDo {
+	return u.withDO(u.DO.WithRecursive(name, q))
+}
+
 func (u userDo) Group(cols ...field.Expr) *userDo {
 	return u.withDO(u.DO.Group(cols...))
 }
@@ -221,6 +230,13 @@ func (u userDo) Unscoped() *userDo {
 	return u.withDO(u.DO.Unscoped())
 }
 
+func (u userDo) CrossTenant() *userDo {
+	return u.withDO(u.DO.CrossTenant())
+}
+
+func (u userDo) OnConflict(cols ...field.Expr) gen.Upsert[*userDo] {
+	return gen.NewUpsert(&u.DO, func(do gen.Dao) *userDo { return u.withDO(do) }, cols...)
+}
 func (u userDo) Create(values ...*model.User) error {
 	if len(values) == 0 {
 		return nil
@@ -283,6 +299,10 @@ func (u userDo) FindInBatches(result *[]*model.User, batchSize int, fc func(tx g
 	return u.DO.FindInBatches(result, batchSize, fc)
 }
 
+func (u userDo) Iter() *gen.Iterator[*model.User] {
+	return gen.NewIterator[*model.User](&u.DO)
+}
+
 func (u userDo) Attrs(attrs ...field.AssignExpr) *userDo {
 	return u.withDO(u.DO.Attrs(attrs...))
 }
@@ -336,6 +356,14 @@ func (u userDo) FindByPage(offset int, limit int) (result []*model.User, count i
 	return
 }
 
+func (u userDo) FindByCursor(cursor string, limit int, orderCols ...field.OrderExpr) (result []*model.User, next string, prev string, err error) {
+	results, next, prev, err := u.DO.FindByCursor(cursor, limit, orderCols...)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return results.([]*model.User), next, prev, nil
+}
+
 func (u userDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
 	count, err = u.Count()
 	if err != nil {
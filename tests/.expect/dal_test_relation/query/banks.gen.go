@@ -24,6 +24,7 @@ func newBank(db *gorm.DB, opts ...gen.DOOption) bank {
 
 	_bank.bankDo.UseDB(db, opts...)
 	_bank.bankDo.UseModel(&model.Bank{})
+	_bank.bankDo.UseQueryName("bank")
 
 	tableName := _bank.bankDo.TableName()
 	_bank.ALL = field.NewAsterisk(tableName)
@@ -176,6 +177,14 @@ func (b bankDo) RightJoin(table schema.Tabler, on ...field.Expr) *bankDo {
 	return b.withDO(b.DO.RightJoin(table, on...))
 }
 
+func (b bankDo) With(name string, q gen.SubQuery) *bankDo {
+	return b.withDO(b.DO.With(name, q))
+}
+
+func (b bankDo) WithRecursive(name string, q gen.SubQuery) *bankDo {
+	return b.withDO(b.DO.WithRecursive(name, q))
+}
+
 func (b bankDo) Group(cols ...field.Expr) *bankDo {
 	return b.withDO(b.DO.Group(cols...))
 }
@@ -200,6 +209,13 @@ func (b bankDo) Unscoped() *bankDo {
 	return b.withDO(b.DO.Unscoped())
 }
 
+func (b bankDo) CrossTenant() *bankDo {
+	return b.withDO(b.DO.CrossTenant())
+}
+
+func (b bankDo) OnConflict(cols ...field.Expr) gen.Upsert[*bankDo] {
+	return gen.NewUpsert(&b.DO, func(do gen.Dao) *bankDo { return b.withDO(do) }, cols...)
+}
 func (b bankDo) Create(values ...*model.Bank) error {
 	if len(values) == 0 {
 		return nil
@@ -262,6 +278,10 @@ func (b bankDo) FindInBatches(result *[]*model.Bank, batchSize int, fc func(tx g
 	return b.DO.FindInBatches(result, batchSize, fc)
 }
 
+func (b bankDo) Iter() *gen.Iterator[*model.Bank] {
+	return gen.NewIterator[*model.Bank](&b.DO)
+}
+
 func (b bankDo) Attrs(attrs ...field.AssignExpr) *bankDo {
 	return b.withDO(b.DO.Attrs(attrs...))
 }
@@ -315,6 +335,14 @@ func (b bankDo) FindByPage(offset int, limit int) (result []*model.Bank, count i
 	return
 }
 
+func (b bankDo) FindByCursor(cursor string, limit int, orderCols ...field.OrderExpr) (result []*model.Bank, next string, prev string, err error) {
+	results, next, prev, err := b.DO.FindByCursor(cursor, limit, orderCols...)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return results.([]*model.Bank), next, prev, nil
+}
+
 func (b bankDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
 	count, err = b.Count()
 	if err != nil {
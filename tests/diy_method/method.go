@@ -27,6 +27,12 @@ type InsertMethod interface {
 	// INSERT INTO users (name,age) VALUES (@name,@age) ON DUPLICATE KEY UPDATE age=VALUES(age)
 	AddUser3(name string, age int) gen.SQLResult
 
+	// AnalyzeUsers analyze users table on replica
+	// @read
+	//
+	// ANALYZE TABLE users
+	AnalyzeUsers() (gen.SQLResult, error)
+
 	// AddUser4
 	//
 	// INSERT INTO users (name,age) VALUES (@name,@age) ON DUPLICATE KEY UPDATE age=VALUES(age)
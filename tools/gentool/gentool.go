@@ -14,6 +14,7 @@ import (
 	"gorm.io/driver/sqlite"
 	"gorm.io/driver/sqlserver"
 	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
 
 	"gorm.io/gen"
 )
@@ -35,25 +36,44 @@ const (
 
 // CmdParams is command line parameters
 type CmdParams struct {
-	DSN                 string   `yaml:"dsn"`          // consult[https://gorm.io/docs/connecting_to_the_database.html]"
-	DB                  string   `yaml:"db"`           // input mysql or postgres or sqlite or sqlserver. consult[https://gorm.io/docs/connecting_to_the_database.html]
-	Tables              []string `yaml:"tables"`       // enter the required data table or leave it blank
-	OnlyModel           bool     `yaml:"onlyModel"`    // only generate model
-	OutPath             string   `yaml:"outPath"`      // specify a directory for output
-	OutFile             string   `yaml:"outFile"`      // query code file name, default: gen.go
-	WithUnitTest        bool     `yaml:"withUnitTest"` // generate unit test for query code
-	UnitTestTemplate    string   `yaml:"unitTestTemplate"`
-	ModelPkgName        string   `yaml:"modelPkgName"`        // generated model code's package name
-	FieldNullable       bool     `yaml:"fieldNullable"`       // generate with pointer when field is nullable
-	FieldCoverable      bool     `yaml:"fieldCoverable"`      // generate with pointer when field has default value
-	FieldWithIndexTag   bool     `yaml:"fieldWithIndexTag"`   // generate field with gorm index tag
-	FieldWithTypeTag    bool     `yaml:"fieldWithTypeTag"`    // generate field with gorm column type tag
-	FieldWithDefaultTag bool     `yaml:"fieldWithDefaultTag"` // generate field with gorm default tag
-	FieldSignable       bool     `yaml:"fieldSignable"`       // detect integer field's unsigned type, adjust generated data type
-	WithDefaultQuery    bool     `yaml:"withDefaultQuery"`    // create default query in generated code
-	WithoutContext      bool     `yaml:"withoutContext"`      // generate code without context constrain
-	WithQueryInterface  bool     `yaml:"withQueryInterface"`  // generate code with exported interface object
-	WithGeneric         bool     `yaml:"withGeneric"`         // generate code with generic
+	DSN                 string        `yaml:"dsn"`          // consult[https://gorm.io/docs/connecting_to_the_database.html]"
+	DB                  string        `yaml:"db"`           // input mysql or postgres or sqlite or sqlserver. consult[https://gorm.io/docs/connecting_to_the_database.html]
+	DDL                 []string      `yaml:"ddl"`          // read tables from DDL files or directories of .sql files instead of database, dialect is given by db
+	Tables              []TableConfig `yaml:"tables"`       // enter the required data table, or config blocks of tables matched by name, glob or regex, or leave it blank
+	Include             []string      `yaml:"include"`      // only generate tables in database matched by glob patterns, like user_*
+	IncludeReg          []string      `yaml:"includeReg"`   // only generate tables in database matched by RegExp
+	Exclude             []string      `yaml:"exclude"`      // skip tables in database matched by glob patterns, like flyway_schema_history
+	ExcludeReg          []string      `yaml:"excludeReg"`   // skip tables in database matched by RegExp, like _p\d+$
+	OnlyModel           bool          `yaml:"onlyModel"`    // only generate model
+	OutPath             string        `yaml:"outPath"`      // specify a directory for output
+	OutFile             string        `yaml:"outFile"`      // query code file name, default: gen.go
+	WithUnitTest        bool          `yaml:"withUnitTest"` // generate unit test for query code
+	UnitTestTemplate    string        `yaml:"unitTestTemplate"`
+	ModelPkgName        string        `yaml:"modelPkgName"`        // generated model code's package name
+	FieldNullable       bool          `yaml:"fieldNullable"`       // generate with pointer when field is nullable
+	FieldCoverable      bool          `yaml:"fieldCoverable"`      // generate with pointer when field has default value
+	FieldWithIndexTag   bool          `yaml:"fieldWithIndexTag"`   // generate field with gorm index tag
+	FieldWithTypeTag    bool          `yaml:"fieldWithTypeTag"`    // generate field with gorm column type tag
+	FieldWithDefaultTag bool          `yaml:"fieldWithDefaultTag"` // generate field with gorm default tag
+	FieldWithEnumType   bool          `yaml:"fieldWithEnumType"`   // generate named type with constants for enum column
+	FieldSignable       bool          `yaml:"fieldSignable"`       // detect integer field's unsigned type, adjust generated data type
+	VersionColumn       string        `yaml:"versionColumn"`       // column of optimistic locking version, like version or lock_version
+	WithDefaultQuery    bool          `yaml:"withDefaultQuery"`    // create default query in generated code
+	WithoutContext      bool          `yaml:"withoutContext"`      // generate code without context constrain
+	WithQueryInterface  bool          `yaml:"withQueryInterface"`  // generate code with exported interface object
+	WithGeneric         bool          `yaml:"withGeneric"`         // generate code with generic
+	Check               bool          `yaml:"check"`               // check generated code is up to date without writing files
+	Prune               bool          `yaml:"prune"`               // delete generated files of tables no longer generated
+	EditProtection      string        `yaml:"editProtection"`      // behavior when generated file was edited by hand: overwrite || abort || new
+
+	// declarative model options, only available in yaml config
+	DataTypeMap       map[string]string        `yaml:"dataTypeMap"`       // database type -> go type, like tinyint: bool
+	ImportPkgPaths    []string                 `yaml:"importPkgPaths"`    // import paths of types used in dataTypeMap and model options
+	ModelNameStrategy *NameStrategy            `yaml:"modelNameStrategy"` // model struct name strategy of table name, default case: camel
+	FileNameStrategy  *NameStrategy            `yaml:"fileNameStrategy"`  // model file name strategy of table name, default case: snake
+	JSONTagStrategy   string                   `yaml:"jsonTagStrategy"`   // json tag name strategy of column name: camel || lowerCamel || snake || origin
+	ModelOptions      *ModelOptions            `yaml:"modelOptions"`      // options applied to all models
+	TableOptions      map[string]*ModelOptions `yaml:"tableOptions"`      // options applied to model of table, take precedence over modelOptions
 }
 
 func (c *CmdParams) revise() *CmdParams {
@@ -70,18 +90,27 @@ func (c *CmdParams) revise() *CmdParams {
 		return c
 	}
 
-	tableList := make([]string, 0, len(c.Tables))
-	for _, tableName := range c.Tables {
-		_tableName := strings.TrimSpace(tableName) // trim leading and trailing space in tableName
-		if _tableName == "" {                      // skip empty tableName
+	tableList := make([]TableConfig, 0, len(c.Tables))
+	for _, table := range c.Tables {
+		table.Name = strings.TrimSpace(table.Name)                     // trim leading and trailing space in tableName
+		if table.Name == "" && table.Glob == "" && table.Regex == "" { // skip empty tableName
 			continue
 		}
-		tableList = append(tableList, _tableName)
+		tableList = append(tableList, table)
 	}
 	c.Tables = tableList
 	return c
 }
 
+// UnmarshalYAML accept a plain table name or a table config block
+func (c *TableConfig) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		return value.Decode(&c.Name)
+	}
+	type plain TableConfig
+	return value.Decode((*plain)(c))
+}
+
 // YamlConfig is yaml config struct
 type YamlConfig struct {
 	Version  string     `yaml:"version"`  //
@@ -110,20 +139,34 @@ func connectDB(t DBType, dsn string) (*gorm.DB, error) {
 	}
 }
 
+// parseEditProtection convert editProtection option to gen.EditProtection
+func parseEditProtection(name string) (gen.EditProtection, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "overwrite":
+		return gen.EditOverwrite, nil
+	case "abort":
+		return gen.EditAbort, nil
+	case "new":
+		return gen.EditWriteNew, nil
+	default:
+		return gen.EditOverwrite, fmt.Errorf("unknow editProtection %q (support overwrite || abort || new)", name)
+	}
+}
+
 // genModels is gorm/gen generated models
-func genModels(g *gen.Generator, db *gorm.DB, tables []string) (models []interface{}, err error) {
-	if len(tables) == 0 {
-		// Execute tasks for all tables in the database
-		tables, err = db.Migrator().GetTables()
-		if err != nil {
-			return nil, fmt.Errorf("GORM migrator get all tables fail: %w", err)
-		}
+func genModels(b *modelOptsBuilder) (models []interface{}, err error) {
+	tables, err := b.selectTables(b.g.GetTables)
+	if err != nil {
+		return nil, fmt.Errorf("get all tables fail: %w", err)
 	}
 
 	// Execute some data table tasks
 	models = make([]interface{}, len(tables))
 	for i, tableName := range tables {
-		models[i] = g.GenerateModel(tableName)
+		models[i], err = b.generateModel(tableName)
+		if err != nil {
+			return nil, err
+		}
 	}
 	return models, nil
 }
@@ -150,7 +193,12 @@ func argParse() *CmdParams {
 	genPath := flag.String("c", "", "is path for gen.yml")
 	dsn := flag.String("dsn", "", "consult[https://gorm.io/docs/connecting_to_the_database.html]")
 	db := flag.String("db", string(dbMySQL), "input mysql|postgres|sqlite|sqlserver|clickhouse. consult[https://gorm.io/docs/connecting_to_the_database.html]")
+	ddl := flag.String("ddl", "", "read tables from DDL files or directories of .sql files instead of database, separated by comma, dialect is given by -db")
 	tableList := flag.String("tables", "", "enter the required data table or leave it blank")
+	include := flag.String("include", "", "only generate tables matched by glob patterns, separated by comma, like \"user_*,order_*\"")
+	includeReg := flag.String("includeReg", "", "only generate tables matched by RegExp")
+	exclude := flag.String("exclude", "", "skip tables matched by glob patterns, separated by comma, like \"flyway_*,tmp_*\"")
+	excludeReg := flag.String("excludeReg", "", "skip tables matched by RegExp, like \"_p\\d+$\"")
 	onlyModel := flag.Bool("onlyModel", false, "only generate models (without query file)")
 	outPath := flag.String("outPath", defaultQueryPath, "specify a directory for output")
 	outFile := flag.String("outFile", "", "query code file name, default: gen.go")
@@ -162,11 +210,16 @@ func argParse() *CmdParams {
 	fieldWithIndexTag := flag.Bool("fieldWithIndexTag", false, "generate field with gorm index tag")
 	fieldWithTypeTag := flag.Bool("fieldWithTypeTag", false, "generate field with gorm column type tag")
 	fieldWithDefaultTag := flag.Bool("fieldWithDefaultTag", false, "generate field with gorm default tag")
+	fieldWithEnumType := flag.Bool("fieldWithEnumType", false, "generate named type with constants for enum column")
 	fieldSignable := flag.Bool("fieldSignable", false, "detect integer field's unsigned type, adjust generated data type")
+	versionColumn := flag.String("versionColumn", "", "column of optimistic locking version, like version or lock_version")
 	withDefaultQuery := flag.Bool("withDefaultQuery", false, "create default query in generated code")
 	withoutContext := flag.Bool("withoutContext", false, "generate code without context constrain")
 	withQueryInterface := flag.Bool("withQueryInterface", false, "generate code with exported interface object")
 	withGeneric := flag.Bool("withGeneric", false, "generate code with generic")
+	check := flag.Bool("check", false, "check generated code is up to date without writing files, exit 1 when stale")
+	prune := flag.Bool("prune", false, "delete generated files of tables no longer generated, hand-edited files are kept")
+	editProtection := flag.String("editProtection", "", "behavior when generated file was edited by hand: overwrite|abort|new (default overwrite)")
 
 	flag.Parse()
 
@@ -182,8 +235,25 @@ func argParse() *CmdParams {
 	if *db != "" {
 		cmdParse.DB = *db
 	}
+	if *ddl != "" {
+		cmdParse.DDL = strings.Split(*ddl, ",")
+	}
 	if *tableList != "" {
-		cmdParse.Tables = strings.Split(*tableList, ",")
+		for _, tableName := range strings.Split(*tableList, ",") {
+			cmdParse.Tables = append(cmdParse.Tables, TableConfig{Name: tableName})
+		}
+	}
+	if *include != "" {
+		cmdParse.Include = strings.Split(*include, ",")
+	}
+	if *includeReg != "" {
+		cmdParse.IncludeReg = []string{*includeReg}
+	}
+	if *exclude != "" {
+		cmdParse.Exclude = strings.Split(*exclude, ",")
+	}
+	if *excludeReg != "" {
+		cmdParse.ExcludeReg = []string{*excludeReg}
 	}
 	if *onlyModel {
 		cmdParse.OnlyModel = true
@@ -218,9 +288,15 @@ func argParse() *CmdParams {
 	if *fieldWithDefaultTag {
 		cmdParse.FieldWithDefaultTag = *fieldWithDefaultTag
 	}
+	if *fieldWithEnumType {
+		cmdParse.FieldWithEnumType = *fieldWithEnumType
+	}
 	if *fieldSignable {
 		cmdParse.FieldSignable = *fieldSignable
 	}
+	if *versionColumn != "" {
+		cmdParse.VersionColumn = *versionColumn
+	}
 	if *withDefaultQuery {
 		cmdParse.WithDefaultQuery = true
 	}
@@ -233,6 +309,15 @@ func argParse() *CmdParams {
 	if *withGeneric {
 		cmdParse.WithGeneric = true
 	}
+	if *check {
+		cmdParse.Check = true
+	}
+	if *prune {
+		cmdParse.Prune = true
+	}
+	if *editProtection != "" {
+		cmdParse.EditProtection = *editProtection
+	}
 
 	return &cmdParse
 }
@@ -244,9 +329,12 @@ func main() {
 		log.Fatalln("parse config fail")
 	}
 
-	db, err := connectDB(DBType(config.DB), config.DSN)
-	if err != nil {
-		log.Fatalln("connect db server fail:", err)
+	var db *gorm.DB
+	if len(config.DDL) == 0 {
+		var err error
+		if db, err = connectDB(DBType(config.DB), config.DSN); err != nil {
+			log.Fatalln("connect db server fail:", err)
+		}
 	}
 
 	var generateMode gen.GenerateMode
@@ -263,6 +351,11 @@ func main() {
 		generateMode |= gen.WithGeneric
 	}
 
+	protection, err := parseEditProtection(config.EditProtection)
+	if err != nil {
+		log.Fatalln("parse config fail:", err)
+	}
+
 	g := gen.NewGenerator(gen.Config{
 		OutPath:             config.OutPath,
 		OutFile:             config.OutFile,
@@ -274,13 +367,35 @@ func main() {
 		FieldWithIndexTag:   config.FieldWithIndexTag,
 		FieldWithTypeTag:    config.FieldWithTypeTag,
 		FieldWithDefaultTag: config.FieldWithDefaultTag,
+		FieldWithEnumType:   config.FieldWithEnumType,
 		FieldSignable:       config.FieldSignable,
+		VersionColumn:       config.VersionColumn,
+		Prune:               config.Prune,
+		EditProtection:      protection,
 		Mode:                generateMode,
 	})
 
-	g.UseDB(db)
+	var namer schema.Namer = schema.NamingStrategy{}
+	if db != nil {
+		g.UseDB(db)
+		namer = db.NamingStrategy
+	} else if err = g.UseDDL(config.DB, config.DDL...); err != nil {
+		log.Fatalln("read DDL fail:", err)
+	}
+
+	if err = config.configure(g); err != nil {
+		log.Fatalln("parse config fail:", err)
+	}
+	if err = config.applyTableFilters(g); err != nil {
+		log.Fatalln("parse config fail:", err)
+	}
+
+	builder, err := newModelOptsBuilder(g, namer, config)
+	if err != nil {
+		log.Fatalln("parse config fail:", err)
+	}
 
-	models, err := genModels(g, db, config.Tables)
+	models, err := genModels(builder)
 	if err != nil {
 		log.Fatalln("get tables info fail:", err)
 	}
@@ -289,5 +404,28 @@ func main() {
 		g.ApplyBasic(models...)
 	}
 
-	g.Execute()
+	if config.Check {
+		checkGenerated(g)
+		return
+	}
+
+	if err = g.ExecuteE(); err != nil {
+		log.Fatalln("generate code fail:\n" + err.Error())
+	}
+}
+
+// checkGenerated print the diff of stale generated files, exit 1 when any file would change
+func checkGenerated(g *gen.Generator) {
+	changes, err := g.DryRun()
+	if err != nil {
+		log.Fatalln("check generated code fail:", err)
+	}
+	if len(changes) == 0 {
+		return
+	}
+	for _, change := range changes {
+		fmt.Print(change.Diff)
+	}
+	log.Printf("generated code is stale: %d file(s) would change", len(changes))
+	os.Exit(1)
 }
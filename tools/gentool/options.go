@@ -0,0 +1,602 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+// ModelOptions is declarative model options, every entry is converted to gen.ModelOpt
+type ModelOptions struct {
+	Columns       map[string]ColumnOptions `yaml:"columns"`       // per-column overrides, keyed by column name
+	Ignore        []string                 `yaml:"ignore"`        // ignore columns by name
+	IgnoreReg     []string                 `yaml:"ignoreReg"`     // ignore columns by RegExp
+	TypeReg       map[string]string        `yaml:"typeReg"`       // column name RegExp -> field type
+	GenTypeReg    map[string]string        `yaml:"genTypeReg"`    // column name RegExp -> field type in query code, like Int64 || String || Field
+	TrimPrefix    string                   `yaml:"trimPrefix"`    // trim field name's prefix
+	TrimSuffix    string                   `yaml:"trimSuffix"`    // trim field name's suffix
+	AddPrefix     string                   `yaml:"addPrefix"`     // add prefix to field name
+	AddSuffix     string                   `yaml:"addSuffix"`     // add suffix to field name
+	TagStrategies map[string]string        `yaml:"tagStrategies"` // tag name -> name strategy of tag content, like json: lowerCamel
+	RemoveTags    []string                 `yaml:"removeTags"`    // remove tags from all fields, like json
+	NewFields     []NewFieldOptions        `yaml:"newFields"`     // add fields which are not columns of table
+	Relations     []RelationOptions        `yaml:"relations"`     // relate to other tables
+	Methods       []string                 `yaml:"methods"`       // add builtin methods to model: tableNameWithNamer
+}
+
+// ColumnOptions is overrides for a single column
+type ColumnOptions struct {
+	Ignore  bool              `yaml:"ignore"`  // ignore column
+	Name    string            `yaml:"name"`    // field name
+	Type    string            `yaml:"type"`    // field type
+	GenType string            `yaml:"genType"` // field type in query code, like Int64 || String || Field
+	Comment string            `yaml:"comment"` // field comment
+	JSONTag string            `yaml:"jsonTag"` // json tag content
+	Tags    map[string]string `yaml:"tags"`    // add or replace tags
+	GORMTag map[string]string `yaml:"gormTag"` // add or replace gorm tag settings, empty value means flag setting like `primaryKey`
+}
+
+// NewFieldOptions is a field to add to model
+type NewFieldOptions struct {
+	Name string            `yaml:"name"`
+	Type string            `yaml:"type"`
+	Tags map[string]string `yaml:"tags"`
+}
+
+// RelationOptions is a relation field to add to model
+type RelationOptions struct {
+	Type         string            `yaml:"type"`         // has_one || has_many || belongs_to || many_to_many
+	Field        string            `yaml:"field"`        // relation field name
+	Table        string            `yaml:"table"`        // related table
+	Pointer      bool              `yaml:"pointer"`      // generate field as *Model
+	Slice        bool              `yaml:"slice"`        // generate field as []Model
+	SlicePointer bool              `yaml:"slicePointer"` // generate field as []*Model
+	JSONTag      string            `yaml:"jsonTag"`      // json tag content
+	Tags         map[string]string `yaml:"tags"`         // other tags
+	GORMTag      map[string]string `yaml:"gormTag"`      // gorm tag settings, like foreignKey: UserID
+}
+
+// NameStrategy is declarative name strategy, applied in order: trim, case, prefix and suffix
+type NameStrategy struct {
+	Case       string `yaml:"case"`       // camel (UserName) || lowerCamel (userName) || snake (user_name) || origin
+	TrimPrefix string `yaml:"trimPrefix"` // trim prefix of origin name
+	TrimSuffix string `yaml:"trimSuffix"` // trim suffix of origin name
+	Prefix     string `yaml:"prefix"`     // add prefix
+	Suffix     string `yaml:"suffix"`     // add suffix
+}
+
+var nameCases = map[string]func(string) string{
+	"":       func(name string) string { return name },
+	"origin": func(name string) string { return name },
+	"camel":  func(name string) string { return schema.NamingStrategy{}.SchemaName(name) },
+	"lowerCamel": func(name string) string {
+		name = schema.NamingStrategy{}.SchemaName(name)
+		r, size := utf8.DecodeRuneInString(name)
+		return string(unicode.ToLower(r)) + name[size:]
+	},
+	"snake": func(name string) string { return schema.NamingStrategy{}.ColumnName("", name) },
+}
+
+var builtinMethods = map[string]interface{}{
+	"tableNameWithNamer": gen.DefaultMethodTableWithNamer,
+}
+
+// build return name strategy function, defaultCase is used when case is empty, nil means strategy not set
+func (s *NameStrategy) build(defaultCase string) (func(string) string, error) {
+	if s == nil {
+		return nil, nil
+	}
+	c := s.Case
+	if c == "" {
+		c = defaultCase
+	}
+	toCase, ok := nameCases[c]
+	if !ok {
+		return nil, fmt.Errorf("unknow name case %q (support camel || lowerCamel || snake || origin)", s.Case)
+	}
+	return func(name string) string {
+		name = strings.TrimSuffix(strings.TrimPrefix(name, s.TrimPrefix), s.TrimSuffix)
+		return s.Prefix + toCase(name) + s.Suffix
+	}, nil
+}
+
+// nameStrategyOf return name strategy function by case name
+func nameStrategyOf(name string) (func(string) string, error) {
+	return (&NameStrategy{Case: name}).build("")
+}
+
+// buildDataTypeMap convert database type -> go type mapping
+func buildDataTypeMap(typeMap map[string]string) map[string]func(columnType gorm.ColumnType) (dataType string) {
+	if len(typeMap) == 0 {
+		return nil
+	}
+	dataTypeMap := make(map[string]func(columnType gorm.ColumnType) (dataType string), len(typeMap))
+	for dbType, goType := range typeMap {
+		goType := goType
+		dataTypeMap[dbType] = func(gorm.ColumnType) string { return goType }
+	}
+	return dataTypeMap
+}
+
+// TableConfig is config of tables matched by name, glob or regex, a plain string is equivalent to a name
+type TableConfig struct {
+	Name              string        `yaml:"name"`              // exact table name
+	Glob              string        `yaml:"glob"`              // glob pattern of table name, like audit_*
+	Regex             string        `yaml:"regex"`             // RegExp of table name, like ^legacy_
+	ModelName         string        `yaml:"modelName"`         // model struct name, only work with name
+	FileName          string        `yaml:"fileName"`          // model file name without .gen.go, only work with name
+	ModelNameStrategy *NameStrategy `yaml:"modelNameStrategy"` // model struct name strategy of matched tables
+	FileNameStrategy  *NameStrategy `yaml:"fileNameStrategy"`  // model file name strategy of matched tables
+	SubPackage        string        `yaml:"subPackage"`        // generate models into sub package of model package, like audit
+	ModelOptions      `yaml:",inline"`
+
+	regex   *regexp.Regexp
+	modelNS func(string) string
+	fileNS  func(string) string
+}
+
+// compile check matcher and build name strategies
+func (c *TableConfig) compile() (err error) {
+	matchers := 0
+	for _, m := range []string{c.Name, c.Glob, c.Regex} {
+		if m != "" {
+			matchers++
+		}
+	}
+	if matchers != 1 {
+		return fmt.Errorf("exactly one of name, glob and regex is required")
+	}
+	if c.Name == "" && (c.ModelName != "" || c.FileName != "") {
+		return fmt.Errorf("modelName and fileName only work with name, use modelNameStrategy and fileNameStrategy instead")
+	}
+	if c.Glob != "" {
+		if _, err = path.Match(c.Glob, ""); err != nil {
+			return fmt.Errorf("glob %q: %w", c.Glob, err)
+		}
+	}
+	if c.Regex != "" {
+		if c.regex, err = regexp.Compile(c.Regex); err != nil {
+			return fmt.Errorf("regex %q: %w", c.Regex, err)
+		}
+	}
+	if c.modelNS, err = c.ModelNameStrategy.build("camel"); err != nil {
+		return fmt.Errorf("modelNameStrategy: %w", err)
+	}
+	if c.fileNS, err = c.FileNameStrategy.build("snake"); err != nil {
+		return fmt.Errorf("fileNameStrategy: %w", err)
+	}
+	return nil
+}
+
+// isPattern report whether table config match tables by glob or regex
+func (c *TableConfig) isPattern() bool { return c.Name == "" }
+
+// match report whether table config match table name
+func (c *TableConfig) match(tableName string) bool {
+	switch {
+	case c.Name != "":
+		return c.Name == tableName
+	case c.Glob != "":
+		ok, _ := path.Match(c.Glob, tableName)
+		return ok
+	default:
+		return c.regex.MatchString(tableName)
+	}
+}
+
+// configure apply declarative type map, import paths, file name and json tag strategies to generator
+func (c *CmdParams) configure(g *gen.Generator) error {
+	if dataTypeMap := buildDataTypeMap(c.DataTypeMap); dataTypeMap != nil {
+		g.WithDataTypeMap(dataTypeMap)
+	}
+	if len(c.ImportPkgPaths) > 0 {
+		g.WithImportPkgPath(c.ImportPkgPaths...)
+	}
+
+	fileNS, err := c.FileNameStrategy.build("snake")
+	if err != nil {
+		return fmt.Errorf("fileNameStrategy: %w", err)
+	}
+	if fileNS != nil {
+		g.WithFileNameStrategy(fileNS)
+	}
+	if c.JSONTagStrategy != "" {
+		jsonNS, err := nameStrategyOf(c.JSONTagStrategy)
+		if err != nil {
+			return fmt.Errorf("jsonTagStrategy: %w", err)
+		}
+		g.WithJSONTagNameStrategy(jsonNS)
+	}
+	return nil
+}
+
+// applyTableFilters check and apply include and exclude filters of tables in database
+func (c *CmdParams) applyTableFilters(g *gen.Generator) error {
+	for _, pattern := range append(append([]string{}, c.Include...), c.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("table pattern %q: %w", pattern, err)
+		}
+	}
+	for _, reg := range append(append([]string{}, c.IncludeReg...), c.ExcludeReg...) {
+		if _, err := regexp.Compile(reg); err != nil {
+			return fmt.Errorf("table RegExp %q: %w", reg, err)
+		}
+	}
+	g.WithTableIncludes(c.Include...)
+	g.WithTableIncludesReg(c.IncludeReg...)
+	g.WithTableExcludes(c.Exclude...)
+	g.WithTableExcludesReg(c.ExcludeReg...)
+	return nil
+}
+
+// relateFunc build relation field option to a generated model
+type relateFunc func(relType field.RelationshipType, fieldName string, config *field.RelateConfig) gen.ModelOpt
+
+// modelOptsBuilder convert declarative model options of global, tableOptions and tables blocks to gen.ModelOpt
+type modelOptsBuilder struct {
+	g       *gen.Generator
+	namer   schema.Namer // name strategy of database connection
+	global  *ModelOptions
+	tables  map[string]*ModelOptions
+	blocks  []TableConfig
+	modelNS func(string) string // global model name strategy
+
+	relates map[string]relateFunc // relate to generated models by table name
+}
+
+func newModelOptsBuilder(g *gen.Generator, namer schema.Namer, c *CmdParams) (*modelOptsBuilder, error) {
+	modelNS, err := c.ModelNameStrategy.build("camel")
+	if err != nil {
+		return nil, fmt.Errorf("modelNameStrategy: %w", err)
+	}
+	for i := range c.Tables {
+		if err := c.Tables[i].compile(); err != nil {
+			return nil, fmt.Errorf("tables[%d]: %w", i, err)
+		}
+	}
+	return &modelOptsBuilder{
+		g:       g,
+		namer:   namer,
+		global:  c.ModelOptions,
+		tables:  c.TableOptions,
+		blocks:  c.Tables,
+		modelNS: modelNS,
+		relates: make(map[string]relateFunc),
+	}, nil
+}
+
+// selectTables return tables to generate: tables given by name, and tables in database matched by glob or regex.
+// all tables in database are generated when no table is configured, tables in database are filtered by include and exclude
+func (b *modelOptsBuilder) selectTables(getTables func() ([]string, error)) ([]string, error) {
+	allTables := func() ([]string, error) {
+		tables, err := getTables()
+		if err != nil {
+			return nil, err
+		}
+		result := make([]string, 0, len(tables))
+		for _, tableName := range tables {
+			if b.g.MatchTable(tableName) {
+				result = append(result, tableName)
+			}
+		}
+		return result, nil
+	}
+	if len(b.blocks) == 0 {
+		return allTables()
+	}
+
+	tables := make([]string, 0, len(b.blocks))
+	selected := make(map[string]bool, len(b.blocks))
+	hasPattern := false
+	for _, c := range b.blocks {
+		if c.isPattern() {
+			hasPattern = true
+			continue
+		}
+		if !selected[c.Name] {
+			selected[c.Name] = true
+			tables = append(tables, c.Name)
+		}
+	}
+	if !hasPattern {
+		return tables, nil
+	}
+
+	all, err := allTables()
+	if err != nil {
+		return nil, err
+	}
+	for _, tableName := range all {
+		if selected[tableName] {
+			continue
+		}
+		for _, c := range b.blocks {
+			if c.isPattern() && c.match(tableName) {
+				selected[tableName] = true
+				tables = append(tables, tableName)
+				break
+			}
+		}
+	}
+	return tables, nil
+}
+
+// blockOf return the first tables block matching table
+func (b *modelOptsBuilder) blockOf(tableName string) *TableConfig {
+	for i := range b.blocks {
+		if b.blocks[i].match(tableName) {
+			return &b.blocks[i]
+		}
+	}
+	return nil
+}
+
+// optsFor return model options of table, applied in order: modelOptions, tableOptions and tables block,
+// so the latter take precedence
+func (b *modelOptsBuilder) optsFor(tableName string, block *TableConfig, withRelation bool) ([]gen.ModelOpt, error) {
+	sources := []*ModelOptions{b.global, b.tables[tableName]}
+	if block != nil {
+		sources = append(sources, &block.ModelOptions)
+	}
+
+	opts := make([]gen.ModelOpt, 0, 8)
+	for _, o := range sources {
+		if o == nil {
+			continue
+		}
+		modelOpts, err := b.build(o, withRelation)
+		if err != nil {
+			return nil, fmt.Errorf("table %q: %w", tableName, err)
+		}
+		opts = append(opts, modelOpts...)
+	}
+
+	if block == nil {
+		return opts, nil
+	}
+	switch {
+	case block.FileName != "":
+		opts = append(opts, gen.WithFileName(block.FileName))
+	case block.fileNS != nil:
+		opts = append(opts, gen.WithFileName(block.fileNS(tableName)))
+	}
+	if block.SubPackage != "" {
+		opts = append(opts, gen.WithModelSubPkg(block.SubPackage))
+	}
+	return opts, nil
+}
+
+// modelNameOf return model struct name of table
+func (b *modelOptsBuilder) modelNameOf(tableName string, block *TableConfig) string {
+	switch {
+	case block != nil && block.ModelName != "":
+		return block.ModelName
+	case block != nil && block.modelNS != nil:
+		return block.modelNS(tableName)
+	case b.modelNS != nil:
+		return b.modelNS(tableName)
+	default:
+		return b.namer.SchemaName(tableName)
+	}
+}
+
+// generate generate model of table through GenerateModelAs, return nil if table is ignored
+func (b *modelOptsBuilder) generate(tableName string, withRelation bool) (interface{}, relateFunc, error) {
+	block := b.blockOf(tableName)
+	opts, err := b.optsFor(tableName, block, withRelation)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := b.g.GenerateModelAs(tableName, b.modelNameOf(tableName, block), opts...)
+	if m == nil {
+		return nil, nil, nil
+	}
+	return m, func(relType field.RelationshipType, fieldName string, config *field.RelateConfig) gen.ModelOpt {
+		return gen.FieldRelate(relType, fieldName, m, config)
+	}, nil
+}
+
+// generateModel generate model of table with its declarative options
+func (b *modelOptsBuilder) generateModel(tableName string) (interface{}, error) {
+	m, relate, err := b.generate(tableName, true)
+	if err != nil || m == nil {
+		return nil, err
+	}
+	b.relates[tableName] = relate
+	return m, nil
+}
+
+// relateTo return relate function of table, generate the model without relations if not generated yet
+func (b *modelOptsBuilder) relateTo(tableName string) (relateFunc, error) {
+	if relate, ok := b.relates[tableName]; ok {
+		return relate, nil
+	}
+	m, relate, err := b.generate(tableName, false)
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return nil, fmt.Errorf("related table %q is ignored", tableName)
+	}
+	return relate, nil
+}
+
+func (b *modelOptsBuilder) build(o *ModelOptions, withRelation bool) (opts []gen.ModelOpt, err error) {
+	if len(o.Ignore) > 0 {
+		opts = append(opts, gen.FieldIgnore(o.Ignore...))
+	}
+	for _, reg := range o.IgnoreReg {
+		if _, err := regexp.Compile(reg); err != nil {
+			return nil, fmt.Errorf("ignoreReg %q: %w", reg, err)
+		}
+	}
+	if len(o.IgnoreReg) > 0 {
+		opts = append(opts, gen.FieldIgnoreReg(o.IgnoreReg...))
+	}
+	for _, reg := range sortedKeys(o.TypeReg) {
+		if _, err := regexp.Compile(reg); err != nil {
+			return nil, fmt.Errorf("typeReg %q: %w", reg, err)
+		}
+		opts = append(opts, gen.FieldTypeReg(reg, o.TypeReg[reg]))
+	}
+	for _, reg := range sortedKeys(o.GenTypeReg) {
+		if _, err := regexp.Compile(reg); err != nil {
+			return nil, fmt.Errorf("genTypeReg %q: %w", reg, err)
+		}
+		opts = append(opts, gen.FieldGenTypeReg(reg, o.GenTypeReg[reg]))
+	}
+
+	for _, name := range sortedKeys(o.Columns) {
+		opts = append(opts, columnOpts(name, o.Columns[name])...)
+	}
+
+	if o.TrimPrefix != "" {
+		opts = append(opts, gen.FieldTrimPrefix(o.TrimPrefix))
+	}
+	if o.TrimSuffix != "" {
+		opts = append(opts, gen.FieldTrimSuffix(o.TrimSuffix))
+	}
+	if o.AddPrefix != "" {
+		opts = append(opts, gen.FieldAddPrefix(o.AddPrefix))
+	}
+	if o.AddSuffix != "" {
+		opts = append(opts, gen.FieldAddSuffix(o.AddSuffix))
+	}
+	for _, tagName := range sortedKeys(o.TagStrategies) {
+		ns, err := nameStrategyOf(o.TagStrategies[tagName])
+		if err != nil {
+			return nil, fmt.Errorf("tagStrategies %q: %w", tagName, err)
+		}
+		opts = append(opts, gen.FieldNewTagWithNS(tagName, ns))
+	}
+
+	if len(o.RemoveTags) > 0 {
+		tags := o.RemoveTags
+		opts = append(opts, gen.FieldModify(func(f gen.Field) gen.Field {
+			for _, tag := range tags {
+				f.Tag.Remove(tag)
+			}
+			return f
+		}))
+	}
+
+	for _, f := range o.NewFields {
+		if f.Name == "" || f.Type == "" {
+			return nil, fmt.Errorf("newFields: name and type are required")
+		}
+		opts = append(opts, gen.FieldNew(f.Name, f.Type, field.Tag(f.Tags)))
+	}
+	if withRelation {
+		for _, rel := range o.Relations {
+			opt, err := b.relationOpt(rel)
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, opt)
+		}
+	}
+	for _, name := range o.Methods {
+		method, ok := builtinMethods[name]
+		if !ok {
+			return nil, fmt.Errorf("unknow method %q (support tableNameWithNamer)", name)
+		}
+		opts = append(opts, gen.WithMethod(method))
+	}
+	return opts, nil
+}
+
+// columnOpts convert per-column overrides
+func columnOpts(columnName string, c ColumnOptions) (opts []gen.ModelOpt) {
+	if c.Ignore {
+		return []gen.ModelOpt{gen.FieldIgnore(columnName)}
+	}
+	if c.Name != "" {
+		opts = append(opts, gen.FieldRename(columnName, c.Name))
+	}
+	if c.Type != "" {
+		opts = append(opts, gen.FieldType(columnName, c.Type))
+	}
+	if c.GenType != "" {
+		opts = append(opts, gen.FieldGenType(columnName, c.GenType))
+	}
+	if c.Comment != "" {
+		opts = append(opts, gen.FieldComment(columnName, c.Comment))
+	}
+	if c.JSONTag != "" {
+		opts = append(opts, gen.FieldJSONTag(columnName, c.JSONTag))
+	}
+	if len(c.Tags) > 0 {
+		opts = append(opts, gen.FieldNewTag(columnName, field.Tag(c.Tags)))
+	}
+	if len(c.GORMTag) > 0 {
+		settings := c.GORMTag
+		opts = append(opts, gen.FieldGORMTag(columnName, func(tag field.GormTag) field.GormTag {
+			return setGORMTag(tag, settings)
+		}))
+	}
+	return opts
+}
+
+// relationOpt convert relation to FieldRelate option
+func (b *modelOptsBuilder) relationOpt(rel RelationOptions) (gen.ModelOpt, error) {
+	relType := field.RelationshipType(rel.Type)
+	switch relType {
+	case field.HasOne, field.HasMany, field.BelongsTo, field.Many2Many:
+	default:
+		return nil, fmt.Errorf("unknow relation type %q (support has_one || has_many || belongs_to || many_to_many)", rel.Type)
+	}
+	if rel.Field == "" || rel.Table == "" {
+		return nil, fmt.Errorf("relation %s: field and table are required", rel.Type)
+	}
+
+	relate, err := b.relateTo(rel.Table)
+	if err != nil {
+		return nil, fmt.Errorf("relation %s: %w", rel.Field, err)
+	}
+	config := &field.RelateConfig{
+		RelatePointer:      rel.Pointer,
+		RelateSlice:        rel.Slice,
+		RelateSlicePointer: rel.SlicePointer,
+		JSONTag:            rel.JSONTag,
+		Tag:                field.Tag(rel.Tags),
+	}
+	if len(rel.GORMTag) > 0 {
+		config.GORMTag = setGORMTag(nil, rel.GORMTag)
+	}
+	return relate(relType, rel.Field, config), nil
+}
+
+// setGORMTag add or replace gorm tag settings
+func setGORMTag(tag field.GormTag, settings map[string]string) field.GormTag {
+	if tag == nil {
+		tag = field.GormTag{}
+	}
+	for _, k := range sortedKeys(settings) {
+		if v := settings[k]; v != "" {
+			tag.Set(k, v)
+		} else {
+			tag.Set(k)
+		}
+	}
+	return tag
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+	"gorm.io/gorm/utils/tests"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+func newTestBuilder(t *testing.T, c *CmdParams) *modelOptsBuilder {
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	ddl := filepath.Join(t.TempDir(), "schema.sql")
+	if err = os.WriteFile(ddl, []byte("CREATE TABLE users (id bigint PRIMARY KEY, name varchar(64));\nCREATE TABLE posts (id bigint PRIMARY KEY, user_id bigint);"), 0640); err != nil {
+		t.Fatalf("write DDL: %v", err)
+	}
+
+	g := gen.NewGenerator(gen.Config{OutPath: filepath.Join(t.TempDir(), "query")})
+	g.UseDB(db)
+	if err = g.UseDDL("mysql", ddl); err != nil {
+		t.Fatalf("use DDL: %v", err)
+	}
+	if err = c.applyTableFilters(g); err != nil {
+		t.Fatalf("apply table filters: %v", err)
+	}
+	b, err := newModelOptsBuilder(g, schema.NamingStrategy{}, c)
+	if err != nil {
+		t.Fatalf("new model options builder: %v", err)
+	}
+	return b
+}
+
+func TestNameStrategy(t *testing.T) {
+	testCases := []struct {
+		strategy    *NameStrategy
+		defaultCase string
+		input       string
+		expect      string
+	}{
+		{strategy: &NameStrategy{}, input: "user_info", expect: "user_info"},
+		{strategy: &NameStrategy{Case: "origin"}, input: "user_info", expect: "user_info"},
+		{strategy: &NameStrategy{Case: "camel"}, input: "user_info", expect: "UserInfo"},
+		{strategy: &NameStrategy{Case: "lowerCamel"}, input: "user_info", expect: "userInfo"},
+		{strategy: &NameStrategy{Case: "snake"}, input: "UserInfo", expect: "user_info"},
+		{strategy: &NameStrategy{}, defaultCase: "camel", input: "user_info", expect: "UserInfo"},
+		{strategy: &NameStrategy{Case: "camel", TrimPrefix: "t_", TrimSuffix: "_tab"}, input: "t_user_tab", expect: "User"},
+		{strategy: &NameStrategy{Case: "snake", Prefix: "m_", Suffix: "_gen"}, input: "User", expect: "m_user_gen"},
+	}
+	for _, tc := range testCases {
+		ns, err := tc.strategy.build(tc.defaultCase)
+		if err != nil {
+			t.Fatalf("build name strategy %+v fail: %v", tc.strategy, err)
+		}
+		if got := ns(tc.input); got != tc.expect {
+			t.Errorf("name strategy %+v of %q expects %q, got %q", tc.strategy, tc.input, tc.expect, got)
+		}
+	}
+
+	if ns, err := (*NameStrategy)(nil).build("camel"); ns != nil || err != nil {
+		t.Errorf("nil name strategy expects nil function, got %v", err)
+	}
+	if _, err := nameStrategyOf("kebab"); err == nil {
+		t.Errorf("unknown name case expects error")
+	}
+}
+
+func TestTableConfig_compile(t *testing.T) {
+	testCases := []struct {
+		config  TableConfig
+		wantErr bool
+	}{
+		{config: TableConfig{Name: "users", ModelName: "Account"}},
+		{config: TableConfig{Glob: "audit_*"}},
+		{config: TableConfig{Regex: `^legacy_\d+$`}},
+		{config: TableConfig{}, wantErr: true},
+		{config: TableConfig{Name: "users", Glob: "audit_*"}, wantErr: true},
+		{config: TableConfig{Glob: "audit_[", ModelName: "Audit"}, wantErr: true},
+		{config: TableConfig{Glob: "audit_["}, wantErr: true},
+		{config: TableConfig{Regex: "legacy_("}, wantErr: true},
+		{config: TableConfig{Glob: "audit_*", ModelNameStrategy: &NameStrategy{Case: "kebab"}}, wantErr: true},
+	}
+	for _, tc := range testCases {
+		if err := tc.config.compile(); (err != nil) != tc.wantErr {
+			t.Errorf("compile %+v expects error %v, got %v", tc.config, tc.wantErr, err)
+		}
+	}
+}
+
+func TestModelOptsBuilder_blockOf(t *testing.T) {
+	b := newTestBuilder(t, &CmdParams{Tables: []TableConfig{
+		{Name: "audit_users"},
+		{Glob: "audit_*"},
+		{Regex: `^legacy_\d+$`},
+	}})
+
+	testCases := []struct {
+		tableName string
+		expect    int // index of matched block, -1 if none
+	}{
+		{tableName: "audit_users", expect: 0},
+		{tableName: "audit_logs", expect: 1},
+		{tableName: "legacy_01", expect: 2},
+		{tableName: "legacy_x", expect: -1},
+		{tableName: "users", expect: -1},
+	}
+	for _, tc := range testCases {
+		block := b.blockOf(tc.tableName)
+		switch {
+		case tc.expect < 0 && block != nil:
+			t.Errorf("table %s expects no block, got %+v", tc.tableName, block)
+		case tc.expect >= 0 && block != &b.blocks[tc.expect]:
+			t.Errorf("table %s expects block %d, got %+v", tc.tableName, tc.expect, block)
+		}
+	}
+}
+
+func TestModelOptsBuilder_selectTables(t *testing.T) {
+	dbTables := []string{"users", "audit_logs", "audit_users", "legacy_01", "flyway_schema_history"}
+	errDB := errors.New("db is unavailable")
+
+	testCases := []struct {
+		name   string
+		params CmdParams
+		tables []string
+		err    error
+		expect []string
+	}{
+		{
+			name:   "all tables",
+			params: CmdParams{Exclude: []string{"flyway_*"}},
+			tables: dbTables,
+			expect: []string{"users", "audit_logs", "audit_users", "legacy_01"},
+		},
+		{
+			name:   "names only",
+			params: CmdParams{Tables: []TableConfig{{Name: "posts"}, {Name: "users"}, {Name: "posts"}}},
+			err:    errDB, // tables in database are not needed
+			expect: []string{"posts", "users"},
+		},
+		{
+			name:   "names and patterns",
+			params: CmdParams{Tables: []TableConfig{{Name: "audit_users"}, {Glob: "audit_*"}, {Regex: `^legacy_\d+$`}}},
+			tables: dbTables,
+			expect: []string{"audit_users", "audit_logs", "legacy_01"},
+		},
+		{
+			name:   "patterns filtered",
+			params: CmdParams{Tables: []TableConfig{{Glob: "audit_*"}}, ExcludeReg: []string{"_users$"}},
+			tables: dbTables,
+			expect: []string{"audit_logs"},
+		},
+		{
+			name:   "database error",
+			params: CmdParams{Tables: []TableConfig{{Glob: "audit_*"}}},
+			err:    errDB,
+		},
+	}
+	for _, tc := range testCases {
+		params := tc.params
+		b := newTestBuilder(t, &params)
+		tables, err := b.selectTables(func() ([]string, error) { return tc.tables, tc.err })
+		if tc.expect == nil {
+			if !errors.Is(err, tc.err) {
+				t.Errorf("%s: expects error %v, got %v", tc.name, tc.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: select tables fail: %v", tc.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(tables, tc.expect) {
+			t.Errorf("%s: expects tables %v, got %v", tc.name, tc.expect, tables)
+		}
+	}
+}
+
+func TestModelOptsBuilder_relateTo(t *testing.T) {
+	b := newTestBuilder(t, &CmdParams{TableOptions: map[string]*ModelOptions{
+		"posts": {IgnoreReg: []string{"("}},
+	}})
+
+	if _, err := b.generateModel("users"); err != nil {
+		t.Fatalf("generate model users fail: %v", err)
+	}
+	generated := reflect.ValueOf(b.relates["users"]).Pointer()
+	relate, err := b.relateTo("users")
+	if err != nil {
+		t.Fatalf("relate to users fail: %v", err)
+	}
+	if reflect.ValueOf(relate).Pointer() != generated {
+		t.Errorf("relate to generated model expects to reuse it")
+	}
+	if relate(field.HasOne, "User", nil) == nil {
+		t.Errorf("relate function expects model option")
+	}
+
+	if _, err = b.relateTo("posts"); err == nil {
+		t.Errorf("relate to table with invalid options expects error")
+	}
+	if _, ok := b.relates["posts"]; ok {
+		t.Errorf("model generated for relation should not be cached as generated model")
+	}
+}
+
+func TestSetGORMTag(t *testing.T) {
+	testCases := []struct {
+		tag      field.GormTag
+		settings map[string]string
+		expect   field.GormTag
+	}{
+		{
+			settings: map[string]string{"foreignKey": "UserID", "constraint": "OnDelete:CASCADE"},
+			expect:   field.GormTag{"foreignKey": {"UserID"}, "constraint": {"OnDelete:CASCADE"}},
+		},
+		{
+			tag:      field.GormTag{"column": {"id"}, "type": {"bigint"}},
+			settings: map[string]string{"type": "int", "primaryKey": ""},
+			expect:   field.GormTag{"column": {"id"}, "type": {"int"}, "primaryKey": nil},
+		},
+		{
+			tag:    field.GormTag{"column": {"id"}},
+			expect: field.GormTag{"column": {"id"}},
+		},
+	}
+	for _, tc := range testCases {
+		if got := setGORMTag(tc.tag, tc.settings); !reflect.DeepEqual(got, tc.expect) {
+			t.Errorf("set gorm tag %v to %v expects %v, got %v", tc.settings, tc.tag, tc.expect, got)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package gen
+
+import (
+	"gorm.io/gorm/clause"
+
+	"gorm.io/gen/field"
+)
+
+// Upsert typed builder of ON CONFLICT clause, created by OnConflict, action of conflict must be specified
+// by DoUpdates, DoNothing or UpdateAll before Create/CreateInBatches
+//
+//	u.OnConflict(u.Email).DoUpdates(u.LoginCount.Add(1), u.Name.Value("new")).Create(&user)
+type Upsert[T any] struct {
+	do      *DO
+	wrap    func(Dao) T
+	columns []clause.Column
+}
+
+// NewUpsert create upsert builder of do on conflict columns, wrap convert result Dao to query type
+func NewUpsert[T any](do *DO, wrap func(Dao) T, cols ...field.Expr) Upsert[T] {
+	columns := make([]clause.Column, len(cols))
+	for i, col := range cols {
+		columns[i] = clause.Column{Name: string(col.ColumnName())}
+	}
+	return Upsert[T]{do: do, wrap: wrap, columns: columns}
+}
+
+// DoUpdates update columns by assign expressions on conflict, like counter = counter + 1 by u.Counter.Add(1)
+func (u Upsert[T]) DoUpdates(assigns ...field.AssignExpr) T {
+	set := u.do.assignSetWithoutAutoUpdate(assigns)
+	for i := range set {
+		set[i].Column.Table = ""
+		set[i].Value = assignValue{set[i].Value}
+	}
+	return u.with(clause.OnConflict{Columns: u.columns, DoUpdates: set})
+}
+
+// DoNothing ignore rows on conflict
+func (u Upsert[T]) DoNothing() T {
+	return u.with(clause.OnConflict{Columns: u.columns, DoNothing: true})
+}
+
+// UpdateAll update all columns except primary keys to new values on conflict
+func (u Upsert[T]) UpdateAll() T {
+	return u.with(clause.OnConflict{Columns: u.columns, UpdateAll: true})
+}
+
+func (u Upsert[T]) with(c clause.OnConflict) T {
+	return u.wrap(u.do.Clauses(c))
+}
+
+// assignValue value of ON CONFLICT assignment built from field.AssignExpr, which contains only columns and bound vars
+type assignValue struct{ value interface{} }
+
+// Build build value as var
+func (v assignValue) Build(builder clause.Builder) {
+	builder.AddVar(builder, v.value)
+}
@@ -0,0 +1,82 @@
+package gen
+
+import (
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen/field"
+)
+
+// UpdateWithVersion update all columns of model with optimistic locking, row is updated only if its version column
+// equals to version of model, then version is increased by 1. ErrStaleObject is returned if no row is updated
+func (d *DO) UpdateWithVersion(versionColumn string, model interface{}) (info ResultInfo, err error) {
+	d, done := d.Observe("", "UpdateWithVersion")
+	info, err = d.updateWithVersion(versionColumn, model, func(stmt *gorm.Statement, version *schema.Field) clause.Set {
+		stmt = stmt.DB.Session(&gorm.Session{}).Model(model).Select("*").Omit(version.DBName).Statement
+		stmt.Dest = model
+		stmt.ReflectValue = reflect.Indirect(reflect.ValueOf(model))
+		return callbacks.ConvertToAssignments(stmt)
+	})
+	return info, done(err)
+}
+
+// UpdatesWithVersion update columns of the row of model with optimistic locking, see UpdateWithVersion
+func (d *DO) UpdatesWithVersion(versionColumn string, model interface{}, columns ...field.AssignExpr) (info ResultInfo, err error) {
+	d, done := d.Observe("", "UpdatesWithVersion")
+	info, err = d.updateWithVersion(versionColumn, model, func(*gorm.Statement, *schema.Field) clause.Set {
+		return d.assignSet(columns)
+	})
+	return info, done(err)
+}
+
+func (d *DO) updateWithVersion(versionColumn string, model interface{}, assignments func(*gorm.Statement, *schema.Field) clause.Set) (info ResultInfo, err error) {
+	tx := d.prepareTx().Model(model)
+	stmt := tx.Statement
+	if err = stmt.Parse(model); err != nil {
+		return ResultInfo{Error: err}, err
+	}
+	version := stmt.Schema.LookUpField(versionColumn)
+	if version == nil {
+		err = fmt.Errorf("gen: version column %s is not field of %s", versionColumn, stmt.Schema.Name)
+		return ResultInfo{Error: err}, err
+	}
+
+	modelValue := reflect.Indirect(reflect.ValueOf(model))
+	conds := make([]clause.Expression, 0, len(stmt.Schema.PrimaryFields)+1)
+	for _, pk := range stmt.Schema.PrimaryFields {
+		if value, isZero := pk.ValueOf(stmt.Context, modelValue); !isZero {
+			conds = append(conds, clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: pk.DBName}, Value: value})
+		}
+	}
+	if len(conds) == 0 {
+		err = fmt.Errorf("gen: primary key of %s is required to update with version", stmt.Schema.Name)
+		return ResultInfo{Error: err}, err
+	}
+	current, _ := version.ValueOf(stmt.Context, modelValue)
+	conds = append(conds, clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: version.DBName}, Value: current})
+	set := append(assignments(stmt, version), clause.Assignment{
+		Column: clause.Column{Name: version.DBName},
+		Value:  clause.Expr{SQL: "? + 1", Vars: []interface{}{clause.Column{Name: version.DBName}}},
+	})
+
+	result := tx.Clauses(clause.Where{Exprs: conds}, set).Omit("*").Updates(map[string]interface{}{})
+	if result.Error != nil {
+		return ResultInfo{RowsAffected: result.RowsAffected, Error: result.Error}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ResultInfo{Error: ErrStaleObject}, ErrStaleObject
+	}
+
+	v := reflect.Indirect(version.ReflectValueOf(stmt.Context, modelValue))
+	if v.CanInt() {
+		v.SetInt(v.Int() + 1)
+	} else if v.CanUint() {
+		v.SetUint(v.Uint() + 1)
+	}
+	return ResultInfo{RowsAffected: result.RowsAffected}, nil
+}
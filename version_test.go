@@ -0,0 +1,70 @@
+package gen
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/utils/tests"
+
+	"gorm.io/gen/field"
+)
+
+type versionModel struct {
+	ID        uint
+	Name      string
+	Version   int
+	UpdatedAt time.Time
+}
+
+func TestDO_UpdateWithVersion(t *testing.T) {
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	var rowsAffected int64
+	_ = db.Callback().Update().After("gorm:update").Register("test:rows_affected", func(db *gorm.DB) { db.RowsAffected = rowsAffected })
+
+	l := &captureLogger{}
+	var d DO
+	d.UseDB(db.Session(&gorm.Session{DryRun: true, NewDB: true, Logger: l}))
+	d.UseModel(versionModel{})
+
+	user := &versionModel{ID: 1, Name: "a", Version: 3}
+	rowsAffected = 1
+	if _, err := d.UpdateWithVersion("version", user); err != nil {
+		t.Fatalf("UpdateWithVersion fail: %v", err)
+	}
+	if expect := "UPDATE `version_models` SET `name`=\"a\",`updated_at`=\"" + user.UpdatedAt.Format("2006-01-02 15:04:05.999") + "\",`version`=`version` + 1 WHERE `version_models`.`id` = 1 AND `version_models`.`version` = 3"; l.lastSQL != expect {
+		t.Errorf("SQL expects %s, got %s", expect, l.lastSQL)
+	}
+	if user.Version != 4 {
+		t.Errorf("version of model should be increased to 4, got %d", user.Version)
+	}
+
+	if _, err := d.UpdatesWithVersion("version", user, field.NewString("", "name").Value("b")); err != nil {
+		t.Fatalf("UpdatesWithVersion fail: %v", err)
+	}
+	if expect := "UPDATE `version_models` SET `name`=\"b\",`updated_at`="; len(l.lastSQL) < len(expect) || l.lastSQL[:len(expect)] != expect {
+		t.Errorf("SQL expects prefix %s, got %s", expect, l.lastSQL)
+	}
+	if expect := ",`version`=`version` + 1 WHERE `version_models`.`id` = 1 AND `version_models`.`version` = 4"; len(l.lastSQL) < len(expect) || l.lastSQL[len(l.lastSQL)-len(expect):] != expect {
+		t.Errorf("SQL expects suffix %s, got %s", expect, l.lastSQL)
+	}
+
+	rowsAffected = 0
+	if _, err := d.UpdatesWithVersion("version", user, field.NewString("", "name").Value("c")); !errors.Is(err, ErrStaleObject) {
+		t.Errorf("expect ErrStaleObject, got %v", err)
+	}
+	if user.Version != 5 {
+		t.Errorf("version of stale model should not be changed, got %d", user.Version)
+	}
+
+	if _, err := d.UpdateWithVersion("lock_version", user); err == nil {
+		t.Errorf("expect error of unknown version column")
+	}
+	if _, err := d.UpdateWithVersion("version", &versionModel{Version: 1}); err == nil {
+		t.Errorf("expect error of model without primary key")
+	}
+}